@@ -0,0 +1,13 @@
+# Verify that the registrar delegated the zone to the hosting.de nameservers.
+data "hostingde_delegation_status" "example" {
+  zone_name = "example.test"
+  resolver  = "1.1.1.1"
+}
+
+output "delegated" {
+  value = data.hostingde_delegation_status.example.delegated
+}
+
+output "missing_nameservers" {
+  value = data.hostingde_delegation_status.example.missing_nameservers
+}
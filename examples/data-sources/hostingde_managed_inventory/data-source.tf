@@ -0,0 +1,19 @@
+# Export the zones and records of the account, e.g. to compare them with
+# the Terraform state.
+data "hostingde_managed_inventory" "all" {}
+
+output "inventory" {
+  value = jsonencode({
+    zones   = data.hostingde_managed_inventory.all.zones
+    records = data.hostingde_managed_inventory.all.records
+  })
+}
+
+# Records not created by this provider, except the ones hosting.de manages.
+output "unmanaged_records" {
+  value = [
+    for record in data.hostingde_managed_inventory.all.records :
+    "${record.name} ${record.type} ${record.content}"
+    if !record.managed && !record.read_only
+  ]
+}
@@ -0,0 +1,10 @@
+# Check that www.example.test is served by all nameservers of the zone.
+data "hostingde_record_propagation" "www" {
+  zone_name = "example.test"
+  name      = "www.example.test"
+  type      = "A"
+}
+
+output "www_propagated" {
+  value = data.hostingde_record_propagation.www.propagated
+}
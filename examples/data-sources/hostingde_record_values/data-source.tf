@@ -0,0 +1,10 @@
+# Look up the addresses www.example.test resolves to in hosting.de.
+data "hostingde_record_values" "www" {
+  zone_name = "example.test"
+  name      = "www.example.test"
+  type      = "A"
+}
+
+locals {
+  www_addresses = data.hostingde_record_values.www.values
+}
@@ -0,0 +1,13 @@
+# Look up an existing DNS zone by name.
+data "hostingde_zone" "example" {
+  name = "example.test"
+}
+
+output "record_count" {
+  value = data.hostingde_zone.example.record_count
+}
+
+# Access fields of the zone config not modeled by the provider.
+output "soa_refresh" {
+  value = jsondecode(data.hostingde_zone.example.raw_json).soaValues.refresh
+}
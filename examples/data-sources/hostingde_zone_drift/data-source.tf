@@ -0,0 +1,24 @@
+# Compare a zone with the records of a configuration, for example in a
+# scheduled job that alerts on drift without running a full plan.
+data "hostingde_zone_drift" "example" {
+  zone_id  = hostingde_zone.example.id
+  owned_by = "managed by Terraform"
+  expected = [
+    for record in hostingde_record.all : {
+      name     = record.name
+      type     = record.type
+      content  = record.content
+      ttl      = record.effective_ttl
+      priority = record.priority
+    }
+  ]
+}
+
+output "zone_drift" {
+  value = {
+    in_sync = data.hostingde_zone_drift.example.in_sync
+    missing = data.hostingde_zone_drift.example.missing
+    extra   = data.hostingde_zone_drift.example.extra
+    changed = data.hostingde_zone_drift.example.changed
+  }
+}
@@ -0,0 +1,9 @@
+# Back up a zone as a BIND zonefile.
+data "hostingde_zone_export" "example" {
+  zone_name = "example.test"
+}
+
+resource "local_file" "zone_backup" {
+  filename = "${path.module}/example.test.zone"
+  content  = data.hostingde_zone_export.example.zonefile
+}
@@ -0,0 +1,15 @@
+# Find records with a low TTL, e.g. before a migration.
+data "hostingde_zone_records" "low_ttl" {
+  zone_id = hostingde_zone.example.id
+  max_ttl = 300
+}
+
+output "low_ttl_records" {
+  value = data.hostingde_zone_records.low_ttl.records
+}
+
+# Find records still pointing at a server before decommissioning it.
+data "hostingde_zone_records" "old_server" {
+  zone_id          = hostingde_zone.example.id
+  content_contains = "192.0.2.10"
+}
@@ -0,0 +1,12 @@
+# Monitor the status of a zone and its DNSSEC keys.
+data "hostingde_zone_status" "example" {
+  zone_name = "example.test"
+}
+
+output "zone_active" {
+  value = data.hostingde_zone_status.example.active
+}
+
+output "ds_publish_status" {
+  value = [for key in data.hostingde_zone_status.example.dnssec_keys : key.status if key.flags == 257]
+}
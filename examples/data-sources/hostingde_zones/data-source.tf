@@ -0,0 +1,18 @@
+# List all DNS zones of the account.
+data "hostingde_zones" "all" {}
+
+output "zone_names" {
+  value = [for zone in data.hostingde_zones.all.zones : zone.name]
+}
+
+# List the zones created in 2024.
+data "hostingde_zones" "created_2024" {
+  created_after  = "2024-01-01T00:00:00Z"
+  created_before = "2025-01-01T00:00:00Z"
+}
+
+# List the zones with records created by a provider configured with
+# managed_by_comment = "team-a".
+data "hostingde_zones" "team_a" {
+  owned_by = "team-a"
+}
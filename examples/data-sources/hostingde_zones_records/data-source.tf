@@ -0,0 +1,15 @@
+# Read the records of several zones at once, e.g. for an inventory report.
+data "hostingde_zones_records" "inventory" {
+  zone_names = ["example.com", "example.org", "example.net"]
+}
+
+output "record_counts" {
+  value = {
+    for name, zone in data.hostingde_zones_records.inventory.zones : name => length(zone.records)
+  }
+}
+
+# Read all zones matching a pattern of the hosting.de API.
+data "hostingde_zones_records" "customers" {
+  name_filter = "*.customers.example.com"
+}
@@ -0,0 +1,15 @@
+# Publish the DNS-01 challenges for a certificate covering
+# example.test and *.example.test.
+resource "hostingde_acme_challenge" "example" {
+  zone_id = hostingde_zone.sample.id
+  domain  = "example.test"
+  token   = "LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
+}
+
+resource "hostingde_acme_challenge" "wildcard" {
+  zone_id = hostingde_zone.sample.id
+  domain  = "*.example.test"
+  token   = "G0Dq0nx6SGp0Ix7CxKrqyAdCXoEnfxGUKqww5klPLjM"
+
+  propagation_timeout = "10m"
+}
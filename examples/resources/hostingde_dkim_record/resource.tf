@@ -0,0 +1,13 @@
+# Publish the DKIM key of the selector mail2024 at
+# mail2024._domainkey.example.test.
+resource "hostingde_dkim_record" "example" {
+  zone_id  = hostingde_zone.sample.id
+  selector = "mail2024"
+  domain   = "example.test"
+
+  tags = {
+    v = "DKIM1"
+    k = "rsa"
+    p = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAvGz5l3n0mqJ5W2RS6rI1ZkvXksk1uMxY0m6qZbP8Kp"
+  }
+}
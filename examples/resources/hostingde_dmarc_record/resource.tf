@@ -0,0 +1,9 @@
+# Publish "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.test; adkim=s"
+# at _dmarc.example.test.
+resource "hostingde_dmarc_record" "example" {
+  zone_id        = hostingde_zone.sample.id
+  policy         = "quarantine"
+  pct            = 50
+  rua            = ["mailto:dmarc@example.test"]
+  dkim_alignment = "strict"
+}
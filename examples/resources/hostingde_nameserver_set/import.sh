@@ -0,0 +1,2 @@
+# A nameserver set can be imported by specifying its id.
+terraform import hostingde_nameserver_set.example $NAMESERVER_SET_ID
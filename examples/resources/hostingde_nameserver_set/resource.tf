@@ -0,0 +1,10 @@
+# Define a nameserver set and create a zone using it.
+resource "hostingde_nameserver_set" "example" {
+  name        = "own nameservers"
+  nameservers = ["ns1.example.net", "ns2.example.net", "ns3.example.org"]
+}
+
+resource "hostingde_zone" "example" {
+  name           = "example.test"
+  nameserver_set = hostingde_nameserver_set.example.name
+}
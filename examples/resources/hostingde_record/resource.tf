@@ -1,7 +1,7 @@
 # Manage example DNS record.
 resource "hostingde_record" "example" {
   zone_id = hostingde_zone.sample.id
-  name = "test.example.test"
+  name = "test"
   type = "CNAME"
   content = "www.example.com"
   ttl = 300
@@ -10,9 +10,79 @@ resource "hostingde_record" "example" {
 # Manage example DNS MX record.
 resource "hostingde_record" "example" {
   zone_id = hostingde_zone.sample.id
-  name = "test.example.test"
+  name = "test"
   type = "MX"
   content = "mail.example.com"
   ttl = 300
   priority = 10
 }
+
+# Vanity nameservers for the zone itself: the apex NS records come from
+# the nameserver set of the zone, which hosting.de has to set up with the
+# vanity host names (select it with nameserver_set of hostingde_zone).
+# The glue records of the nameservers are regular A and AAAA records in
+# the zone, their addresses also have to be registered at the registrar.
+resource "hostingde_record" "ns1_glue" {
+  zone_id = hostingde_zone.sample.id
+  name = "ns1"
+  type = "A"
+  content = "192.0.2.53"
+}
+
+# Delegate a subzone to a nameserver within the zone. The NS record
+# warns if the zone has no glue record for the nameserver.
+resource "hostingde_record" "sub_glue" {
+  zone_id = hostingde_zone.sample.id
+  name = "ns1.sub"
+  type = "AAAA"
+  content = "2001:db8::53"
+}
+
+resource "hostingde_record" "sub_delegation" {
+  zone_id = hostingde_zone.sample.id
+  name = "sub"
+  type = "NS"
+  content = "ns1.sub.example.test"
+
+  depends_on = [hostingde_record.sub_glue]
+}
+
+# Content relative to the zone, for example in a module shared by several
+# zones. $${zone} is passed to the provider as ${zone}, which it replaces
+# with the zone name.
+resource "hostingde_record" "mail" {
+  zone_id = hostingde_zone.sample.id
+  name = "@"
+  type = "MX"
+  content = "mail.$${zone}"
+  priority = 10
+}
+
+# Records built from a map. Each record is tracked by its map key and keeps
+# its ID, so reordering the map plans nothing, changing a value updates only
+# that record in place, and adding or removing keys leaves the others alone.
+# Changing the type of a record replaces it. Use keys that don't change when
+# values change, like the record name, not the content.
+resource "hostingde_record" "hosts" {
+  for_each = {
+    www  = "192.0.2.10"
+    api  = "192.0.2.20"
+    mail = "192.0.2.30"
+  }
+
+  zone_id = hostingde_zone.sample.id
+  name    = each.key
+  type    = "A"
+  content = each.value
+}
+
+# A record in a zone given by name. create_zone_if_missing creates the zone
+# with the zone_defaults of the provider if it doesn't exist yet. The zone is
+# not managed by the record: it is left in place when the record is destroyed.
+resource "hostingde_record" "quickstart" {
+  zone_name              = "example.test"
+  name                   = "www"
+  type                   = "A"
+  content                = "192.0.2.1"
+  create_zone_if_missing = true
+}
@@ -0,0 +1,3 @@
+# A record set can be imported by specifying the zone id, the record name
+# relative to the zone and the record type, separated by slashes.
+terraform import hostingde_record_set.example $ZONE_ID/www/A
@@ -0,0 +1,29 @@
+# Manage all A records of www as one set.
+resource "hostingde_record_set" "www" {
+  zone_id = hostingde_zone.sample.id
+  name    = "www"
+  type    = "A"
+  values  = ["192.0.2.1", "192.0.2.2"]
+  ttl     = 300
+}
+
+# MX values start with the priority. The set may become empty,
+# which deletes all MX records of the zone apex.
+resource "hostingde_record_set" "mx" {
+  zone_id     = hostingde_zone.sample.id
+  name        = "@"
+  type        = "MX"
+  values      = var.mail_servers
+  allow_empty = true
+}
+
+# In a zone shared with other tools, manage_prefix guards against
+# touching records outside of the names owned by this configuration.
+resource "hostingde_record_set" "acme" {
+  zone_id       = hostingde_zone.sample.id
+  name          = "_acme-challenge.${var.host}"
+  type          = "TXT"
+  values        = var.acme_tokens
+  allow_empty   = true
+  manage_prefix = "_acme-challenge.*"
+}
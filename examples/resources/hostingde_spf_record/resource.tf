@@ -0,0 +1,8 @@
+# Publish "v=spf1 ip4:192.0.2.0/24 include:_spf.example.net -all" at the
+# apex of example.test.
+resource "hostingde_spf_record" "example" {
+  zone_id    = hostingde_zone.sample.id
+  ip4        = ["192.0.2.0/24"]
+  include    = ["_spf.example.net"]
+  all_policy = "fail"
+}
@@ -3,3 +3,60 @@ resource "hostingde_zone" "sample" {
   name = "example.test"
   type = "NATIVE"
 }
+
+# Migrate a zone from another DNS provider.
+resource "hostingde_zone" "migrated" {
+  name     = "example.org"
+  type     = "NATIVE"
+  zonefile = file("${path.module}/example.org.zone")
+}
+
+# Create the records of a new zone from a JSON manifest.
+resource "hostingde_zone" "manifest" {
+  name = "example.info"
+  type = "NATIVE"
+  records_json = jsonencode([
+    { name = "@", type = "A", content = "192.0.2.1" },
+    { name = "www", type = "CNAME", content = "example.info", ttl = 300 },
+    { name = "@", type = "MX", content = "mail.example.info", priority = 10 },
+  ])
+}
+
+# Allow only Let's Encrypt to issue certificates for a new zone.
+resource "hostingde_zone" "restricted" {
+  name               = "example.net"
+  type               = "NATIVE"
+  default_caa_issuer = "letsencrypt.org"
+}
+
+# Sign a zone with keys managed outside of hosting.de.
+resource "hostingde_zone" "manual_dnssec" {
+  name        = "example.com"
+  type        = "NATIVE"
+  dnssec_mode = "manual"
+  dnssec = {
+    keys = [{
+      flags      = 257
+      algorithm  = 13
+      public_key = var.ksk_public_key
+    }]
+  }
+}
+
+# Serve a zone from a dedicated DNS cluster. The API doesn't list the DNS
+# server groups of the account, but the groups in use can be checked
+# against the zones data source.
+data "hostingde_zones" "all" {}
+
+resource "hostingde_zone" "dedicated" {
+  name             = "example.net"
+  type             = "NATIVE"
+  dns_server_group = var.dns_server_group
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.hostingde_zones.all.zones[*].dns_server_group, var.dns_server_group)
+      error_message = "No zone of the account uses DNS server group ${var.dns_server_group}."
+    }
+  }
+}
@@ -0,0 +1,330 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// acmeChallengeTTL is the TTL of challenge records. They only live for the
+// duration of a certificate order, so resolvers shouldn't cache them long.
+const acmeChallengeTTL = 60
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &acmeChallengeResource{}
+	_ resource.ResourceWithConfigure      = &acmeChallengeResource{}
+	_ resource.ResourceWithValidateConfig = &acmeChallengeResource{}
+)
+
+// NewAcmeChallengeResource is a helper function to simplify the provider implementation.
+func NewAcmeChallengeResource() resource.Resource {
+	return &acmeChallengeResource{}
+}
+
+// acmeChallengeResource is the resource implementation.
+type acmeChallengeResource struct {
+	client *Client
+}
+
+// acmeChallengeResourceModel maps the ACME challenge resource schema data.
+type acmeChallengeResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ZoneID             types.String `tfsdk:"zone_id"`
+	Domain             types.String `tfsdk:"domain"`
+	Token              types.String `tfsdk:"token"`
+	Name               types.String `tfsdk:"name"`
+	WaitForPropagation types.Bool   `tfsdk:"wait_for_propagation"`
+	PropagationTimeout types.String `tfsdk:"propagation_timeout"`
+}
+
+// Metadata returns the resource type name.
+func (r *acmeChallengeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acme_challenge"
+}
+
+// Schema defines the schema for the resource.
+func (r *acmeChallengeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Publishes the TXT record of an ACME DNS-01 challenge and removes it on destroy. " +
+			"Several challenges for the same domain, for example for a certificate covering both the domain and its wildcard, " +
+			"are published as separate TXT values of the same record name and don't affect each other.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the TXT record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone that the challenge record belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Domain the certificate is issued for. Example: www.example.com. " +
+					"A leading wildcard label is removed, as the challenge for *.example.com is published at _acme-challenge.example.com.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Description: "Value of the challenge TXT record, i.e. the key authorization digest provided by the ACME client.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the challenge TXT record. Example: _acme-challenge.www.example.com.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"wait_for_propagation": schema.BoolAttribute{
+				Description: "Whether creating the challenge waits until all nameservers of the zone serve it, " +
+					"so the ACME server can validate it right away. Defaults to true.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"propagation_timeout": schema.StringAttribute{
+				Description: "How long to wait for the challenge to propagate if wait_for_propagation is true, " +
+					"as a duration like \"2m\". The apply fails once the timeout expired. Defaults to 5m.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *acmeChallengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create ACME challenge")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan acmeChallengeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	// Only add the record, TXT values of other challenges for the same name are kept
+	record := DNSRecord{
+		Name:     acmeChallengeName(plan.Domain.ValueString()),
+		ZoneID:   plan.ZoneID.ValueString(),
+		Type:     "TXT",
+		Content:  plan.Token.ValueString(),
+		TTL:      acmeChallengeTTL,
+		Comments: r.client.options.ManagedByComment,
+	}
+
+	recordReq := RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: plan.ZoneID.ValueString(),
+		RecordsToAdd: []DNSRecord{record},
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordResp, err := r.client.updateRecords(ctx, recordReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not create ACME challenge record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var returnedRecord *DNSRecord
+	for i, r := range recordResp.Response.Records {
+		if r.Name == record.Name && r.Type == record.Type && txtValue(r.Content) == txtValue(record.Content) {
+			returnedRecord = &recordResp.Response.Records[i]
+		}
+	}
+	if returnedRecord == nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not find the created ACME challenge record "+record.Name+" in the response of hosting.de",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(returnedRecord.ID)
+	plan.ZoneID = types.StringValue(recordResp.Response.ZoneConfig.ID)
+	plan.Name = types.StringValue(returnedRecord.Name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.WaitForPropagation.ValueBool() {
+		resp.Diagnostics.Append(waitForZoneRecordPropagation(ctx, r.client, plan.ZoneID.ValueString(), record.Name, record.Type, record.Content, plan.PropagationTimeout)...)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *acmeChallengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state acmeChallengeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := r.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "RecordId",
+		Value: state.ID.ValueString(),
+	}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read ACME challenge record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The challenge was cleaned up outside of Terraform, e.g. by the ACME client
+	if len(records) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ZoneID = types.StringValue(records[0].ZoneID)
+	state.Name = types.StringValue(records[0].Name)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the changed propagation settings, all attributes of the
+// record itself require a replacement.
+func (r *acmeChallengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan acmeChallengeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *acmeChallengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete ACME challenge")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state acmeChallengeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete by ID, so concurrent challenges for the same name are kept
+	recordReq := RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: state.ZoneID.ValueString(),
+		RecordsToDelete: []DNSRecord{{
+			ID:   state.ID.ValueString(),
+			Name: state.Name.ValueString(),
+			Type: "TXT",
+		}},
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.updateRecords(ctx, recordReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Record",
+			"Could not delete ACME challenge record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *acmeChallengeResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ValidateConfig checks the propagation timeout.
+func (r *acmeChallengeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData acmeChallengeResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePropagationTimeout(configData.PropagationTimeout)...)
+}
+
+// acmeChallengeName returns the name of the TXT record holding the DNS-01
+// challenge for a domain.
+func acmeChallengeName(domain string) string {
+	domain = strings.TrimSuffix(domain, ".")
+	domain = strings.TrimPrefix(domain, "*.")
+
+	return "_acme-challenge." + domain
+}
@@ -0,0 +1,82 @@
+package hostingde
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAcmeChallengeResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Concurrent challenges for the same name testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_acme_challenge" "test" {
+  zone_id = hostingde_zone.test.id
+  domain = "example2.test"
+  token = "LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
+  wait_for_propagation = false
+}
+resource "hostingde_acme_challenge" "wildcard" {
+  zone_id = hostingde_zone.test.id
+  domain = "*.example2.test"
+  token = "G0Dq0nx6SGp0Ix7CxKrqyAdCXoEnfxGUKqww5klPLjM"
+  wait_for_propagation = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_acme_challenge.test", "name", "_acme-challenge.example2.test"),
+					resource.TestCheckResourceAttr("hostingde_acme_challenge.wildcard", "name", "_acme-challenge.example2.test"),
+					resource.TestCheckResourceAttrSet("hostingde_acme_challenge.test", "id"),
+					resource.TestCheckResourceAttrSet("hostingde_acme_challenge.wildcard", "id"),
+				),
+			},
+			// Removing one challenge keeps the other
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_acme_challenge" "wildcard" {
+  zone_id = hostingde_zone.test.id
+  domain = "*.example2.test"
+  token = "G0Dq0nx6SGp0Ix7CxKrqyAdCXoEnfxGUKqww5klPLjM"
+  wait_for_propagation = false
+}
+data "hostingde_record_values" "test" {
+  zone_name = hostingde_zone.test.name
+  name = "_acme-challenge.example2.test"
+  type = "TXT"
+  depends_on = [hostingde_acme_challenge.wildcard]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hostingde_record_values.test", "values.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAcmeChallengeName(t *testing.T) {
+	for domain, want := range map[string]string{
+		"example.test":       "_acme-challenge.example.test",
+		"www.example.test.":  "_acme-challenge.www.example.test",
+		"*.example.test":     "_acme-challenge.example.test",
+		"*.sub.example.test": "_acme-challenge.sub.example.test",
+	} {
+		if got := acmeChallengeName(domain); got != want {
+			t.Errorf("acmeChallengeName(%q) = %q, want %q", domain, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package hostingde
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// authTokenCommandTimeout bounds how long auth_token_command may run, so a
+// hanging command doesn't block every request of the apply.
+const authTokenCommandTimeout = 30 * time.Second
+
+// hasAuthTokenSource returns whether the auth token is read from a command
+// or a file, and can be read again once it expired.
+func (o ClientOptions) hasAuthTokenSource() bool {
+	return len(o.AuthTokenCommand) > 0 || o.AuthTokenFile != ""
+}
+
+// readAuthToken reads the auth token from AuthTokenCommand or AuthTokenFile.
+// Surrounding whitespace, like the trailing newline of a file, is removed.
+func readAuthToken(ctx context.Context, opts ClientOptions) (string, error) {
+	var token string
+	switch {
+	case len(opts.AuthTokenCommand) > 0:
+		ctx, cancel := context.WithTimeout(ctx, authTokenCommandTimeout)
+		defer cancel()
+
+		// The command is run without a shell, so its arguments are never
+		// interpreted
+		cmd := exec.CommandContext(ctx, opts.AuthTokenCommand[0], opts.AuthTokenCommand[1:]...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("running auth_token_command %s: %v: %s", opts.AuthTokenCommand[0], err, strings.TrimSpace(stderr.String()))
+		}
+		token = string(output)
+	case opts.AuthTokenFile != "":
+		content, err := os.ReadFile(opts.AuthTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading auth_token_file: %v", err)
+		}
+		token = string(content)
+	default:
+		return "", errors.New("neither auth_token_command nor auth_token_file is configured")
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("the auth token read from auth_token_command or auth_token_file is empty")
+	}
+
+	return token, nil
+}
+
+// currentAuthToken returns the auth token sent with requests.
+func (c *Client) currentAuthToken() string {
+	c.authTokenMu.Lock()
+	defer c.authTokenMu.Unlock()
+
+	return c.authToken
+}
+
+// refreshAuthToken reads the auth token again after a request with the stale
+// token was rejected. Requests running in parallel are rejected at the same
+// time, so the token is only read again if no other request did already.
+func (c *Client) refreshAuthToken(ctx context.Context, stale string) (string, error) {
+	c.authTokenMu.Lock()
+	defer c.authTokenMu.Unlock()
+
+	if c.authToken != stale {
+		return c.authToken, nil
+	}
+
+	token, err := readAuthToken(ctx, c.options)
+	if err != nil {
+		return "", err
+	}
+	tflog.Info(ctx, "hosting.de API rejected the auth token, read it again")
+	c.authToken = token
+
+	return token, nil
+}
@@ -0,0 +1,100 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadAuthToken(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		opts    ClientOptions
+		want    string
+		wantErr bool
+	}{
+		"file":            {opts: ClientOptions{AuthTokenFile: file}, want: "file-token"},
+		"missing file":    {opts: ClientOptions{AuthTokenFile: filepath.Join(t.TempDir(), "missing")}, wantErr: true},
+		"command":         {opts: ClientOptions{AuthTokenCommand: []string{"echo", "command-token"}}, want: "command-token"},
+		"failing command": {opts: ClientOptions{AuthTokenCommand: []string{"false"}}, wantErr: true},
+		"empty token":     {opts: ClientOptions{AuthTokenCommand: []string{"echo"}}, wantErr: true},
+		"no source":       {opts: ClientOptions{}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := readAuthToken(context.Background(), tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got token %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClientRefreshesAuthToken(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		fileToken  string
+		ownToken   string
+		wantCalls  int32
+		wantErr    bool
+		wantTokens []string
+	}{
+		{name: "rotated token", fileToken: "new", wantCalls: 2, wantTokens: []string{"old", "new"}},
+		// The token is read again only once, a still rejected token fails
+		{name: "token not rotated", fileToken: "old", wantCalls: 2, wantErr: true, wantTokens: []string{"old", "old"}},
+		// Requests carrying their own token are left alone
+		{name: "own token", fileToken: "new", ownToken: "other", wantCalls: 1, wantErr: true, wantTokens: []string{"other"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "token")
+			if err := os.WriteFile(file, []byte(tc.fileToken), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			var calls atomic.Int32
+			var tokens []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				var request BaseRequest
+				if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+					t.Errorf("invalid request body: %v", err)
+				}
+				tokens = append(tokens, request.AuthToken)
+				if request.AuthToken != "new" {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+				fmt.Fprint(w, `{"status": "success"}`)
+			}))
+			defer server.Close()
+
+			token := "old"
+			client := NewClient(nil, &token, &server.URL, ClientOptions{AuthTokenFile: file})
+
+			_, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{AuthToken: tc.ownToken}})
+
+			var requestErr *RequestError
+			if tc.wantErr != errors.As(err, &requestErr) {
+				t.Errorf("got error %v, want a RequestError %t", err, tc.wantErr)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("got %d requests, want %d", got, tc.wantCalls)
+			}
+			if fmt.Sprint(tokens) != fmt.Sprint(tc.wantTokens) {
+				t.Errorf("got tokens %v, want %v", tokens, tc.wantTokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package hostingde
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultCircuitBreakerCooldown is how long requests are refused once the
+// circuit breaker opened, used if the provider configuration does not
+// specify otherwise.
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// errCircuitOpen is wrapped by the errors of requests refused by the circuit
+// breaker, so callers can tell them apart with errors.Is.
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker stops sending requests to the API for a cooldown after a
+// number of consecutive failures, so the remaining operations of an apply
+// fail right away during an outage instead of each running into timeouts.
+type circuitBreaker struct {
+	// threshold is the number of consecutive failures opening the circuit,
+	// zero disables the circuit breaker.
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns an error if the circuit is open. Once the cooldown passed,
+// requests are sent again, and the next failure opens the circuit again.
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.now().Before(b.openUntil) {
+		return fmt.Errorf("%w after %d consecutive failed requests to the hosting.de API, not sending requests until %s. "+
+			"Check the availability of the API, or raise circuit_breaker_threshold",
+			errCircuitOpen, b.failures, b.openUntil.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// success closes the circuit and resets the count of failures.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+// failure counts a failed request and opens the circuit once the threshold
+// is reached.
+func (b *circuitBreaker) failure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
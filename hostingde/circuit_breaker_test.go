@@ -0,0 +1,72 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	breaker.failure()
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("got error %v below the threshold", err)
+	}
+
+	// A success resets the count
+	breaker.success()
+	breaker.failure()
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("got error %v after a success", err)
+	}
+
+	breaker.failure()
+	if err := breaker.allow(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("got error %v at the threshold, want the circuit to be open", err)
+	}
+
+	// Requests are sent again after the cooldown, the next failure reopens
+	now = now.Add(time.Minute)
+	if err := breaker.allow(); err != nil {
+		t.Fatalf("got error %v after the cooldown", err)
+	}
+	breaker.failure()
+	if err := breaker.allow(); !errors.Is(err, errCircuitOpen) {
+		t.Errorf("got error %v after a failure following the cooldown, want the circuit to be open", err)
+	}
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Minute,
+	})
+
+	for i := 0; i < 4; i++ {
+		_, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}})
+		if err == nil {
+			t.Fatalf("request %d: got no error", i)
+		}
+		if wantOpen := i >= 2; errors.Is(err, errCircuitOpen) != wantOpen {
+			t.Errorf("request %d: got error %v, want circuit open %t", i, err, wantOpen)
+		}
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("got %d requests to the API, want 2", got)
+	}
+}
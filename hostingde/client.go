@@ -4,24 +4,167 @@ package hostingde
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/semaphore"
 )
 
 // Client -
 type Client struct {
 	HTTPClient *http.Client
 	accountId  string
-	authToken  string
 	baseURL    string
+	// authToken is replaced by refreshAuthToken, guarded by authTokenMu.
+	authToken   string
+	authTokenMu sync.Mutex
+	options     ClientOptions
+	// requests bounds the number of outstanding API requests, nil if
+	// MaxConcurrentRequests is unlimited.
+	requests *semaphore.Weighted
+	breaker  *circuitBreaker
+	// retryDelay is the delay before the first retry of a read request.
+	retryDelay time.Duration
+}
+
+// Default HTTP transport tuning, used when the provider configuration does not
+// specify otherwise.
+const (
+	defaultMaxIdleConns    = 10
+	defaultMaxConnsPerHost = 0
+	defaultMaxRedirects    = 3
+)
+
+// ClientOptions holds optional settings of the client used to talk to the
+// hosting.de API, including provider-level defaults for resources.
+type ClientOptions struct {
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections kept
+	// open to the API.
+	MaxIdleConns int
+	// MaxConnsPerHost limits the total number of connections to the API host.
+	// Zero means no limit.
+	MaxConnsPerHost int
+	// DefaultNameserverSet is the name of the nameserver set used for new
+	// zones that don't specify one.
+	DefaultNameserverSet string
+	// OperationJitter is the upper bound of a random delay before resources
+	// are created or updated. Zero disables the delay.
+	OperationJitter time.Duration
+	// ReadOnly makes resources refuse to create, update or delete anything.
+	ReadOnly bool
+	// MaxRedirects is the maximum number of redirects followed per request.
+	// Zero disables following redirects.
+	MaxRedirects int
+	// NewRecordDefaultTTL is the TTL planned for new records that don't
+	// configure one. Nil keeps the static default of the ttl attribute.
+	NewRecordDefaultTTL *int64
+	// RequireExplicitTTL makes records that don't configure a TTL fail to
+	// plan, instead of planning NewRecordDefaultTTL.
+	RequireExplicitTTL bool
+	// StrictWarnings turns the warnings of resources and data sources into
+	// errors, as long as no remote object was changed yet.
+	StrictWarnings bool
+	// ExtraHeaders are added to every request, for example for API gateways.
+	// They can not override the headers listed in reservedHeaders.
+	ExtraHeaders map[string]string
+	// ManagedByComment is stored in the comments of every record the
+	// provider creates. Empty leaves the comments unset.
+	ManagedByComment string
+	// MaxConcurrentRequests limits the number of API requests in flight,
+	// independent of Terraform's parallelism. Zero means no limit.
+	MaxConcurrentRequests int
+	// FallbackBaseURL is tried if the base URL is unreachable. Writes are
+	// only sent to it if the base URL couldn't be connected to. Empty
+	// disables the fallback.
+	FallbackBaseURL string
+	// CircuitBreakerThreshold is the number of consecutive failed requests
+	// after which requests are refused for CircuitBreakerCooldown. Zero
+	// disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+	// RateLimitWarningThreshold reports a warning once the remaining
+	// requests reported by the rate limit headers of the API fall below it.
+	// Zero disables the warning.
+	RateLimitWarningThreshold int
+	// APILanguage is sent in the Accept-Language header of every request,
+	// unless ExtraHeaders sets it.
+	// Empty leaves the language to the API.
+	APILanguage string
+	// RecordMatchStrategy is how hostingde_record finds its record on Read,
+	// one of recordMatchID and recordMatchNameTypeContent.
+	RecordMatchStrategy string
+	// ZoneDefaults are used for new zones that don't configure the
+	// corresponding attributes.
+	ZoneDefaults ZoneDefaults
+	// ReadRetries is how often read requests are retried after connection
+	// failures and server errors. Requests changing data are not retried.
+	ReadRetries int
+	// AuthTokenCommand and AuthTokenFile are read again to replace the auth
+	// token once the API rejected it. At most one of them is set.
+	AuthTokenCommand []string
+	AuthTokenFile    string
 }
 
-func NewClient(accountId, authToken, baseUrl *string) *Client {
+// ZoneDefaults holds the provider-level defaults for new zones. Empty values
+// leave the default to the resource or the API.
+type ZoneDefaults struct {
+	Type          string
+	NameserverSet string
+	// SOAValues are sent when creating a zone, zero values are left to the
+	// API. Nil if no SOA value is configured.
+	SOAValues       *SOAValues
+	DNSSECAlgorithm string
+	DNSSECNSECMode  string
+}
+
+// Strategies for finding the record of a hostingde_record on Read. Matching
+// by name, type and content is meant for migrating state whose IDs don't
+// refer to the records anymore.
+const (
+	recordMatchID              = "id"
+	recordMatchNameTypeContent = "name_type_content"
+)
+
+// reservedHeaders are set by the client or the HTTP transport and can't be
+// overridden by ExtraHeaders.
+var reservedHeaders = []string{"Connection", "Content-Length", "Content-Type", "Host", "Transfer-Encoding"}
+
+// requestIDHeaders are response headers that may carry an ID to correlate a
+// request with the logs of hosting.de, checked in order.
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id"}
+
+// Rate limit headers of the API response, only evaluated if present.
+const (
+	rateLimitLimitHeader     = "X-RateLimit-Limit"
+	rateLimitRemainingHeader = "X-RateLimit-Remaining"
+	rateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// Delays between retries of read requests, doubling with every retry.
+const (
+	defaultReadRetryDelay = 1 * time.Second
+	maxReadRetryDelay     = 30 * time.Second
+)
+
+// defaultRateLimitWarningThreshold is the number of remaining requests below
+// which a warning is logged, used if the provider configuration does not
+// specify otherwise.
+const defaultRateLimitWarningThreshold = 10
+
+func NewClient(accountId, authToken, baseUrl *string, opts ClientOptions) *Client {
 	var account, token, url string
 
 	if accountId != nil {
@@ -34,22 +177,39 @@ func NewClient(accountId, authToken, baseUrl *string) *Client {
 		url = *baseUrl
 	}
 
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = opts.MaxIdleConns
+	// All requests go to the same host, allow keeping all idle connections for it
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	transport.MaxConnsPerHost = opts.MaxConnsPerHost
+
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second},
-		accountId:  account,
-		authToken:  token,
-		baseURL:    url,
+		HTTPClient: &http.Client{
+			Timeout:       10 * time.Second,
+			Transport:     transport,
+			CheckRedirect: checkRedirect(opts.MaxRedirects),
+		},
+		accountId: account,
+		authToken: token,
+		baseURL:   url,
+		options:   opts,
+		breaker:   newCircuitBreaker(opts.CircuitBreakerThreshold, opts.CircuitBreakerCooldown),
+
+		retryDelay: defaultReadRetryDelay,
+	}
+	if opts.MaxConcurrentRequests > 0 {
+		c.requests = semaphore.NewWeighted(int64(opts.MaxConcurrentRequests))
 	}
 
 	return &c
 }
 
-func (c *Client) doRequestIter(httpMethod string, uri string, request Request, response interface{}, iteration int) ([]byte, error) {
+func (c *Client) doRequestIter(ctx context.Context, httpMethod string, uri string, request Request, iteration int) ([]byte, error) {
 	if iteration > 8 {
 		return nil, fmt.Errorf("reached max retry count, status of ZoneConfig in response is still blocked")
 	}
 	if request.getAuthToken() == "" {
-		request.setAuthToken(c.authToken)
+		request.setAuthToken(c.currentAuthToken())
 	}
 	if request.getAccountId() == "" {
 		request.setAccountId(c.accountId)
@@ -60,53 +220,148 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 		return nil, err
 	}
 
-	req, err := http.NewRequest(httpMethod, uri, bytes.NewReader(rawBody))
-	if err != nil {
-		return nil, err
-	}
+	var resp *http.Response
+	var release func()
+	var start time.Time
+	var endpoint string
+	requestURI := uri
+	for attempt := 0; ; attempt++ {
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		// Only the request itself holds a slot, not the wait before a retry
+		release, err = c.acquireRequestSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		start = time.Now()
+		endpoint = c.baseURL
+		uri = requestURI
+		resp, err = c.send(ctx, httpMethod, uri, rawBody)
+
+		// Only connection failures are retried, the fallback would answer an
+		// HTTP error status the same way. Writes are only sent again if the
+		// primary was never reached, as it may have applied them otherwise.
+		if err != nil && c.options.FallbackBaseURL != "" && ctx.Err() == nil && strings.HasPrefix(uri, c.baseURL) &&
+			(isReadRequest(uri) || isDialError(err)) {
+			tflog.Warn(ctx, "hosting.de API unreachable, retrying with the fallback base URL", map[string]any{
+				"hostingde_uri":   uri,
+				"hostingde_error": err.Error(),
+			})
+			endpoint = c.options.FallbackBaseURL
+			uri = endpoint + strings.TrimPrefix(uri, c.baseURL)
+			resp, err = c.send(ctx, httpMethod, uri, rawBody)
+		}
+
+		switch {
+		case err != nil:
+			// A cancelled apply says nothing about the API
+			if ctx.Err() == nil {
+				c.breaker.failure()
+			}
+		case resp.StatusCode >= http.StatusInternalServerError:
+			c.breaker.failure()
+		default:
+			c.breaker.success()
+		}
+
+		if !c.retryRead(ctx, uri, resp, err, attempt) {
+			break
+		}
 
-	resp, err := c.HTTPClient.Do(req)
+		release()
+		fields := map[string]any{
+			"hostingde_uri":         uri,
+			"hostingde_retry_count": attempt + 1,
+		}
+		if err != nil {
+			fields["hostingde_error"] = err.Error()
+		} else {
+			fields["hostingde_status_code"] = resp.StatusCode
+			resp.Body.Close()
+		}
+		tflog.Warn(ctx, "hosting.de API read request failed, retrying", fields)
+
+		if err := sleepContext(ctx, c.readRetryDelay(attempt)); err != nil {
+			return nil, fmt.Errorf("error querying API: %v", err)
+		}
+	}
 	if err != nil {
+		release()
 		return nil, fmt.Errorf("error querying API: %v", err)
 	}
 
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, errors.New(toErrorWithNewlines(uri, body))
+	release()
+
+	// Only the common part is decoded here, the caller decodes the rest with
+	// decodeEnvelope. Error responses may not be JSON, so a decoding error is
+	// only reported once the HTTP status was checked.
+	var br BaseResponse
+	var decodeErr error
+	if err == nil {
+		decodeErr = json.Unmarshal(body, &br)
+	}
+	requestID := responseRequestID(resp.Header, br.Metadata)
+
+	logFields := map[string]any{
+		"hostingde_http_method": httpMethod,
+		"hostingde_uri":         uri,
+		"hostingde_endpoint":    endpoint,
+		"hostingde_status_code": resp.StatusCode,
+		"hostingde_duration_ms": time.Since(start).Milliseconds(),
+		"hostingde_retry_count": iteration,
+	}
+	if requestID != "" {
+		logFields["hostingde_request_id"] = requestID
+	}
+	limit, hasRateLimit := parseRateLimit(resp.Header)
+	if hasRateLimit {
+		logFields["hostingde_ratelimit_remaining"] = limit.remaining
+		if limit.limit != "" {
+			logFields["hostingde_ratelimit_limit"] = limit.limit
+		}
+		if limit.reset != "" {
+			logFields["hostingde_ratelimit_reset"] = limit.reset
+		}
+	}
+	tflog.Debug(ctx, "hosting.de API request completed", logFields)
+
+	if hasRateLimit && limit.remaining < c.options.RateLimitWarningThreshold {
+		tflog.Warn(ctx, "hosting.de API rate limit almost exhausted", map[string]any{
+			"hostingde_ratelimit_remaining": limit.remaining,
+			"hostingde_ratelimit_limit":     limit.limit,
+			"hostingde_ratelimit_reset":     limit.reset,
+		})
+		addClientWarning(ctx, "hosting.de API rate limit almost exhausted",
+			fmt.Sprintf("Fewer than %d requests to the hosting.de API are left until the rate limit resets. "+
+				"Consider lowering the parallelism of Terraform or max_concurrent_requests.", c.options.RateLimitWarningThreshold))
 	}
 
-	err = json.Unmarshal(body, response)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %s", err, toErrorWithNewlines(uri, body))
+		return nil, errors.New(errorMessage(uri, body, requestID))
 	}
 
-	// Sometimes the API returns an undocumented blocked status
-	var br *BaseResponse
-	switch r := response.(type) {
-	default:
-		return nil, fmt.Errorf("error: invalid response type: %T", r)
-	case *ZoneUpdateResponse:
-		br = &r.BaseResponse
-	case *ZoneCreateResponse:
-		br = &r.BaseResponse
-	case *ZoneDeleteResponse:
-		br = &r.BaseResponse
-	case *ZoneConfigsFindResponse:
-		if len(r.Response.Data) == 0 {
-			return nil, fmt.Errorf("%v: uri: %s %s", err, uri, response)
-		}
-		br = &r.BaseResponse
-	case *ZonesFindResponse:
-		if len(r.Response.Data) == 0 {
-			return nil, fmt.Errorf("%v: uri: %s %s", err, uri, response)
-		}
-		br = &r.BaseResponse
-	case *RecordsFindResponse:
-		br = &r.BaseResponse
-	case *RecordsUpdateResponse:
-		br = &r.BaseResponse
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, &RequestError{
+			StatusCode: resp.StatusCode,
+			URI:        uri,
+			AccountID:  request.getAccountId(),
+			RequestID:  requestID,
+			Body:       body,
+		}
+	}
+
+	if decodeErr != nil {
+		return nil, fmt.Errorf("%v: %s", decodeErr, errorMessage(uri, body, requestID))
+	}
+
+	for _, warning := range br.Warnings {
+		addClientWarning(ctx, "hosting.de API warning", "The hosting.de API returned a warning for "+uri+": "+warning)
 	}
 
 	iteration++
@@ -123,19 +378,364 @@ func (c *Client) doRequestIter(httpMethod string, uri string, request Request, r
 			}
 		}
 		if blocked {
-			fmt.Printf("Request blocked, triggering new request: %d\n", iteration)
+			tflog.Debug(ctx, "Request blocked, triggering new request", map[string]any{
+				"hostingde_uri":         uri,
+				"hostingde_retry_count": iteration,
+			})
 			time.Sleep(1 * time.Second)
-			return c.doRequestIter(httpMethod, uri, request, response, iteration)
+			return c.doRequestIter(ctx, httpMethod, uri, request, iteration)
 		}
 	}
 
 	return body, err
 }
 
-func (c *Client) doRequest(httpMethod string, uri string, request Request, response interface{}) ([]byte, error) {
-	return c.doRequestIter(httpMethod, uri, request, response, 0)
+// isReadRequest returns whether the request to the URI only reads data, which
+// holds for the find and get methods of the API. Sending such a request again
+// has no side effects, unlike creating, updating or deleting objects.
+func isReadRequest(uri string) bool {
+	method := uri[strings.LastIndex(uri, "/")+1:]
+	return strings.HasSuffix(method, "Find") || strings.HasSuffix(method, "Get")
+}
+
+// isDialError returns whether the request failed while connecting, before
+// anything was sent to the server.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryRead returns whether a request is retried after the given attempt.
+// Only read requests are retried, up to ReadRetries times, after connection
+// failures, HTTP 5xx responses and HTTP 429. Other requests are never
+// retried, as a request that failed on the way back may have been applied,
+// and sending it again could for example create a record twice.
+func (c *Client) retryRead(ctx context.Context, uri string, resp *http.Response, err error, attempt int) bool {
+	if attempt >= c.options.ReadRetries || ctx.Err() != nil || !isReadRequest(uri) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// readRetryDelay returns the delay before retrying a read request after the
+// given attempt, doubling with every attempt up to maxReadRetryDelay.
+func (c *Client) readRetryDelay(attempt int) time.Duration {
+	delay := c.retryDelay
+	for i := 0; i < attempt && delay < maxReadRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxReadRetryDelay)
+}
+
+// sleepContext waits for the duration, or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send sends the JSON encoded request body to the URI.
+func (c *Client) send(ctx context.Context, httpMethod string, uri string, rawBody []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, httpMethod, uri, bytes.NewReader(rawBody))
+	if err != nil {
+		return nil, err
+	}
+
+	// An Accept-Language extra header overrides APILanguage
+	if c.options.APILanguage != "" {
+		req.Header.Set("Accept-Language", c.options.APILanguage)
+	}
+	for name, value := range c.options.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.HTTPClient.Do(req)
+}
+
+func (c *Client) doRequest(ctx context.Context, httpMethod string, uri string, request Request) ([]byte, error) {
+	// Requests with their own token, like those of other accounts, keep it
+	ownToken := request.getAuthToken() != ""
+
+	body, err := c.doRequestIter(ctx, httpMethod, uri, request, 0)
+
+	// A short-lived token may expire during a long apply. The rejected
+	// request was not applied, so it is safe to send it once more.
+	var requestErr *RequestError
+	if ownToken || !c.options.hasAuthTokenSource() || !errors.As(err, &requestErr) || requestErr.StatusCode != http.StatusUnauthorized {
+		return body, err
+	}
+
+	token, refreshErr := c.refreshAuthToken(ctx, request.getAuthToken())
+	if refreshErr != nil {
+		return nil, fmt.Errorf("%w, reading the auth token again failed: %v", err, refreshErr)
+	}
+	request.setAuthToken(token)
+
+	return c.doRequestIter(ctx, httpMethod, uri, request, 0)
+}
+
+// apiResponse is implemented by all response types embedding BaseResponse.
+type apiResponse interface {
+	base() *BaseResponse
+}
+
+func (r *BaseResponse) base() *BaseResponse {
+	return r
+}
+
+// decodeEnvelope unmarshals the body of an API response into the response
+// type T, which carries the typed data along with the errors, warnings and
+// metadata of the common envelope. Fields unknown to T are ignored, so fields
+// added to the API don't break the provider. A status other than success or one of the
+// accepted statuses, like pending, is returned as an error together with the
+// response, so callers can inspect the errors of single objects.
+// https://www.hosting.de/api/?json#responses
+func decodeEnvelope[T any, P interface {
+	*T
+	apiResponse
+}](ctx context.Context, uri string, body []byte, acceptedStatuses ...string) (*T, error) {
+	response := new(T)
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, toErrorWithNewlines(uri, body))
+	}
+	logUnknownField(ctx, uri, body, new(T))
+
+	status := P(response).base().Status
+	if status != "success" && !slices.Contains(acceptedStatuses, status) {
+		return response, errors.New(toErrorWithNewlines(uri, body))
+	}
+
+	return response, nil
+}
+
+// loggedUnknownFields holds the URIs and fields logUnknownField already
+// logged, so each is only logged once per provider run.
+var loggedUnknownFields sync.Map
+
+// logUnknownField logs a debug message if the response body contains a field
+// the provider doesn't model, so maintainers notice new API capabilities. The
+// body is only decoded again if debug logging is enabled.
+func logUnknownField(ctx context.Context, uri string, body []byte, target any) {
+	if !debugLogging() {
+		return
+	}
+
+	field := unknownField(body, target)
+	if field == "" {
+		return
+	}
+
+	if _, logged := loggedUnknownFields.LoadOrStore(uri+" "+field, true); logged {
+		return
+	}
+	tflog.Debug(ctx, "Ignoring unknown field in hosting.de API response", map[string]any{
+		"uri":   uri,
+		"field": field,
+	})
+}
+
+// debugLogging reports whether the provider logs at debug or trace level, by
+// the same environment variables Terraform sets the provider log level with.
+func debugLogging() bool {
+	for _, name := range []string{"TF_LOG_PROVIDER_HOSTINGDE", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := os.Getenv(name); level != "" {
+			switch strings.ToUpper(level) {
+			case "TRACE", "DEBUG", "JSON":
+				return true
+			}
+			return false
+		}
+	}
+
+	return false
+}
+
+// unknownField returns the first field of the JSON body that target has no
+// field for, or an empty string if there is none. Target is overwritten.
+func unknownField(body []byte, target any) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(target)
+	if err == nil {
+		return ""
+	}
+
+	field, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return ""
+	}
+
+	return strings.Trim(field, `"`)
+}
+
+// acquireRequestSlot waits until less than MaxConcurrentRequests requests
+// are in flight. The returned function releases the slot again.
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requests == nil {
+		return func() {}, nil
+	}
+
+	if err := c.requests.Acquire(ctx, 1); err != nil {
+		return nil, fmt.Errorf("error waiting for a free API request slot: %w", err)
+	}
+
+	return func() { c.requests.Release(1) }, nil
+}
+
+// checkRedirect returns a redirect policy following at most maxRedirects
+// redirects. The auth token is part of the request body, which is sent again
+// on 307 and 308 redirects, so only redirects to the same host are followed.
+// Other redirects turn the POST requests of the API into GET requests
+// without a body and are not followed.
+func checkRedirect(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host {
+			return fmt.Errorf("refusing to follow redirect from %s to different host %s", via[0].URL.Host, req.URL.Host)
+		}
+
+		if req.Method != via[0].Method {
+			return fmt.Errorf("refusing to follow HTTP %d redirect to %s, which would send the %s request as %s without its body",
+				req.Response.StatusCode, req.URL, via[0].Method, req.Method)
+		}
+
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return nil
+	}
+}
+
+// waitForJitter sleeps for a random duration up to the configured operation
+// jitter, to spread out bursts of parallel create and update operations.
+func (c *Client) waitForJitter(ctx context.Context) error {
+	if c.options.OperationJitter <= 0 {
+		return nil
+	}
+
+	delay := time.Duration(rand.Int63n(int64(c.options.OperationJitter)))
+	tflog.Debug(ctx, "Delaying operation", map[string]any{"hostingde_jitter_ms": delay.Milliseconds()})
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// maxPageLimit is the maximum page size accepted by the API for find requests.
+// Larger limits are silently truncated by the API.
+const maxPageLimit = 100
+
+// clampLimit returns the given page limit, capped at the API's maximum.
+func clampLimit(limit int) int {
+	if limit <= 0 || limit > maxPageLimit {
+		return maxPageLimit
+	}
+
+	return limit
+}
+
+// errNotFound is wrapped by the errors of lookups that found nothing, so
+// callers can tell a missing object apart with errors.Is.
+var errNotFound = errors.New("not found")
+
+// RequestError is returned for requests the API rejected with an HTTP error
+// status, so callers can tell the cause apart with errors.As.
+type RequestError struct {
+	StatusCode int
+	URI        string
+	// AccountID is the account the request was made for, empty for the
+	// account of the auth token.
+	AccountID string
+	// RequestID correlates the request with the logs of hosting.de, empty
+	// if the API didn't return one.
+	RequestID string
+	Body      []byte
+}
+
+func (e *RequestError) Error() string {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return fmt.Sprintf("authentication failed (HTTP %d), check your auth token: %s",
+			e.StatusCode, errorMessage(e.URI, e.Body, e.RequestID))
+	case http.StatusForbidden:
+		account := "the account of the token"
+		if e.AccountID != "" {
+			account = "account " + e.AccountID
+		}
+		return fmt.Sprintf("not authorized (HTTP %d), your auth token lacks permission for this zone or %s: %s",
+			e.StatusCode, account, errorMessage(e.URI, e.Body, e.RequestID))
+	default:
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, errorMessage(e.URI, e.Body, e.RequestID))
+	}
 }
 
 func toErrorWithNewlines(uri string, rawBody []byte) string {
+	var response BaseResponse
+	_ = json.Unmarshal(rawBody, &response)
+
+	return errorMessage(uri, rawBody, responseRequestID(nil, response.Metadata))
+}
+
+// errorMessage formats an error of a request, with the request ID to give to
+// the hosting.de support if there is one.
+func errorMessage(uri string, rawBody []byte, requestID string) string {
+	if requestID != "" {
+		uri += " (request id: " + requestID + ")"
+	}
+
 	return fmt.Sprintf("Request URI was: %s Error message body: %s", uri, strings.ReplaceAll(string(rawBody), `\n`, "\n"))
 }
+
+// rateLimit holds the rate limit headers of a response. Limit and reset are
+// only logged, so they are kept as sent.
+type rateLimit struct {
+	limit     string
+	remaining int
+	reset     string
+}
+
+// parseRateLimit returns the rate limit headers of a response, false if the
+// response has no valid X-RateLimit-Remaining header.
+func parseRateLimit(header http.Header) (rateLimit, bool) {
+	remaining, err := strconv.Atoi(header.Get(rateLimitRemainingHeader))
+	if err != nil {
+		return rateLimit{}, false
+	}
+
+	return rateLimit{
+		limit:     header.Get(rateLimitLimitHeader),
+		remaining: remaining,
+		reset:     header.Get(rateLimitResetHeader),
+	}, true
+}
+
+// responseRequestID returns the ID correlating a request with the logs of
+// hosting.de. A request ID header takes precedence over the server
+// transaction ID in the metadata of the response body.
+func responseRequestID(header http.Header, metadata Metadata) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+
+	return metadata.ServerTransactionID
+}
@@ -0,0 +1,683 @@
+package hostingde
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// testHandler answers a request to a mock API endpoint. The returned value is
+// encoded as the JSON response.
+type testHandler func(t *testing.T, body []byte) any
+
+// newTestClient returns a client talking to a mock API, which answers each
+// endpoint with the handler registered for it, like "/recordsFind".
+func newTestClient(t *testing.T, handlers map[string]testHandler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[strings.TrimPrefix(r.URL.Path, "/api")]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		// The API takes every operation as POST
+		if r.Method != http.MethodPost {
+			t.Errorf("got %s request to %s, want POST", r.Method, r.URL.Path)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("could not read request body: %v", err)
+		}
+
+		if err := json.NewEncoder(w).Encode(handler(t, body)); err != nil {
+			t.Fatalf("could not write response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	baseURL := server.URL + "/api"
+	return NewClient(nil, nil, &baseURL, ClientOptions{})
+}
+
+func TestClientOperationMethods(t *testing.T) {
+	ctx := context.Background()
+	for _, tc := range []struct {
+		endpoint  string
+		operation func(c *Client) error
+	}{
+		{endpoint: "/zonesFind", operation: func(c *Client) error {
+			_, err := c.listZones(ctx, ZonesFindRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zoneConfigsFind", operation: func(c *Client) error {
+			_, err := c.listZoneConfigs(ctx, ZoneConfigsFindRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zoneCreate", operation: func(c *Client) error {
+			_, err := c.createZone(ctx, ZoneCreateRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zoneUpdate", operation: func(c *Client) error {
+			_, err := c.updateZone(ctx, ZoneUpdateRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zoneDelete", operation: func(c *Client) error {
+			_, err := c.deleteZone(ctx, ZoneDeleteRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zonePurgeRestorable", operation: func(c *Client) error {
+			_, err := c.purgeZone(ctx, ZoneDeleteRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/zoneDnsSecOptionsGet", operation: func(c *Client) error {
+			_, err := c.getDNSSecOptions(ctx, DNSSecOptionsGetRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/recordsFind", operation: func(c *Client) error {
+			_, err := c.listRecords(ctx, RecordsFindRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/recordsUpdate", operation: func(c *Client) error {
+			_, err := c.updateRecords(ctx, RecordsUpdateRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/recordTemplatesFind", operation: func(c *Client) error {
+			_, err := c.listAllRecordTemplates(ctx, "1")
+			return err
+		}},
+		{endpoint: "/nameserverSetsFind", operation: func(c *Client) error {
+			_, err := c.listNameserverSets(ctx, NameserverSetsFindRequest{BaseRequest: &BaseRequest{}})
+			return err
+		}},
+		{endpoint: "/nameserverSetCreate", operation: func(c *Client) error {
+			_, err := c.createNameserverSet(ctx, NameserverSet{})
+			return err
+		}},
+		{endpoint: "/nameserverSetUpdate", operation: func(c *Client) error {
+			_, err := c.updateNameserverSet(ctx, NameserverSet{})
+			return err
+		}},
+		{endpoint: "/nameserverSetDelete", operation: func(c *Client) error {
+			return c.deleteNameserverSet(ctx, "1")
+		}},
+	} {
+		// newTestClient fails the test on requests other than POST
+		client := newTestClient(t, map[string]testHandler{
+			tc.endpoint: func(t *testing.T, _ []byte) any {
+				return json.RawMessage(`{"status": "success", "response": {"totalEntries": 1, "data": [{"id": "1"}]}}`)
+			},
+		})
+		if err := tc.operation(client); err != nil {
+			t.Errorf("%s: got error %v", tc.endpoint, err)
+		}
+	}
+}
+
+func TestClientRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var updateRequest RecordsUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&updateRequest); err != nil || updateRequest.AuthToken != "token" {
+			t.Errorf("redirected request lost its body: %v", err)
+		}
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old/recordsUpdate":
+			http.Redirect(w, r, "/new/recordsUpdate", http.StatusPermanentRedirect)
+		case "/new/recordsUpdate":
+			fmt.Fprint(w, `{"status": "success"}`)
+		case "/other/recordsUpdate":
+			http.Redirect(w, r, target.URL+"/recordsUpdate", http.StatusPermanentRedirect)
+		case "/moved/recordsUpdate":
+			http.Redirect(w, r, "/new/recordsUpdate", http.StatusMovedPermanently)
+		case "/found/recordsUpdate":
+			http.Redirect(w, r, "/new/recordsUpdate", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	for _, tc := range []struct {
+		path         string
+		maxRedirects int
+		wantErr      bool
+	}{
+		// Redirects on the same host are followed
+		{path: "/old", maxRedirects: 1},
+		{path: "/old", maxRedirects: 0, wantErr: true},
+		// Redirects to other hosts would leak the auth token
+		{path: "/other", maxRedirects: 1, wantErr: true},
+		// 301 and 302 redirects would send the request as GET without body
+		{path: "/moved", maxRedirects: 1, wantErr: true},
+		{path: "/found", maxRedirects: 1, wantErr: true},
+	} {
+		baseURL := server.URL + tc.path
+		client := NewClient(nil, &token, &baseURL, ClientOptions{MaxRedirects: tc.maxRedirects})
+
+		_, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}})
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s with max %d redirects: got error %v, want error %t", tc.path, tc.maxRedirects, err, tc.wantErr)
+		}
+	}
+}
+
+func TestClientAuthErrors(t *testing.T) {
+	for _, tc := range []struct {
+		statusCode int
+		want       string
+	}{
+		{statusCode: http.StatusUnauthorized, want: "check your auth token"},
+		{statusCode: http.StatusForbidden, want: "lacks permission for this zone or account 1234"},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tc.statusCode)
+			fmt.Fprint(w, `{"status": "error"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		accountId := "1234"
+		client := NewClient(&accountId, nil, &server.URL, ClientOptions{})
+
+		_, err := client.listRecords(context.Background(), RecordsFindRequest{BaseRequest: &BaseRequest{}})
+
+		var requestErr *RequestError
+		if !errors.As(err, &requestErr) || requestErr.StatusCode != tc.statusCode {
+			t.Fatalf("HTTP %d: got error %v, want a RequestError with the status code", tc.statusCode, err)
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("HTTP %d: got error %q, want it to contain %q", tc.statusCode, err.Error(), tc.want)
+		}
+	}
+}
+
+func TestClientExtraHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Edge-Auth"); got != "secret" {
+			t.Errorf("got X-Edge-Auth header %q, want %q", got, "secret")
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("got Content-Type header %q, want %q", got, "application/json")
+		}
+		if got := r.Header.Get("Accept-Language"); got != "en" {
+			t.Errorf("got Accept-Language header %q, want %q", got, "en")
+		}
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{
+		ExtraHeaders: map[string]string{"X-Edge-Auth": "secret"},
+		APILanguage:  defaultAPILanguage,
+	})
+
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err != nil {
+		t.Fatalf("updateRecords returned an error: %v", err)
+	}
+}
+
+func TestClientExtraHeadersAcceptLanguage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Language"); got != "de" {
+			t.Errorf("got Accept-Language header %q, want the extra header %q", got, "de")
+		}
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{
+		ExtraHeaders: map[string]string{"accept-language": "de"},
+		APILanguage:  defaultAPILanguage,
+	})
+
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err != nil {
+		t.Fatalf("updateRecords returned an error: %v", err)
+	}
+}
+
+func TestClientRequestID(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		header string
+		body   string
+		want   string
+	}{
+		{name: "header", header: "abc123", body: `{"status": "error"}`, want: "(request id: abc123)"},
+		{name: "metadata", body: `{"status": "error", "metadata": {"serverTransactionId": "srv-1"}}`, want: "(request id: srv-1)"},
+		{name: "header before metadata", header: "abc123", body: `{"status": "error", "metadata": {"serverTransactionId": "srv-1"}}`, want: "(request id: abc123)"},
+		{name: "none", body: `{"status": "error"}`, want: ""},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tc.header != "" {
+				w.Header().Set("X-Request-Id", tc.header)
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, tc.body)
+		}))
+		t.Cleanup(server.Close)
+
+		client := NewClient(nil, nil, &server.URL, ClientOptions{})
+
+		_, err := client.listRecords(context.Background(), RecordsFindRequest{BaseRequest: &BaseRequest{}})
+		if err == nil {
+			t.Fatalf("%s: got no error", tc.name)
+		}
+		if tc.want == "" && strings.Contains(err.Error(), "request id") {
+			t.Errorf("%s: got error %q, want no request id", tc.name, err)
+		}
+		if !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %q, want it to contain %q", tc.name, err, tc.want)
+		}
+	}
+
+	// Errors of the API status only have the body
+	body := []byte(`{"status": "error", "metadata": {"serverTransactionId": "srv-1"}}`)
+	if got := toErrorWithNewlines("/recordsFind", body); !strings.Contains(got, "(request id: srv-1)") {
+		t.Errorf("got error %q, want the server transaction ID", got)
+	}
+}
+
+func TestClientMaxConcurrentRequests(t *testing.T) {
+	const limit = 2
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		// Keep the request open, so parallel requests overlap
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{
+		MaxIdleConns:          10,
+		MaxConcurrentRequests: limit,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err != nil {
+				t.Errorf("updateRecords returned an error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > limit {
+		t.Errorf("got %d requests in flight, want at most %d", got, limit)
+	}
+	if got := maxInFlight.Load(); got < limit {
+		t.Errorf("got at most %d requests in flight, want the limit of %d to be used", got, limit)
+	}
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		body         string
+		accepted     []string
+		wantErr      bool
+		wantResponse bool
+		wantWarnings []string
+		wantErrors   int
+		wantTotal    int
+	}{
+		{
+			name:         "success",
+			body:         `{"status": "success", "response": {"totalEntries": 2, "data": [{"id": "r1"}, {"id": "r2"}]}}`,
+			wantResponse: true,
+			wantTotal:    2,
+		},
+		{
+			name:         "warnings",
+			body:         `{"status": "success", "warnings": ["zone is not delegated"], "response": {"totalEntries": 1, "data": [{"id": "r1"}]}}`,
+			wantResponse: true,
+			wantWarnings: []string{"zone is not delegated"},
+			wantTotal:    1,
+		},
+		{
+			name:         "error",
+			body:         `{"status": "error", "errors": [{"code": 10205, "text": "Record not found"}]}`,
+			wantErr:      true,
+			wantResponse: true,
+			wantErrors:   1,
+		},
+		{
+			name:         "pending not accepted",
+			body:         `{"status": "pending"}`,
+			wantErr:      true,
+			wantResponse: true,
+		},
+		{
+			name:         "pending accepted",
+			body:         `{"status": "pending"}`,
+			accepted:     []string{"pending"},
+			wantResponse: true,
+		},
+		{
+			name:         "unknown fields",
+			body:         `{"status": "success", "newFeature": true, "response": {"totalEntries": 1, "data": [{"id": "r1", "dnssecSigned": true}]}}`,
+			wantResponse: true,
+			wantTotal:    1,
+		},
+		{
+			name:    "invalid JSON",
+			body:    `{"status": `,
+			wantErr: true,
+		},
+	} {
+		response, err := decodeEnvelope[RecordsFindResponse](context.Background(), "/recordsFind", []byte(tc.body), tc.accepted...)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tc.name, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "/recordsFind") {
+			t.Errorf("%s: got error %q, want it to contain the URI", tc.name, err)
+		}
+		if (response != nil) != tc.wantResponse {
+			t.Fatalf("%s: got response %v, want response %t", tc.name, response, tc.wantResponse)
+		}
+		if response == nil {
+			continue
+		}
+		if len(response.Warnings) != len(tc.wantWarnings) || (len(tc.wantWarnings) > 0 && response.Warnings[0] != tc.wantWarnings[0]) {
+			t.Errorf("%s: got warnings %v, want %v", tc.name, response.Warnings, tc.wantWarnings)
+		}
+		if len(response.Errors) != tc.wantErrors {
+			t.Errorf("%s: got %d errors, want %d", tc.name, len(response.Errors), tc.wantErrors)
+		}
+		if response.Response.TotalEntries != tc.wantTotal || len(response.Response.Data) != tc.wantTotal {
+			t.Errorf("%s: got %d of %d records, want %d", tc.name, len(response.Response.Data), response.Response.TotalEntries, tc.wantTotal)
+		}
+	}
+}
+
+func TestDebugLogging(t *testing.T) {
+	for _, tc := range []struct {
+		env  map[string]string
+		want bool
+	}{
+		{env: map[string]string{}},
+		{env: map[string]string{"TF_LOG": "debug"}, want: true},
+		{env: map[string]string{"TF_LOG": "JSON"}, want: true},
+		{env: map[string]string{"TF_LOG": "INFO"}},
+		{env: map[string]string{"TF_LOG": "TRACE", "TF_LOG_PROVIDER": "WARN"}},
+		{env: map[string]string{"TF_LOG": "WARN", "TF_LOG_PROVIDER_HOSTINGDE": "TRACE"}, want: true},
+	} {
+		for _, name := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_HOSTINGDE"} {
+			t.Setenv(name, tc.env[name])
+		}
+		if got := debugLogging(); got != tc.want {
+			t.Errorf("debugLogging() with %v = %t, want %t", tc.env, got, tc.want)
+		}
+	}
+}
+
+func TestUnknownField(t *testing.T) {
+	for body, want := range map[string]string{
+		`{"status": "success", "response": {"data": [{"id": "r1"}]}}`:                       "",
+		`{"status": "success", "newFeature": true}`:                                         "newFeature",
+		`{"status": "success", "response": {"data": [{"id": "r1", "dnssecSigned": true}]}}`: "dnssecSigned",
+		`{"status": `: "",
+	} {
+		if got := unknownField([]byte(body), new(RecordsFindResponse)); got != want {
+			t.Errorf("unknownField(%s) = %q, want %q", body, got, want)
+		}
+	}
+}
+
+func TestClientFallbackBaseURL(t *testing.T) {
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fallback/recordsUpdate" {
+			t.Errorf("got request to %s, want the endpoint below the fallback base URL", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer fallback.Close()
+
+	// A closed server refuses connections
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	baseURL := unreachable.URL + "/api"
+	client := NewClient(nil, nil, &baseURL, ClientOptions{FallbackBaseURL: fallback.URL + "/fallback"})
+
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err != nil {
+		t.Fatalf("updateRecords returned an error: %v", err)
+	}
+
+	// HTTP errors of a reachable API are not retried
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"status": "error"}`)
+	}))
+	defer server.Close()
+
+	client = NewClient(nil, nil, &server.URL, ClientOptions{FallbackBaseURL: fallback.URL + "/fallback"})
+
+	var requestErr *RequestError
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); !errors.As(err, &requestErr) {
+		t.Errorf("got error %v, want the RequestError of the primary", err)
+	}
+}
+
+func TestClientFallbackBaseURLDroppedConnection(t *testing.T) {
+	var fallbackRequests int
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallbackRequests++
+		fmt.Fprint(w, `{"status": "success"}`)
+	}))
+	defer fallback.Close()
+
+	// The primary reads the request, then drops the connection without an
+	// answer, so it may have applied it
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Fatalf("could not hijack connection: %v", err)
+		}
+		conn.Close()
+	}))
+	defer primary.Close()
+
+	client := NewClient(nil, nil, &primary.URL, ClientOptions{FallbackBaseURL: fallback.URL})
+
+	if _, err := client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err == nil {
+		t.Errorf("updateRecords returned no error for a dropped connection")
+	}
+	if fallbackRequests != 0 {
+		t.Errorf("got %d write requests to the fallback, want none", fallbackRequests)
+	}
+
+	// Reads have no side effects and are sent to the fallback
+	if _, err := client.listZoneConfigs(context.Background(), ZoneConfigsFindRequest{BaseRequest: &BaseRequest{}}); err != nil {
+		t.Errorf("listZoneConfigs returned an error: %v", err)
+	}
+	if fallbackRequests != 1 {
+		t.Errorf("got %d read requests to the fallback, want 1", fallbackRequests)
+	}
+}
+
+func TestClientAPIWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "success", "warnings": ["record TTL raised to the minimum"]}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{})
+	ctx, warnings := withClientWarnings(context.Background())
+	if _, err := client.updateRecords(ctx, RecordsUpdateRequest{BaseRequest: &BaseRequest{}}); err != nil {
+		t.Fatalf("updateRecords returned an error: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	warnings.appendTo(&diags)
+	if diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "record TTL raised to the minimum") {
+		t.Errorf("got diagnostics %v, want the warning of the API", diags)
+	}
+
+	// Moved warnings aren't reported twice
+	warnings.appendTo(&diags)
+	if len(diags) != 1 {
+		t.Errorf("got %d diagnostics, want 1", len(diags))
+	}
+}
+
+func TestClientRateLimitHeaders(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		remaining string
+		wantWarn  bool
+	}{
+		{name: "plenty left", remaining: "100"},
+		{name: "at threshold", remaining: "10"},
+		{name: "below threshold", remaining: "9", wantWarn: true},
+		{name: "no headers"},
+		{name: "invalid", remaining: "many"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.remaining != "" {
+					w.Header().Set("X-RateLimit-Limit", "600")
+					w.Header().Set("X-RateLimit-Remaining", tc.remaining)
+					w.Header().Set("X-RateLimit-Reset", "1700000000")
+				}
+				fmt.Fprint(w, `{"status": "success", "response": {"data": [{"id": "1"}]}}`)
+			}))
+			defer server.Close()
+
+			var logs bytes.Buffer
+			ctx, warnings := withClientWarnings(tflogtest.RootLogger(context.Background(), &logs))
+			client := NewClient(nil, nil, &server.URL, ClientOptions{RateLimitWarningThreshold: defaultRateLimitWarningThreshold})
+
+			// The warning is reported once per operation
+			for i := 0; i < 2; i++ {
+				if _, err := client.listRecords(ctx, RecordsFindRequest{BaseRequest: &BaseRequest{}}); err != nil {
+					t.Fatalf("listRecords returned an error: %v", err)
+				}
+			}
+
+			entries, err := tflogtest.MultilineJSONDecode(&logs)
+			if err != nil {
+				t.Fatalf("could not decode logs: %v", err)
+			}
+
+			var logged bool
+			for _, entry := range entries {
+				if entry["@level"] == "debug" && entry["hostingde_ratelimit_limit"] == "600" {
+					logged = true
+				}
+			}
+
+			var diags diag.Diagnostics
+			warnings.appendTo(&diags)
+			wantWarnings := 0
+			if tc.wantWarn {
+				wantWarnings = 1
+			}
+			if diags.WarningsCount() != wantWarnings {
+				t.Errorf("got warnings %v, want %d", diags, wantWarnings)
+			}
+			if wantLogged := tc.remaining != "" && tc.remaining != "many"; logged != wantLogged {
+				t.Errorf("logged rate limit = %t, want %t, logs: %v", logged, wantLogged, entries)
+			}
+		})
+	}
+}
+
+func TestClientReadRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		path        string
+		readRetries int
+		failures    int
+		wantCalls   int32
+		wantErr     bool
+	}{
+		{name: "read recovers", path: "/recordsFind", readRetries: 2, failures: 2, wantCalls: 3},
+		{name: "read exhausts retries", path: "/recordsFind", readRetries: 2, failures: 3, wantCalls: 3, wantErr: true},
+		{name: "retries disabled", path: "/recordsFind", failures: 1, wantCalls: 1, wantErr: true},
+		// Sending a write again could apply it twice
+		{name: "write", path: "/recordsUpdate", readRetries: 2, failures: 1, wantCalls: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if int(calls.Add(1)) <= tc.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				fmt.Fprint(w, `{"status": "success", "response": {"data": [{"id": "1"}]}}`)
+			}))
+			defer server.Close()
+
+			client := NewClient(nil, nil, &server.URL, ClientOptions{ReadRetries: tc.readRetries})
+			client.retryDelay = time.Millisecond
+
+			var err error
+			if tc.path == "/recordsFind" {
+				_, err = client.listRecords(context.Background(), RecordsFindRequest{BaseRequest: &BaseRequest{}})
+			} else {
+				_, err = client.updateRecords(context.Background(), RecordsUpdateRequest{BaseRequest: &BaseRequest{}})
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("got %d requests, want %d", got, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestIsReadRequest(t *testing.T) {
+	for uri, want := range map[string]bool{
+		"https://secure.hosting.de/api/dns/v1/json/zonesFind":            true,
+		"https://secure.hosting.de/api/dns/v1/json/zoneDnsSecOptionsGet": true,
+		"https://secure.hosting.de/api/dns/v1/json/zoneCreate":           false,
+		"https://secure.hosting.de/api/dns/v1/json/recordsUpdate":        false,
+		"https://secure.hosting.de/api/dns/v1/json/zoneDelete":           false,
+	} {
+		if got := isReadRequest(uri); got != want {
+			t.Errorf("isReadRequest(%q) = %t, want %t", uri, got, want)
+		}
+	}
+}
+
+func TestReadRetryDelay(t *testing.T) {
+	client := NewClient(nil, nil, nil, ClientOptions{})
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second} {
+		if got := client.readRetryDelay(attempt); got != want {
+			t.Errorf("delay after attempt %d is %s, want %s", attempt, got, want)
+		}
+	}
+}
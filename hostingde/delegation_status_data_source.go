@@ -0,0 +1,304 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+)
+
+// resolvConfPath is where the system resolver is read from if the data
+// source doesn't configure one.
+const resolvConfPath = "/etc/resolv.conf"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &delegationStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &delegationStatusDataSource{}
+)
+
+// NewDelegationStatusDataSource is a helper function to simplify the provider implementation.
+func NewDelegationStatusDataSource() datasource.DataSource {
+	return &delegationStatusDataSource{}
+}
+
+// delegationStatusDataSource is the data source implementation.
+type delegationStatusDataSource struct {
+	client *Client
+}
+
+// delegationStatusDataSourceModel maps the data source schema data.
+type delegationStatusDataSourceModel struct {
+	ZoneName              types.String `tfsdk:"zone_name"`
+	Resolver              types.String `tfsdk:"resolver"`
+	Timeout               types.String `tfsdk:"timeout"`
+	Delegated             types.Bool   `tfsdk:"delegated"`
+	AssignedNameservers   []string     `tfsdk:"assigned_nameservers"`
+	DelegatedNameservers  []string     `tfsdk:"delegated_nameservers"`
+	MissingNameservers    []string     `tfsdk:"missing_nameservers"`
+	UnexpectedNameservers []string     `tfsdk:"unexpected_nameservers"`
+	ParentZone            types.String `tfsdk:"parent_zone"`
+	ParentNameserver      types.String `tfsdk:"parent_nameserver"`
+}
+
+// Metadata returns the data source type name.
+func (d *delegationStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_delegation_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *delegationStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Compares the nameservers hosting.de assigned to a zone with the delegation published by the parent zone, " +
+			"for example to verify that the registrar applied a delegation. " +
+			"The nameservers of the parent zone are looked up through a resolver and then queried directly, without recursion.",
+		Attributes: map[string]schema.Attribute{
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the zone.",
+				Required:    true,
+			},
+			"resolver": schema.StringAttribute{
+				Description: "Recursive resolver used to look up the nameservers of the parent zone, " +
+					"as host name or IP address with an optional port. Defaults to the first nameserver of " + resolvConfPath + ".",
+				Optional: true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Timeout of each DNS query, as a duration like \"5s\". Defaults to 5s.",
+				Optional:    true,
+			},
+			"delegated": schema.BoolAttribute{
+				Description: "Whether the parent zone delegates the zone to exactly the assigned nameservers.",
+				Computed:    true,
+			},
+			"assigned_nameservers": schema.ListAttribute{
+				Description: "Sorted nameservers of the zone's NS records at the apex in hosting.de.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"delegated_nameservers": schema.ListAttribute{
+				Description: "Sorted nameservers the parent zone delegates the zone to. Empty if the zone isn't delegated.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"missing_nameservers": schema.ListAttribute{
+				Description: "Assigned nameservers the parent zone doesn't delegate to.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"unexpected_nameservers": schema.ListAttribute{
+				Description: "Nameservers the parent zone delegates to that aren't assigned to the zone.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"parent_zone": schema.StringAttribute{
+				Description: "The parent zone the delegation was read from.",
+				Computed:    true,
+			},
+			"parent_nameserver": schema.StringAttribute{
+				Description: "The nameserver of the parent zone that answered.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *delegationStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state delegationStatusDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPropagationTimeout
+	if !state.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(state.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid timeout",
+				"The timeout value must be a positive duration like \"500ms\" or \"5s\", got: "+state.Timeout.ValueString(),
+			)
+			return
+		}
+	}
+
+	resolver := state.Resolver.ValueString()
+	if state.Resolver.IsNull() {
+		config, err := dns.ClientConfigFromFile(resolvConfPath)
+		if err != nil || len(config.Servers) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("resolver"),
+				"No resolver",
+				"Could not read a nameserver from "+resolvConfPath+", please configure resolver.",
+			)
+			return
+		}
+		resolver = config.Servers[0]
+	}
+
+	zoneConfig, err := d.client.getZoneConfigByName(ctx, state.ZoneName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	assigned, err := d.client.listZoneNameservers(ctx, zoneConfig.ID, zoneConfig.Name)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read nameservers of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	parentZone, parentNameservers, err := parentNameservers(ctx, resolver, zoneConfig.Name, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Querying DNS",
+			"Could not look up the parent zone of "+zoneConfig.Name+" through "+resolver+": "+err.Error(),
+		)
+		return
+	}
+
+	parentNameserver, delegated, err := queryDelegation(ctx, parentNameservers, zoneConfig.Name, timeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Querying DNS",
+			"Could not read the delegation of "+zoneConfig.Name+" from the nameservers of "+parentZone+": "+err.Error(),
+		)
+		return
+	}
+
+	state.AssignedNameservers = normalizeNameservers(assigned)
+	state.DelegatedNameservers = delegated
+	state.MissingNameservers = nameserversDifference(state.AssignedNameservers, state.DelegatedNameservers)
+	state.UnexpectedNameservers = nameserversDifference(state.DelegatedNameservers, state.AssignedNameservers)
+	state.Delegated = types.BoolValue(len(delegated) > 0 && slices.Equal(state.AssignedNameservers, state.DelegatedNameservers))
+	state.ParentZone = types.StringValue(parentZone)
+	state.ParentNameserver = types.StringValue(parentNameserver)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *delegationStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
+
+// parentNameservers returns the closest enclosing zone of the given zone
+// that has nameservers, and its nameservers, as resolved by the resolver.
+func parentNameservers(ctx context.Context, resolver string, zoneName string, timeout time.Duration) (string, []string, error) {
+	client := &dns.Client{Timeout: timeout}
+
+	labels := dns.SplitDomainName(zoneName)
+	for i := 1; i <= len(labels); i++ {
+		parent := dns.Fqdn(strings.Join(labels[i:], "."))
+
+		msg := new(dns.Msg)
+		msg.SetQuestion(parent, dns.TypeNS)
+		answer, _, err := client.ExchangeContext(ctx, msg, nameserverAddress(resolver))
+		if err != nil {
+			return "", nil, err
+		}
+		if answer.Rcode != dns.RcodeSuccess && answer.Rcode != dns.RcodeNameError {
+			return "", nil, fmt.Errorf("resolver answered with %s", dns.RcodeToString[answer.Rcode])
+		}
+
+		nameservers := nsRecords(answer.Answer, parent)
+		if len(nameservers) > 0 {
+			return strings.TrimSuffix(parent, "."), nameservers, nil
+		}
+	}
+
+	return "", nil, errors.New("no enclosing zone with nameservers found")
+}
+
+// queryDelegation asks the nameservers of the parent zone for the NS
+// records of the zone, without recursion, and returns the nameserver that
+// answered and the sorted nameservers of the delegation.
+func queryDelegation(ctx context.Context, parentNameservers []string, zoneName string, timeout time.Duration) (string, []string, error) {
+	client := &dns.Client{Timeout: timeout}
+
+	var errs []error
+	for _, nameserver := range parentNameservers {
+		msg := new(dns.Msg)
+		msg.SetQuestion(dns.Fqdn(zoneName), dns.TypeNS)
+		msg.RecursionDesired = false
+
+		answer, _, err := client.ExchangeContext(ctx, msg, nameserverAddress(nameserver))
+		if err == nil && answer.Rcode != dns.RcodeSuccess && answer.Rcode != dns.RcodeNameError {
+			err = fmt.Errorf("nameserver answered with %s", dns.RcodeToString[answer.Rcode])
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", nameserver, err))
+			continue
+		}
+
+		// A referral carries the delegation in the authority section
+		records := append(answer.Answer, answer.Ns...)
+		return nameserver, normalizeNameservers(nsRecords(records, dns.Fqdn(zoneName))), nil
+	}
+
+	return "", nil, errors.Join(errs...)
+}
+
+// nsRecords returns the nameservers of the NS records of the given owner.
+func nsRecords(records []dns.RR, owner string) []string {
+	nameservers := []string{}
+	for _, rr := range records {
+		if ns, ok := rr.(*dns.NS); ok && strings.EqualFold(ns.Hdr.Name, owner) {
+			nameservers = append(nameservers, ns.Ns)
+		}
+	}
+
+	return nameservers
+}
+
+// normalizeNameservers returns the sorted nameservers in lower case and
+// without the trailing dot, so they can be compared.
+func normalizeNameservers(nameservers []string) []string {
+	normalized := []string{}
+	for _, nameserver := range nameservers {
+		normalized = append(normalized, strings.ToLower(strings.TrimSuffix(nameserver, ".")))
+	}
+	slices.Sort(normalized)
+
+	return slices.Compact(normalized)
+}
+
+// nameserversDifference returns the nameservers of a that aren't in b.
+func nameserversDifference(a []string, b []string) []string {
+	difference := []string{}
+	for _, nameserver := range a {
+		if !slices.Contains(b, nameserver) {
+			difference = append(difference, nameserver)
+		}
+	}
+
+	return difference
+}
@@ -0,0 +1,82 @@
+package hostingde
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDelegationStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The test zone isn't delegated by its parent
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example8.test"
+  type = "NATIVE"
+  email = "hostmaster@example8.test"
+}
+data "hostingde_delegation_status" "test" {
+  zone_name = hostingde_zone.test.name
+  resolver  = "1.1.1.1"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hostingde_delegation_status.test", "delegated", "false"),
+					resource.TestCheckResourceAttr("data.hostingde_delegation_status.test", "delegated_nameservers.#", "0"),
+					resource.TestCheckResourceAttrPair("data.hostingde_delegation_status.test", "missing_nameservers", "data.hostingde_delegation_status.test", "assigned_nameservers"),
+				),
+			},
+		},
+	})
+}
+
+func TestParentNameservers(t *testing.T) {
+	resolver := newTestNameserver(t,
+		"example.test. 3600 IN NS ns1.registry.test.",
+		"example.test. 3600 IN NS ns2.registry.test.",
+	)
+
+	// sub.example.test has no NS records of its own, so its parent is example.test
+	parent, nameservers, err := parentNameservers(context.Background(), resolver, "zone.sub.example.test", time.Second)
+	if err != nil {
+		t.Fatalf("parentNameservers returned an error: %v", err)
+	}
+	if parent != "example.test" {
+		t.Errorf("got parent zone %s, want example.test", parent)
+	}
+	if want := []string{"ns1.registry.test.", "ns2.registry.test."}; !slices.Equal(nameservers, want) {
+		t.Errorf("got parent nameservers %v, want %v", nameservers, want)
+	}
+}
+
+func TestQueryDelegation(t *testing.T) {
+	parent := newTestNameserver(t,
+		"zone.example.test. 3600 IN NS NS2.example.net.",
+		"zone.example.test. 3600 IN NS ns1.example.net.",
+	)
+
+	nameserver, delegated, err := queryDelegation(context.Background(), []string{"127.0.0.1:1", parent}, "zone.example.test", 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("queryDelegation returned an error: %v", err)
+	}
+	if nameserver != parent {
+		t.Errorf("got answer from %s, want the reachable nameserver %s", nameserver, parent)
+	}
+	if want := []string{"ns1.example.net", "ns2.example.net"}; !slices.Equal(delegated, want) {
+		t.Errorf("got delegation %v, want %v", delegated, want)
+	}
+
+	assigned := []string{"ns1.example.net", "ns3.example.net"}
+	if got := nameserversDifference(assigned, delegated); !slices.Equal(got, []string{"ns3.example.net"}) {
+		t.Errorf("got missing nameservers %v, want ns3.example.net", got)
+	}
+	if got := nameserversDifference(delegated, assigned); !slices.Equal(got, []string{"ns2.example.net"}) {
+		t.Errorf("got unexpected nameservers %v, want ns2.example.net", got)
+	}
+}
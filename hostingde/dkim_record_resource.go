@@ -0,0 +1,565 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxTXTStringLength is the maximum length of a single character string of
+// a TXT record, longer values are split into several strings.
+const maxTXTStringLength = 255
+
+// dkimTagNamePattern matches the name of a DKIM tag, see RFC 6376 section 3.2.
+var dkimTagNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dkimRecordResource{}
+	_ resource.ResourceWithConfigure      = &dkimRecordResource{}
+	_ resource.ResourceWithValidateConfig = &dkimRecordResource{}
+	_ resource.ResourceWithModifyPlan     = &dkimRecordResource{}
+)
+
+// NewDkimRecordResource is a helper function to simplify the provider implementation.
+func NewDkimRecordResource() resource.Resource {
+	return &dkimRecordResource{}
+}
+
+// dkimRecordResource is the resource implementation.
+type dkimRecordResource struct {
+	client *Client
+}
+
+// dkimRecordResourceModel maps the DKIM record resource schema data.
+type dkimRecordResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	ZoneID   types.String `tfsdk:"zone_id"`
+	Selector types.String `tfsdk:"selector"`
+	Domain   types.String `tfsdk:"domain"`
+	Tags     types.Map    `tfsdk:"tags"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Name     types.String `tfsdk:"name"`
+	Content  types.String `tfsdk:"content"`
+}
+
+// Metadata returns the resource type name.
+func (r *dkimRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dkim_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *dkimRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the TXT record of a DKIM public key, assembled from its tags. " +
+			"The tags are joined in canonical order, v first and p last, and the value is split into strings of at most 255 characters. " +
+			"If the record is changed outside of Terraform, the tags are parsed from its content. " +
+			"Content that can't be parsed keeps the configured tags and is only shown in content.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the TXT record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone that the record belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"selector": schema.StringAttribute{
+				Description: "DKIM selector, the s tag of the signatures. Example: mail2024.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Signing domain, the d tag of the signatures. Must be the zone or a name within it. Example: example.com.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the DKIM key record, like v = \"DKIM1\", k = \"rsa\" and p = \"MIIBIjANBg...\". " +
+					"The p tag with the public key is required, values must not contain semicolons.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the record in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(defaultRecordTTL),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 31556926),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the TXT record. Example: mail2024._domainkey.example.com.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the TXT record, the assembled tags split into quoted strings.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *dkimRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create DKIM record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan dkimRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, plan.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := dkimRecordName(plan.Selector.ValueString(), plan.Domain.ValueString())
+	if !isAbsoluteRecordName(name, zoneName) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Domain outside of the zone",
+			"The DKIM record "+name+" doesn't belong to the zone "+zoneName+". Set domain to the zone or a name within it.",
+		)
+		return
+	}
+
+	record := DNSRecord{
+		Name:     name,
+		ZoneID:   plan.ZoneID.ValueString(),
+		Type:     "TXT",
+		Content:  plan.Content.ValueString(),
+		TTL:      int(plan.TTL.ValueInt64()),
+		Comments: r.client.options.ManagedByComment,
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordResp, err := r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: plan.ZoneID.ValueString(),
+		RecordsToAdd: []DNSRecord{record},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not create DKIM record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var returnedRecord *DNSRecord
+	for i, r := range recordResp.Response.Records {
+		if r.Name == record.Name && r.Type == record.Type && txtValue(r.Content) == txtValue(record.Content) {
+			returnedRecord = &recordResp.Response.Records[i]
+		}
+	}
+	if returnedRecord == nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not find the created DKIM record "+record.Name+" in the response of hosting.de",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(returnedRecord.ID)
+	plan.Name = types.StringValue(returnedRecord.Name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dkimRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state dkimRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read DKIM record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The API may quote or split the value differently
+	if txtValue(record.Content) != txtValue(state.Content.ValueString()) {
+		state.Content = types.StringValue(record.Content)
+
+		tags, err := parseDKIMTags(txtValue(record.Content))
+		if err != nil {
+			tflog.Warn(ctx, "Could not parse the tags of the DKIM record, keeping the configured tags", map[string]any{
+				"hostingde_record_id": record.ID,
+				"error":               err.Error(),
+			})
+		} else {
+			state.Tags, diags = types.MapValueFrom(ctx, types.StringType, tags)
+			resp.Diagnostics.Append(diags...)
+		}
+	}
+
+	state.ZoneID = types.StringValue(record.ZoneID)
+	state.Name = types.StringValue(record.Name)
+	state.TTL = types.Int64Value(int64(record.TTL))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dkimRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update DKIM record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan and prior state
+	var plan, state dkimRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	content := plan.Content.ValueString()
+	ttl := int(plan.TTL.ValueInt64())
+	fields := RecordFields{TTL: &ttl}
+	if !plan.Content.Equal(state.Content) {
+		fields.Content = &content
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.patchRecord(ctx, state.ID.ValueString(), fields); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not update DKIM record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dkimRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete DKIM record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state dkimRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Record already deleted", map[string]any{
+			"hostingde_record_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read DKIM record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: state.ZoneID.ValueString(),
+		RecordsToDelete: []DNSRecord{{
+			ID:   state.ID.ValueString(),
+			Name: state.Name.ValueString(),
+			Type: "TXT",
+		}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Record",
+			"Could not delete DKIM record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dkimRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ModifyPlan plans the name and the assembled content of the record. The
+// content of unchanged tags is kept, so a different quoting by the API
+// doesn't show up as a change.
+func (r *dkimRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan dkimRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Selector.IsUnknown() && !plan.Domain.IsUnknown() {
+		plan.Name = types.StringValue(dkimRecordName(plan.Selector.ValueString(), plan.Domain.ValueString()))
+	}
+
+	if !plan.Tags.IsUnknown() {
+		var tags map[string]string
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Content = types.StringValue(chunkTXT(assembleDKIMTags(tags)))
+
+		if !req.State.Raw.IsNull() {
+			var state dkimRecordResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			// Content changed outside of Terraform is only kept if it
+			// still holds exactly the planned tags
+			if stateTags, err := parseDKIMTags(txtValue(state.Content.ValueString())); err == nil && maps.Equal(stateTags, tags) {
+				plan.Content = state.Content
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// ValidateConfig checks the DKIM tags.
+func (r *dkimRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData dkimRecordResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDKIMTags(configData.Tags)...)
+}
+
+// validateDKIMTags checks the names and values of the tags, and that the
+// public key is present.
+func validateDKIMTags(tags types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if tags.IsNull() || tags.IsUnknown() {
+		return diags
+	}
+
+	if _, ok := tags.Elements()["p"]; !ok {
+		diags.AddAttributeError(
+			path.Root("tags"),
+			"Missing DKIM tag",
+			"The p tag with the public key is required. Set it to an empty string to revoke the key.",
+		)
+	}
+
+	for name, element := range tags.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		if !dkimTagNamePattern.MatchString(name) {
+			diags.AddAttributeError(
+				path.Root("tags").AtMapKey(name),
+				"Invalid DKIM tag",
+				"Tag names start with a letter and contain only letters, digits and underscores, got: "+name,
+			)
+		}
+		if strings.Contains(value.ValueString(), ";") || strings.TrimSpace(value.ValueString()) != value.ValueString() {
+			diags.AddAttributeError(
+				path.Root("tags").AtMapKey(name),
+				"Invalid DKIM tag",
+				"The value of tag "+name+" must not contain semicolons or start or end with whitespace.",
+			)
+		}
+	}
+
+	return diags
+}
+
+// dkimRecordName returns the name of the TXT record holding the key of a
+// selector.
+func dkimRecordName(selector string, domain string) string {
+	return selector + "._domainkey." + strings.TrimSuffix(domain, ".")
+}
+
+// assembleDKIMTags joins the tags of a DKIM key record. The v tag has to come
+// first, the p tag is put last as it is the longest, the others are sorted.
+func assembleDKIMTags(tags map[string]string) string {
+	var names []string
+	for name := range tags {
+		if name != "v" && name != "p" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := tags["v"]; ok {
+		names = append([]string{"v"}, names...)
+	}
+	if _, ok := tags["p"]; ok {
+		names = append(names, "p")
+	}
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"="+tags[name])
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// parseDKIMTags splits the value of a DKIM key record into its tags, the
+// inverse of assembleDKIMTags.
+func parseDKIMTags(value string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, tagValue, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !dkimTagNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid tag %q", part)
+		}
+		if _, ok := tags[name]; ok {
+			return nil, fmt.Errorf("duplicate tag %s", name)
+		}
+		tags[name] = strings.TrimSpace(tagValue)
+	}
+
+	return tags, nil
+}
+
+// chunkTXT splits a value into quoted strings of at most 255 characters, as
+// a single string of a TXT record can't be longer. Quotes and backslashes in
+// the value are escaped, the limit applies to the unescaped characters.
+func chunkTXT(value string) string {
+	var chunks []string
+	for len(value) > maxTXTStringLength {
+		chunks = append(chunks, `"`+txtEscaper.Replace(value[:maxTXTStringLength])+`"`)
+		value = value[maxTXTStringLength:]
+	}
+	chunks = append(chunks, `"`+txtEscaper.Replace(value)+`"`)
+
+	return strings.Join(chunks, " ")
+}
+
+// txtEscaper escapes the characters that end or escape a quoted string of a
+// TXT record.
+var txtEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
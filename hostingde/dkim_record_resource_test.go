@@ -0,0 +1,197 @@
+package hostingde
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAssembleDKIMTags(t *testing.T) {
+	tags := map[string]string{"p": "MIIBIjANBg", "t": "s", "k": "rsa", "v": "DKIM1"}
+
+	value := assembleDKIMTags(tags)
+	if want := "v=DKIM1; k=rsa; t=s; p=MIIBIjANBg"; value != want {
+		t.Errorf("assembleDKIMTags() = %q, want %q", value, want)
+	}
+
+	parsed, err := parseDKIMTags(value)
+	if err != nil {
+		t.Fatalf("parseDKIMTags(%q): %v", value, err)
+	}
+	if !maps.Equal(parsed, tags) {
+		t.Errorf("parseDKIMTags(%q) = %v, want %v", value, parsed, tags)
+	}
+}
+
+func TestParseDKIMTags(t *testing.T) {
+	for _, tc := range []struct {
+		value     string
+		want      map[string]string
+		wantError bool
+	}{
+		{value: "v=DKIM1;k=rsa; p=MIIB;", want: map[string]string{"v": "DKIM1", "k": "rsa", "p": "MIIB"}},
+		{value: "v = DKIM1 ; p=", want: map[string]string{"v": "DKIM1", "p": ""}},
+		{value: "v=DKIM1; some text", wantError: true},
+		{value: "p=MIIB; p=MIIC", wantError: true},
+	} {
+		tags, err := parseDKIMTags(tc.value)
+		if (err != nil) != tc.wantError {
+			t.Errorf("parseDKIMTags(%q): got error %v, want error %t", tc.value, err, tc.wantError)
+			continue
+		}
+		if !tc.wantError && !maps.Equal(tags, tc.want) {
+			t.Errorf("parseDKIMTags(%q) = %v, want %v", tc.value, tags, tc.want)
+		}
+	}
+}
+
+func TestChunkTXT(t *testing.T) {
+	value := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+
+	chunked := chunkTXT(value)
+	chunks := strings.Split(chunked, `" "`)
+	if len(chunks) != 2 {
+		t.Fatalf("chunkTXT() returned %d strings, want 2: %s", len(chunks), chunked)
+	}
+	if len(chunks[0]) != maxTXTStringLength+1 {
+		t.Errorf("first string has %d characters, want %d", len(chunks[0])-1, maxTXTStringLength)
+	}
+
+	// Resolvers concatenate the strings again
+	if got := txtValue(chunked); got != value {
+		t.Errorf("chunkTXT() doesn't round trip, got %q", got)
+	}
+
+	if got := chunkTXT("v=DKIM1; p="); got != `"v=DKIM1; p="` {
+		t.Errorf("chunkTXT() = %s, want a single string", got)
+	}
+}
+
+func TestChunkTXTEscapes(t *testing.T) {
+	value := `v=DKIM1; n="note \ here"; p=` + strings.Repeat("A", 300)
+
+	chunked := chunkTXT(value)
+	if !strings.HasPrefix(chunked, `"v=DKIM1; n=\"note \\ here\"; p=`) {
+		t.Errorf("chunkTXT() = %s, want quotes and backslashes escaped", chunked)
+	}
+	if got := txtValue(chunked); got != value {
+		t.Errorf("chunkTXT() doesn't round trip, got %q", got)
+	}
+}
+
+func TestDkimRecordResourceModifyPlanContent(t *testing.T) {
+	tags := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"v": tftypes.NewValue(tftypes.String, "DKIM1"),
+		"p": tftypes.NewValue(tftypes.String, "MIGf"),
+	})
+	config := map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "1"),
+		"zone_id":  tftypes.NewValue(tftypes.String, "1"),
+		"selector": tftypes.NewValue(tftypes.String, "mail"),
+		"domain":   tftypes.NewValue(tftypes.String, "example.test"),
+		"tags":     tags,
+		"ttl":      tftypes.NewValue(tftypes.Number, 3600),
+	}
+
+	for name, tc := range map[string]struct {
+		stateContent string
+		want         string
+	}{
+		"requoted":    {stateContent: `"v=DKIM1;" " p=MIGf"`, want: `"v=DKIM1;" " p=MIGf"`},
+		"extra tag":   {stateContent: `"v=DKIM1; p=MIGf; t=y"`, want: `"v=DKIM1; p=MIGf"`},
+		"unparsable":  {stateContent: `"v=DKIM1; p=MIGf; garbage"`, want: `"v=DKIM1; p=MIGf"`},
+		"changed key": {stateContent: `"v=DKIM1; p=OTHER"`, want: `"v=DKIM1; p=MIGf"`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The tags in state are unchanged, as Read keeps them for content it can't parse
+			state := map[string]tftypes.Value{}
+			for attribute, value := range config {
+				state[attribute] = value
+			}
+			state["content"] = tftypes.NewValue(tftypes.String, tc.stateContent)
+
+			plan, diags := testModifyPlan(t, &dkimRecordResource{}, config, config, state)
+			if diags.HasError() {
+				t.Fatalf("ModifyPlan returned errors: %v", diags)
+			}
+
+			var content types.String
+			plan.GetAttribute(context.Background(), path.Root("content"), &content)
+			if content.ValueString() != tc.want {
+				t.Errorf("got planned content %s, want %s", content, tc.want)
+			}
+		})
+	}
+}
+
+func TestDkimRecordResourceValidateTags(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tags      map[string]string
+		wantError string
+	}{
+		"valid":           {tags: map[string]string{"v": "DKIM1", "p": "MIIB"}},
+		"revoked":         {tags: map[string]string{"v": "DKIM1", "p": ""}},
+		"missing key":     {tags: map[string]string{"v": "DKIM1"}, wantError: "Missing DKIM tag"},
+		"invalid name":    {tags: map[string]string{"p": "MIIB", "1x": "y"}, wantError: "Invalid DKIM tag"},
+		"semicolon value": {tags: map[string]string{"p": "MIIB; t=y"}, wantError: "Invalid DKIM tag"},
+	} {
+		tags := map[string]tftypes.Value{}
+		for tag, value := range tc.tags {
+			tags[tag] = tftypes.NewValue(tftypes.String, value)
+		}
+
+		diags := testValidateResourceConfig(t, NewDkimRecordResource(), map[string]tftypes.Value{
+			"zone_id":  tftypes.NewValue(tftypes.String, "1"),
+			"selector": tftypes.NewValue(tftypes.String, "mail"),
+			"domain":   tftypes.NewValue(tftypes.String, "example.test"),
+			"tags":     tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tags),
+		})
+
+		var gotError string
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				gotError = d.Summary
+			}
+		}
+		if gotError != tc.wantError {
+			t.Errorf("%s: got error %q, want %q, diagnostics: %v", name, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestDkimRecordResourceStrictWarnings(t *testing.T) {
+	// The warning of the zone lookup fails before the record is created, a
+	// request to /recordsUpdate fails the test
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Warnings = []string{"zone is scheduled for deletion"}
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+	})
+	client.options.StrictWarnings = true
+
+	_, diags := testCreateResource(t, &dkimRecordResource{client: client}, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id":  tftypes.NewValue(tftypes.String, "1"),
+		"selector": tftypes.NewValue(tftypes.String, "mail"),
+		"domain":   tftypes.NewValue(tftypes.String, "example.test"),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"p": tftypes.NewValue(tftypes.String, "MIGf"),
+		}),
+		"content": tftypes.NewValue(tftypes.String, `"v=DKIM1; p=MIGf"`),
+		"ttl":     tftypes.NewValue(tftypes.Number, 3600),
+	})
+	if !diags.HasError() {
+		t.Errorf("got diagnostics %v, want an error before any change", diags)
+	}
+}
@@ -0,0 +1,710 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// dmarcAlignments maps the alignment attributes to the values of the adkim
+// and aspf tags.
+var dmarcAlignments = map[string]string{
+	"relaxed": "r",
+	"strict":  "s",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dmarcRecordResource{}
+	_ resource.ResourceWithConfigure      = &dmarcRecordResource{}
+	_ resource.ResourceWithValidateConfig = &dmarcRecordResource{}
+	_ resource.ResourceWithModifyPlan     = &dmarcRecordResource{}
+)
+
+// NewDmarcRecordResource is a helper function to simplify the provider implementation.
+func NewDmarcRecordResource() resource.Resource {
+	return &dmarcRecordResource{}
+}
+
+// dmarcRecordResource is the resource implementation.
+type dmarcRecordResource struct {
+	client *Client
+}
+
+// dmarcRecordResourceModel maps the DMARC record resource schema data.
+type dmarcRecordResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ZoneID        types.String `tfsdk:"zone_id"`
+	Policy        types.String `tfsdk:"policy"`
+	RUA           types.List   `tfsdk:"rua"`
+	RUF           types.List   `tfsdk:"ruf"`
+	Percent       types.Int64  `tfsdk:"pct"`
+	DKIMAlignment types.String `tfsdk:"dkim_alignment"`
+	SPFAlignment  types.String `tfsdk:"spf_alignment"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+	Name          types.String `tfsdk:"name"`
+	Content       types.String `tfsdk:"content"`
+}
+
+// dmarcPolicy holds the structured fields of a DMARC record. Empty fields are
+// left out of the record.
+type dmarcPolicy struct {
+	Policy        string
+	RUA           []string
+	RUF           []string
+	Percent       *int64
+	DKIMAlignment string
+	SPFAlignment  string
+}
+
+// Metadata returns the resource type name.
+func (r *dmarcRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dmarc_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *dmarcRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the DMARC policy of a zone, the TXT record at _dmarc below the apex, assembled from its tags. " +
+			"The tags are joined as \"v=DMARC1; p=...; pct=...; rua=...; ruf=...; adkim=...; aspf=...\", leaving out unset tags. " +
+			"Creating the record fails if the zone already has a DMARC record, as receivers ignore the policy if there are several. " +
+			"If the record is changed outside of Terraform, the tags are parsed from its content. " +
+			"Content with other tags, like sp or fo, keeps the configured tags and is only shown in content.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the TXT record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone that the record belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"policy": schema.StringAttribute{
+				Description: "Policy for mail failing the DMARC check, the p tag: none, quarantine or reject.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("none", "quarantine", "reject"),
+				},
+			},
+			"rua": schema.ListAttribute{
+				Description: "URIs aggregate reports are sent to, like mailto:dmarc@example.com or https://dmarc.example.com/report. " +
+					"Only mailto and https URIs are allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ruf": schema.ListAttribute{
+				Description: "URIs failure reports are sent to, like mailto:dmarc-failures@example.com. Only mailto and https URIs are allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"pct": schema.Int64Attribute{
+				Description: "Percentage of failing mail the policy is applied to, from 0 to 100. Receivers apply it to all mail if unset.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"dkim_alignment": schema.StringAttribute{
+				Description: "DKIM identifier alignment, the adkim tag: relaxed or strict. Receivers use relaxed if unset.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("relaxed", "strict"),
+				},
+			},
+			"spf_alignment": schema.StringAttribute{
+				Description: "SPF identifier alignment, the aspf tag: relaxed or strict. Receivers use relaxed if unset.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("relaxed", "strict"),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the record in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(defaultRecordTTL),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 31556926),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the TXT record. Example: _dmarc.example.com.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the TXT record, the assembled tags split into quoted strings.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *dmarcRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create DMARC record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan dmarcRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, plan.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	name := "_dmarc." + zoneName
+
+	// Receivers ignore the policy if there is more than one DMARC record
+	existing, err := r.client.listRecordsByName(ctx, plan.ZoneID.ValueString(), name, "TXT")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not list the TXT records of "+name+": "+err.Error(),
+		)
+		return
+	}
+	for _, record := range existing {
+		if isDMARCRecord(record) {
+			resp.Diagnostics.AddError(
+				"Existing DMARC record",
+				"The zone "+zoneName+" already has the DMARC record "+record.ID+" with content "+record.Content+". "+
+					"A domain must have exactly one DMARC record, delete the existing record or remove it from its resource first.",
+			)
+			return
+		}
+	}
+
+	record := DNSRecord{
+		Name:     name,
+		ZoneID:   plan.ZoneID.ValueString(),
+		Type:     "TXT",
+		Content:  plan.Content.ValueString(),
+		TTL:      int(plan.TTL.ValueInt64()),
+		Comments: r.client.options.ManagedByComment,
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordResp, err := r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: plan.ZoneID.ValueString(),
+		RecordsToAdd: []DNSRecord{record},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not create DMARC record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var returnedRecord *DNSRecord
+	for i, r := range recordResp.Response.Records {
+		if r.Name == record.Name && r.Type == record.Type && txtValue(r.Content) == txtValue(record.Content) {
+			returnedRecord = &recordResp.Response.Records[i]
+		}
+	}
+	if returnedRecord == nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not find the created DMARC record "+record.Name+" in the response of hosting.de",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(returnedRecord.ID)
+	plan.Name = types.StringValue(returnedRecord.Name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dmarcRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state dmarcRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read DMARC record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The API may quote or split the value differently
+	if txtValue(record.Content) != txtValue(state.Content.ValueString()) {
+		state.Content = types.StringValue(record.Content)
+
+		policy, err := parseDMARC(txtValue(record.Content))
+		if err != nil {
+			tflog.Warn(ctx, "Could not parse the tags of the DMARC record, keeping the configured tags", map[string]any{
+				"hostingde_record_id": record.ID,
+				"error":               err.Error(),
+			})
+		} else {
+			resp.Diagnostics.Append(state.setPolicy(ctx, policy)...)
+		}
+	}
+
+	state.ZoneID = types.StringValue(record.ZoneID)
+	state.Name = types.StringValue(record.Name)
+	state.TTL = types.Int64Value(int64(record.TTL))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dmarcRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update DMARC record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan and prior state
+	var plan, state dmarcRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	content := plan.Content.ValueString()
+	ttl := int(plan.TTL.ValueInt64())
+	fields := RecordFields{TTL: &ttl}
+	if !plan.Content.Equal(state.Content) {
+		fields.Content = &content
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.patchRecord(ctx, state.ID.ValueString(), fields); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not update DMARC record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dmarcRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete DMARC record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state dmarcRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Record already deleted", map[string]any{
+			"hostingde_record_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read DMARC record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: state.ZoneID.ValueString(),
+		RecordsToDelete: []DNSRecord{{
+			ID:   state.ID.ValueString(),
+			Name: state.Name.ValueString(),
+			Type: "TXT",
+		}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Record",
+			"Could not delete DMARC record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dmarcRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ModifyPlan plans the assembled content of the record. The content of
+// unchanged tags is kept, so a different quoting by the API doesn't show up
+// as a change.
+func (r *dmarcRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan dmarcRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, known, diags := plan.policy(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+	plan.Content = types.StringValue(chunkTXT(assembleDMARC(policy)))
+
+	if !req.State.Raw.IsNull() {
+		var state dmarcRecordResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if txtValue(plan.Content.ValueString()) == txtValue(state.Content.ValueString()) {
+			plan.Content = state.Content
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// ValidateConfig checks the reporting URIs.
+func (r *dmarcRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData dmarcRecordResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDMARCURIs(configData.RUA, "rua")...)
+	resp.Diagnostics.Append(validateDMARCURIs(configData.RUF, "ruf")...)
+}
+
+// policy returns the configured tags, or false if some are unknown.
+func (m dmarcRecordResourceModel) policy(ctx context.Context) (dmarcPolicy, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var policy dmarcPolicy
+
+	for _, value := range []types.List{m.RUA, m.RUF} {
+		if value.IsUnknown() {
+			return policy, false, diags
+		}
+		for _, element := range value.Elements() {
+			if element.IsUnknown() {
+				return policy, false, diags
+			}
+		}
+	}
+	if m.Policy.IsUnknown() || m.Percent.IsUnknown() || m.DKIMAlignment.IsUnknown() || m.SPFAlignment.IsUnknown() {
+		return policy, false, diags
+	}
+
+	diags.Append(m.RUA.ElementsAs(ctx, &policy.RUA, false)...)
+	diags.Append(m.RUF.ElementsAs(ctx, &policy.RUF, false)...)
+	policy.Policy = m.Policy.ValueString()
+	policy.Percent = m.Percent.ValueInt64Pointer()
+	policy.DKIMAlignment = m.DKIMAlignment.ValueString()
+	policy.SPFAlignment = m.SPFAlignment.ValueString()
+
+	return policy, !diags.HasError(), diags
+}
+
+// setPolicy sets the structured fields from a parsed record. Empty lists stay
+// null if they are null, so they don't show up as a change.
+func (m *dmarcRecordResourceModel) setPolicy(ctx context.Context, policy dmarcPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, field := range []struct {
+		list   *types.List
+		values []string
+	}{
+		{list: &m.RUA, values: policy.RUA},
+		{list: &m.RUF, values: policy.RUF},
+	} {
+		if len(field.values) == 0 && field.list.IsNull() {
+			continue
+		}
+		list, d := types.ListValueFrom(ctx, types.StringType, field.values)
+		diags.Append(d...)
+		*field.list = list
+	}
+
+	m.Policy = types.StringValue(policy.Policy)
+	m.Percent = types.Int64PointerValue(policy.Percent)
+	m.DKIMAlignment = optionalString(policy.DKIMAlignment)
+	m.SPFAlignment = optionalString(policy.SPFAlignment)
+
+	return diags
+}
+
+// optionalString returns null for an empty string.
+func optionalString(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
+// validateDMARCURIs checks the known elements of a list of reporting URIs.
+func validateDMARCURIs(list types.List, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	for i, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		if err := validateDMARCURI(value.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root(name).AtListIndex(i),
+				"Invalid DMARC reporting URI",
+				"Invalid "+name+" URI "+strconv.Quote(value.ValueString())+": "+err.Error(),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateDMARCURI checks that a reporting URI is a mailto URI with a single
+// address or an https URL. Commas and semicolons separate URIs and tags in
+// the record, so they must not appear in a URI.
+func validateDMARCURI(uri string) error {
+	if strings.ContainsAny(uri, ",; \t\"") {
+		return errors.New("must not contain commas, semicolons, whitespace or quotes")
+	}
+
+	// A size limit like !10m may follow the URI, see RFC 7489 section 6.2
+	uri, _, _ = strings.Cut(uri, "!")
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return err
+	}
+
+	switch parsed.Scheme {
+	case "mailto":
+		if _, err := mail.ParseAddress(parsed.Opaque); err != nil || !strings.Contains(parsed.Opaque, "@") {
+			return fmt.Errorf("expected a mail address like mailto:dmarc@example.com")
+		}
+	case "https":
+		if parsed.Host == "" {
+			return fmt.Errorf("expected a URL like https://dmarc.example.com/report")
+		}
+	default:
+		return fmt.Errorf("expected a mailto or https URI")
+	}
+
+	return nil
+}
+
+// isDMARCRecord returns whether a TXT record is a DMARC record, i.e. whether
+// its value starts with the version v=DMARC1.
+func isDMARCRecord(record DNSRecord) bool {
+	version, _, _ := strings.Cut(txtValue(record.Content), ";")
+	return strings.TrimSpace(version) == "v=DMARC1"
+}
+
+// assembleDMARC joins the tags of a DMARC record. The p tag has to follow the
+// version, unset tags are left out.
+func assembleDMARC(policy dmarcPolicy) string {
+	tags := []string{"v=DMARC1", "p=" + policy.Policy}
+	if policy.Percent != nil {
+		tags = append(tags, "pct="+strconv.FormatInt(*policy.Percent, 10))
+	}
+	if len(policy.RUA) > 0 {
+		tags = append(tags, "rua="+strings.Join(policy.RUA, ","))
+	}
+	if len(policy.RUF) > 0 {
+		tags = append(tags, "ruf="+strings.Join(policy.RUF, ","))
+	}
+	if policy.DKIMAlignment != "" {
+		tags = append(tags, "adkim="+dmarcAlignments[policy.DKIMAlignment])
+	}
+	if policy.SPFAlignment != "" {
+		tags = append(tags, "aspf="+dmarcAlignments[policy.SPFAlignment])
+	}
+
+	return strings.Join(tags, "; ")
+}
+
+// parseDMARC splits the value of a DMARC record into its tags, the inverse of
+// assembleDMARC. Tags that can't be represented by the resource, like sp or
+// fo, are returned as an error.
+func parseDMARC(value string) (dmarcPolicy, error) {
+	var policy dmarcPolicy
+
+	tags, err := parseDKIMTags(value)
+	if err != nil {
+		return policy, err
+	}
+	if tags["v"] != "DMARC1" {
+		return policy, errors.New("missing version v=DMARC1")
+	}
+
+	alignment := func(value string) (string, error) {
+		for name, tag := range dmarcAlignments {
+			if value == tag {
+				return name, nil
+			}
+		}
+		return "", fmt.Errorf("invalid alignment %q", value)
+	}
+	uris := func(value string) []string {
+		var uris []string
+		for _, uri := range strings.Split(value, ",") {
+			uris = append(uris, strings.TrimSpace(uri))
+		}
+		return uris
+	}
+
+	for name, value := range tags {
+		switch name {
+		case "v":
+		case "p":
+			policy.Policy = value
+		case "pct":
+			percent, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return policy, fmt.Errorf("invalid pct %q", value)
+			}
+			policy.Percent = &percent
+		case "rua":
+			policy.RUA = uris(value)
+		case "ruf":
+			policy.RUF = uris(value)
+		case "adkim":
+			if policy.DKIMAlignment, err = alignment(value); err != nil {
+				return policy, err
+			}
+		case "aspf":
+			if policy.SPFAlignment, err = alignment(value); err != nil {
+				return policy, err
+			}
+		default:
+			return policy, fmt.Errorf("unsupported tag %s", name)
+		}
+	}
+
+	if policy.Policy == "" {
+		return policy, errors.New("missing policy tag p")
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,125 @@
+package hostingde
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAssembleDMARC(t *testing.T) {
+	percent := int64(50)
+	policy := dmarcPolicy{
+		Policy:        "quarantine",
+		RUA:           []string{"mailto:dmarc@example.test", "https://dmarc.example.net/report"},
+		RUF:           []string{"mailto:dmarc-failures@example.test!10m"},
+		Percent:       &percent,
+		DKIMAlignment: "strict",
+		SPFAlignment:  "relaxed",
+	}
+
+	value := assembleDMARC(policy)
+	want := "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.test,https://dmarc.example.net/report; " +
+		"ruf=mailto:dmarc-failures@example.test!10m; adkim=s; aspf=r"
+	if value != want {
+		t.Errorf("assembleDMARC() = %q, want %q", value, want)
+	}
+
+	parsed, err := parseDMARC(value)
+	if err != nil {
+		t.Fatalf("parseDMARC(%q): %v", value, err)
+	}
+	if !reflect.DeepEqual(parsed, policy) {
+		t.Errorf("parseDMARC(%q) = %+v, want %+v", value, parsed, policy)
+	}
+
+	if value := assembleDMARC(dmarcPolicy{Policy: "none"}); value != "v=DMARC1; p=none" {
+		t.Errorf("assembleDMARC() = %q without optional tags, want v=DMARC1; p=none", value)
+	}
+}
+
+func TestParseDMARC(t *testing.T) {
+	for _, tc := range []struct {
+		value     string
+		want      dmarcPolicy
+		wantError bool
+	}{
+		{value: "v=DMARC1;p=reject", want: dmarcPolicy{Policy: "reject"}},
+		{value: "v=DMARC1; p=none; rua=mailto:a@example.test, mailto:b@example.test;", want: dmarcPolicy{Policy: "none", RUA: []string{"mailto:a@example.test", "mailto:b@example.test"}}},
+		{value: "v=DMARC1; p=reject; sp=none", wantError: true},
+		{value: "v=DMARC1; rua=mailto:a@example.test", wantError: true},
+		{value: "v=DMARC1; p=reject; adkim=x", wantError: true},
+		{value: "v=DMARC1; p=reject; pct=all", wantError: true},
+		{value: "v=spf1 -all", wantError: true},
+	} {
+		policy, err := parseDMARC(tc.value)
+		if (err != nil) != tc.wantError {
+			t.Errorf("parseDMARC(%q): got error %v, want error %t", tc.value, err, tc.wantError)
+			continue
+		}
+		if !tc.wantError && !reflect.DeepEqual(policy, tc.want) {
+			t.Errorf("parseDMARC(%q) = %+v, want %+v", tc.value, policy, tc.want)
+		}
+	}
+}
+
+func TestIsDMARCRecord(t *testing.T) {
+	for content, want := range map[string]bool{
+		`"v=DMARC1; p=none"`:  true,
+		`"v=DMARC1;p=reject"`: true,
+		`"v=DMARC10; p=none"`: false,
+		`"v=spf1 -all"`:       false,
+	} {
+		if got := isDMARCRecord(DNSRecord{Type: "TXT", Content: content}); got != want {
+			t.Errorf("isDMARCRecord(%s) = %t, want %t", content, got, want)
+		}
+	}
+}
+
+func TestDmarcRecordResourceValidateConfig(t *testing.T) {
+	list := func(values ...string) tftypes.Value {
+		elements := []tftypes.Value{}
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+
+	for name, tc := range map[string]struct {
+		values    map[string]tftypes.Value
+		wantError string
+	}{
+		"valid": {values: map[string]tftypes.Value{
+			"rua": list("mailto:dmarc@example.test", "https://dmarc.example.net/report"),
+			"ruf": list("mailto:dmarc@example.test!10m"),
+			"pct": tftypes.NewValue(tftypes.Number, 25),
+		}},
+		"invalid policy":   {values: map[string]tftypes.Value{"policy": tftypes.NewValue(tftypes.String, "block")}, wantError: "Invalid Attribute Value Match"},
+		"plain address":    {values: map[string]tftypes.Value{"rua": list("dmarc@example.test")}, wantError: "Invalid DMARC reporting URI"},
+		"http":             {values: map[string]tftypes.Value{"rua": list("http://dmarc.example.net/report")}, wantError: "Invalid DMARC reporting URI"},
+		"invalid address":  {values: map[string]tftypes.Value{"ruf": list("mailto:dmarc")}, wantError: "Invalid DMARC reporting URI"},
+		"several in one":   {values: map[string]tftypes.Value{"rua": list("mailto:a@example.test,mailto:b@example.test")}, wantError: "Invalid DMARC reporting URI"},
+		"percent too high": {values: map[string]tftypes.Value{"pct": tftypes.NewValue(tftypes.Number, 101)}, wantError: "Invalid Attribute Value"},
+	} {
+		values := map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"policy":  tftypes.NewValue(tftypes.String, "reject"),
+		}
+		for attribute, value := range tc.values {
+			values[attribute] = value
+		}
+
+		diags := testValidateResourceConfig(t, NewDmarcRecordResource(), values)
+
+		var gotError string
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				gotError = d.Summary
+			}
+		}
+		if gotError != tc.wantError {
+			t.Errorf("%s: got error %q, want %q, diagnostics: %v", name, gotError, tc.wantError, diags)
+		}
+	}
+}
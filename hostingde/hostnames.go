@@ -0,0 +1,244 @@
+package hostingde
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// Length limits of host names, see RFC 1035 section 2.3.4. The 255 byte
+// limit of the wire format leaves 253 characters for the text form.
+const (
+	maxLabelLength    = 63
+	maxHostnameLength = 253
+	maxWireNameLength = 255
+)
+
+// hostnameContentFields maps the record types whose content contains a host
+// name to the position of the host name among the space separated fields of
+// the content, without the priority.
+var hostnameContentFields = map[string]int{
+	"ALIAS": 0,
+	"CNAME": 0,
+	"MX":    0,
+	"NS":    0,
+	"PTR":   0,
+	"SRV":   2,
+}
+
+// hostnameTargetTypes are the record types whose target must be a host name.
+// An IP address there is a common mistake, which the API only rejects with an
+// unspecific error.
+var hostnameTargetTypes = []string{"CNAME", "MX", "NS", "SRV"}
+
+// targetIPAddress returns the target of a record of hostnameTargetTypes if it
+// is an IPv4 or IPv6 address, or an empty string otherwise.
+func targetIPAddress(recordType string, content string) string {
+	if !slices.Contains(hostnameTargetTypes, recordType) {
+		return ""
+	}
+
+	fields := strings.Fields(content)
+	field := hostnameContentFields[recordType]
+	if field >= len(fields) {
+		return ""
+	}
+
+	if net.ParseIP(strings.TrimSuffix(fields[field], ".")) == nil {
+		return ""
+	}
+
+	return fields[field]
+}
+
+// idnaProfile converts internationalized host names for lookups. Unlike
+// idna.Lookup it accepts underscores, which are common in service names
+// like _dmarc or _domainkey.
+var idnaProfile = idna.New(idna.MapForLookup(), idna.BidiRule(), idna.StrictDomainName(false))
+
+// asciiHostname returns the ASCII form of a host name, with Unicode labels
+// converted to punycode, and checks the length limits. Host names that are
+// already ASCII are returned unchanged.
+func asciiHostname(hostname string) (string, error) {
+	ascii := hostname
+	if !isASCII(hostname) {
+		var err error
+		ascii, err = idnaProfile.ToASCII(hostname)
+		if err != nil {
+			return "", fmt.Errorf("invalid internationalized host name %s: %v", hostname, err)
+		}
+	}
+
+	name := strings.TrimSuffix(ascii, ".")
+	if len(name) > maxHostnameLength {
+		return "", fmt.Errorf("host name %s is %d characters long, the maximum is %d", ascii, len(name), maxHostnameLength)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > maxLabelLength {
+			return "", fmt.Errorf("label %s of host name %s is %d characters long, the maximum is %d", label, ascii, len(label), maxLabelLength)
+		}
+	}
+
+	return ascii, nil
+}
+
+// checkRecordNameLength checks the labels of a record name and its length
+// in DNS messages, where each label takes a length byte and the name ends
+// with the empty root label. Internationalized labels are measured in their
+// punycode form, as sent to the API, and reported in their configured form.
+func checkRecordNameLength(fqdn string) error {
+	name := strings.TrimSuffix(fqdn, ".")
+	if name == "" {
+		return nil
+	}
+
+	wireLength := 1
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return fmt.Errorf("name %s contains an empty label", fqdn)
+		}
+
+		ascii := label
+		if !isASCII(label) {
+			var err error
+			ascii, err = idnaProfile.ToASCII(label)
+			if err != nil {
+				return fmt.Errorf("invalid internationalized label %s: %v", label, err)
+			}
+		}
+		if len(ascii) > maxLabelLength {
+			if ascii != label {
+				return fmt.Errorf("label %s is %d bytes long in punycode (%s), the maximum is %d", label, len(ascii), ascii, maxLabelLength)
+			}
+			return fmt.Errorf("label %s is %d bytes long, the maximum is %d", label, len(ascii), maxLabelLength)
+		}
+
+		wireLength += len(ascii) + 1
+	}
+
+	if wireLength > maxWireNameLength {
+		return fmt.Errorf("name %s is %d bytes long in DNS messages, the maximum is %d", fqdn, wireLength, maxWireNameLength)
+	}
+
+	return nil
+}
+
+// normalizeHostnameContent returns the content of a record with the host
+// name it contains in ASCII form, as stored by the API. The content of
+// other record types is returned unchanged.
+func normalizeHostnameContent(recordType string, content string) (string, error) {
+	field, ok := hostnameContentFields[recordType]
+	if !ok {
+		return content, nil
+	}
+
+	// Malformed content is left to the API to reject
+	fields := strings.Fields(content)
+	if field >= len(fields) {
+		return content, nil
+	}
+
+	ascii, err := asciiHostname(fields[field])
+	if err != nil {
+		return "", err
+	}
+	if ascii == fields[field] {
+		return content, nil
+	}
+
+	fields[field] = ascii
+	return strings.Join(fields, " "), nil
+}
+
+// hexContentFields maps the record types whose content ends with hex data
+// to the position of its first field. The hex data may be split into
+// several fields.
+var hexContentFields = map[string]int{
+	"DS":    3,
+	"SSHFP": 2,
+	"TLSA":  3,
+}
+
+// normalizeContent returns the content to send to the API, in the form
+// the content read from the API is compared in. Host names are converted to
+// lower case punycode and hex digests to lower case, as both are case
+// insensitive. Other content, like the base64 keys of DKIM TXT records, is
+// case sensitive and returned unchanged. Invalid host names are rejected in
+// ValidateConfig, so the content is passed through then.
+func normalizeContent(recordType string, content string) string {
+	if field, ok := hexContentFields[recordType]; ok {
+		return lowerContentFields(content, field, -1)
+	}
+
+	if field, ok := hostnameContentFields[recordType]; ok {
+		normalized, err := normalizeHostnameContent(recordType, content)
+		if err != nil {
+			return content
+		}
+		return lowerContentFields(normalized, field, field+1)
+	}
+
+	return content
+}
+
+// lowerContentFields returns the content with the space separated fields
+// from start up to end in lower case, up to the last field if end is -1.
+// Content without upper case letters in these fields is returned unchanged.
+func lowerContentFields(content string, start int, end int) string {
+	fields := strings.Fields(content)
+	if end == -1 || end > len(fields) {
+		end = len(fields)
+	}
+	if start >= end {
+		return content
+	}
+
+	changed := false
+	for i := start; i < end; i++ {
+		if lower := strings.ToLower(fields[i]); lower != fields[i] {
+			fields[i] = lower
+			changed = true
+		}
+	}
+	if !changed {
+		return content
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// recordStateContent returns the content to store in state. Content that
+// only differs from the API's by the case or Unicode form of its host name
+// or the case of its hex digest, or TXT content that only differs by
+// quoting and splitting into strings, is kept as configured, so it doesn't
+// show up as drift.
+func recordStateContent(recordType string, configuredContent string, content string) string {
+	if configuredContent != "" && normalizeContent(recordType, configuredContent) == normalizeContent(recordType, content) {
+		return configuredContent
+	}
+	if recordType == "TXT" && configuredContent != "" && txtValue(configuredContent) == txtValue(content) {
+		return configuredContent
+	}
+
+	return content
+}
+
+// sameRecordContent reports whether the content the API returned for a
+// record is the content that was sent, by the rules of recordStateContent.
+func sameRecordContent(recordType string, content string, apiContent string) bool {
+	return recordStateContent(recordType, content, apiContent) == content
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
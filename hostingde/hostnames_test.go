@@ -0,0 +1,242 @@
+package hostingde
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNormalizeHostnameContent(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		content    string
+		want       string
+	}{
+		{recordType: "CNAME", content: "bücher.example.test", want: "xn--bcher-kva.example.test"},
+		{recordType: "CNAME", content: "Bücher.example.test.", want: "xn--bcher-kva.example.test."},
+		{recordType: "MX", content: "mail.bücher.example", want: "mail.xn--bcher-kva.example"},
+		{recordType: "SRV", content: "5 5060 sip.bücher.example", want: "5 5060 sip.xn--bcher-kva.example"},
+		// ASCII content is left alone, including its case
+		{recordType: "CNAME", content: "WWW.example.test", want: "WWW.example.test"},
+		{recordType: "CNAME", content: "s1._domainkey.example.net", want: "s1._domainkey.example.net"},
+		// Other record types have no host name in their content
+		{recordType: "TXT", content: "bücher", want: "bücher"},
+	} {
+		got, err := normalizeHostnameContent(tc.recordType, tc.content)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", tc.recordType, tc.content, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s %q: got %q, want %q", tc.recordType, tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeHostnameContentErrors(t *testing.T) {
+	longLabel := strings.Repeat("a", 64) + ".example.test"
+	longName := strings.Repeat("abcdefghi.", 26) + "test"
+
+	for _, content := range []string{longLabel, longName, "bücher" + strings.Repeat("ü", 60) + ".example.test"} {
+		if _, err := normalizeHostnameContent("CNAME", content); err == nil {
+			t.Errorf("CNAME %q: expected an error", content)
+		}
+	}
+}
+
+func TestNormalizeContent(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		content    string
+		want       string
+	}{
+		// Host names in lower case punycode
+		{recordType: "CNAME", content: "WWW.Example.test.", want: "www.example.test."},
+		{recordType: "CNAME", content: "Bücher.example.test", want: "xn--bcher-kva.example.test"},
+		{recordType: "MX", content: "Mail.example.test", want: "mail.example.test"},
+		{recordType: "SRV", content: "5 5060 SIP.example.test", want: "5 5060 sip.example.test"},
+		{recordType: "NS", content: "ns1.example.test", want: "ns1.example.test"},
+		// Hex digests in lower case, also when split into several fields
+		{recordType: "DS", content: "12345 13 2 3A1F9C0E", want: "12345 13 2 3a1f9c0e"},
+		{recordType: "SSHFP", content: "4 2 9DD3C2A1B0", want: "4 2 9dd3c2a1b0"},
+		{recordType: "TLSA", content: "3 1 1 0C72AC70 B745AC19", want: "3 1 1 0c72ac70 b745ac19"},
+		{recordType: "TLSA", content: "3 1 1 0c72ac70b745ac19", want: "3 1 1 0c72ac70b745ac19"},
+		// Base64 keys and other content keep their case
+		{recordType: "TXT", content: "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEB", want: "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEB"},
+		{recordType: "CAA", content: `0 issue "LetsEncrypt.org"`, want: `0 issue "LetsEncrypt.org"`},
+		{recordType: "OPENPGPKEY", content: "mQINBFZ", want: "mQINBFZ"},
+		// Malformed content is left to the API to reject
+		{recordType: "DS", content: "12345 13", want: "12345 13"},
+	} {
+		if got := normalizeContent(tc.recordType, tc.content); got != tc.want {
+			t.Errorf("%s %q: got %q, want %q", tc.recordType, tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestRecordStateContent(t *testing.T) {
+	// The API returns the punycode form of a Unicode target
+	if got := recordStateContent("CNAME", "bücher.example.test", "xn--bcher-kva.example.test"); got != "bücher.example.test" {
+		t.Errorf("got %q, want the configured Unicode form", got)
+	}
+	// Changed outside of Terraform
+	if got := recordStateContent("CNAME", "bücher.example.test", "other.example.test"); got != "other.example.test" {
+		t.Errorf("got %q, want the content of the API", got)
+	}
+	// Imported
+	if got := recordStateContent("CNAME", "", "xn--bcher-kva.example.test"); got != "xn--bcher-kva.example.test" {
+		t.Errorf("got %q, want the content of the API", got)
+	}
+	// The API stores host names and hex digests in lower case
+	if got := recordStateContent("CNAME", "WWW.example.test", "www.example.test"); got != "WWW.example.test" {
+		t.Errorf("got %q, want the configured upper case form", got)
+	}
+	if got := recordStateContent("DS", "12345 13 2 3A1F9C0E", "12345 13 2 3a1f9c0e"); got != "12345 13 2 3A1F9C0E" {
+		t.Errorf("got %q, want the configured upper case form", got)
+	}
+	// Base64 keys are case sensitive
+	if got := recordStateContent("TXT", "p=MIGfMA0", "p=migfma0"); got != "p=migfma0" {
+		t.Errorf("got %q, want the content of the API", got)
+	}
+}
+
+func TestRecordResourceValidateHostname(t *testing.T) {
+	for content, wantError := range map[string]bool{
+		"bücher.example.test":                     false,
+		strings.Repeat("a", 64) + ".example.test": true,
+	} {
+		diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, "www"),
+			"type":    tftypes.NewValue(tftypes.String, "CNAME"),
+			"content": tftypes.NewValue(tftypes.String, content),
+		})
+		if gotError := len(diags) > 0; gotError != wantError {
+			t.Errorf("content %q: got error %t, want %t, diagnostics: %v", content, gotError, wantError, diags)
+		}
+	}
+}
+
+func TestRecordResourceValidateTarget(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		content    string
+		priority   int64
+		wantError  bool
+	}{
+		{recordType: "MX", content: "192.0.2.1", priority: 10, wantError: true},
+		{recordType: "MX", content: "2001:db8::1", priority: 10, wantError: true},
+		{recordType: "NS", content: "192.0.2.1", wantError: true},
+		{recordType: "NS", content: "2001:db8::53", wantError: true},
+		{recordType: "CNAME", content: "192.0.2.1.", wantError: true},
+		{recordType: "CNAME", content: "::ffff:192.0.2.1", wantError: true},
+		{recordType: "SRV", content: "5 5060 192.0.2.1", priority: 10, wantError: true},
+		{recordType: "SRV", content: "5 5060 2001:db8::5060", priority: 10, wantError: true},
+		{recordType: "MX", content: "mail.example.test", priority: 10},
+		{recordType: "NS", content: "ns1.example.net"},
+		{recordType: "SRV", content: "5 5060 sip.example.test", priority: 10},
+		// Other types may contain addresses
+		{recordType: "TXT", content: "192.0.2.1"},
+	} {
+		config := map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, "sub"),
+			"type":    tftypes.NewValue(tftypes.String, tc.recordType),
+			"content": tftypes.NewValue(tftypes.String, tc.content),
+		}
+		if tc.priority != 0 {
+			config["priority"] = tftypes.NewValue(tftypes.Number, tc.priority)
+		}
+
+		diags := testValidateResourceConfig(t, NewRecordResource(), config)
+		if gotError := len(diags) > 0; gotError != tc.wantError {
+			t.Errorf("%s %q: got error %t, want %t, diagnostics: %v", tc.recordType, tc.content, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestCheckRecordNameLength(t *testing.T) {
+	label63 := strings.Repeat("a", 63)
+	// Each label takes a length byte, and the root label another byte
+	maxName := strings.Join([]string{label63, label63, label63, strings.Repeat("a", 61)}, ".")
+	longName := strings.Join([]string{label63, label63, label63, strings.Repeat("a", 62)}, ".")
+
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: "www.example.test"},
+		{name: "_acme-challenge.www.example.test."},
+		{name: "*.bücher.example.test"},
+		{name: ""},
+		{name: label63 + ".example.test"},
+		{name: maxName},
+		{name: strings.Repeat("a", 64) + ".example.test", want: "label " + strings.Repeat("a", 64) + " is 64 bytes long, the maximum is 63"},
+		// 60 characters, but 67 bytes in punycode
+		{name: strings.Repeat("ü", 5) + strings.Repeat("a", 55) + ".example.test", want: "bytes long in punycode (xn--"},
+		{name: "www..example.test", want: "empty label"},
+		{name: longName, want: "is 256 bytes long in DNS messages, the maximum is 255"},
+	} {
+		err := checkRecordNameLength(tc.name)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%q: unexpected error: %v", tc.name, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%q: got error %v, want an error containing %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestRecordResourceValidateName(t *testing.T) {
+	for name, wantError := range map[string]bool{
+		"www":                            false,
+		"@":                              false,
+		strings.Repeat("a", 64):          true,
+		"sub." + strings.Repeat("ü", 60): true,
+	} {
+		diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, name),
+			"type":    tftypes.NewValue(tftypes.String, "A"),
+			"content": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		})
+		if gotError := len(diags) > 0; gotError != wantError {
+			t.Errorf("name %q: got error %t, want %t, diagnostics: %v", name, gotError, wantError, diags)
+		}
+	}
+
+	// The length including the zone is checked once the zone name is known
+	if diags := checkRecordName(strings.Repeat(strings.Repeat("a", 63)+".", 3)+"www", strings.Repeat("b", 60)+".test"); !diags.HasError() {
+		t.Error("expected an error for a name exceeding 255 bytes with the zone")
+	}
+}
+
+func TestRecordStateContentTXT(t *testing.T) {
+	for _, tc := range []struct {
+		configured string
+		content    string
+		want       string
+	}{
+		// Quoting and splitting don't change the data of the record
+		{configured: `v=spf1 -all`, content: `"v=spf1 -all"`, want: `v=spf1 -all`},
+		{configured: `"v=spf1 -all"`, content: `v=spf1 -all`, want: `"v=spf1 -all"`},
+		{configured: `"v=DKIM1; k=rsa; " "p=MIGf"`, content: `"v=DKIM1; k=rsa; p=MIGf"`, want: `"v=DKIM1; k=rsa; " "p=MIGf"`},
+		{configured: `  "abc"   "def"  `, content: `"abcdef"`, want: `  "abc"   "def"  `},
+		{configured: `"say \"hi\""`, content: `"say \034hi\034"`, want: `"say \"hi\""`},
+		// Whitespace within a string is data
+		{configured: `"v=spf1  -all"`, content: `"v=spf1 -all"`, want: `"v=spf1 -all"`},
+		{configured: `"abc" "def"`, content: `"abc def"`, want: `"abc def"`},
+		// Changed outside of Terraform
+		{configured: `"v=spf1 -all"`, content: `"v=spf1 ~all"`, want: `"v=spf1 ~all"`},
+	} {
+		if got := recordStateContent("TXT", tc.configured, tc.content); got != tc.want {
+			t.Errorf("configured %q, API %q: got %q, want %q", tc.configured, tc.content, got, tc.want)
+		}
+	}
+
+	// Only TXT content is compared this way
+	if got := recordStateContent("CAA", `0 issue "letsencrypt.org"`, `0 issue letsencrypt.org`); got != `0 issue letsencrypt.org` {
+		t.Errorf("got %q, want the content of the API for CAA records", got)
+	}
+}
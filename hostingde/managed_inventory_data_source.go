@@ -0,0 +1,299 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &managedInventoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &managedInventoryDataSource{}
+)
+
+// NewManagedInventoryDataSource is a helper function to simplify the provider implementation.
+func NewManagedInventoryDataSource() datasource.DataSource {
+	return &managedInventoryDataSource{}
+}
+
+// managedInventoryDataSource is the data source implementation.
+type managedInventoryDataSource struct {
+	client *Client
+}
+
+// managedInventoryDataSourceModel maps the data source schema data.
+type managedInventoryDataSourceModel struct {
+	ManagedByComment types.String                  `tfsdk:"managed_by_comment"`
+	Zones            []managedInventoryZoneModel   `tfsdk:"zones"`
+	Records          []managedInventoryRecordModel `tfsdk:"records"`
+}
+
+// managedInventoryZoneModel maps a single zone of the inventory.
+type managedInventoryZoneModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	RecordCount        types.Int64  `tfsdk:"record_count"`
+	ManagedRecordCount types.Int64  `tfsdk:"managed_record_count"`
+}
+
+// managedInventoryRecordModel maps a single record of the inventory.
+type managedInventoryRecordModel struct {
+	ID       types.String `tfsdk:"id"`
+	ZoneID   types.String `tfsdk:"zone_id"`
+	ZoneName types.String `tfsdk:"zone_name"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Comments types.String `tfsdk:"comments"`
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+	Managed  types.Bool   `tfsdk:"managed"`
+}
+
+// Metadata returns the data source type name.
+func (d *managedInventoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_inventory"
+}
+
+// Schema defines the schema for the data source.
+func (d *managedInventoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all zones and records of the account, marking the records that carry the managed-by comment of the provider. " +
+			"Meant for reconciling the Terraform state with the account, for example by exporting the lists with jsonencode. " +
+			"Reads every record of the account, which takes several API requests for large accounts.",
+		Attributes: map[string]schema.Attribute{
+			"managed_by_comment": schema.StringAttribute{
+				Description: "Comment marking records as managed, matched as a substring of the record comments. " +
+					"Defaults to the managed_by_comment of the provider. If neither is set, no record is marked as managed.",
+				Optional: true,
+			},
+			"zones": schema.ListNestedAttribute{
+				Description: "Zones of the account, sorted like the API returns them.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the zone.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Domain name of the zone.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The zone type, one of NATIVE, MASTER, and SLAVE.",
+							Computed:    true,
+						},
+						"record_count": schema.Int64Attribute{
+							Description: "Number of records in the zone.",
+							Computed:    true,
+						},
+						"managed_record_count": schema.Int64Attribute{
+							Description: "Number of records in the zone marked as managed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "Records of all zones of the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the record.",
+							Computed:    true,
+						},
+						"zone_id": schema.StringAttribute{
+							Description: "Numeric identifier of the zone of the record.",
+							Computed:    true,
+						},
+						"zone_name": schema.StringAttribute{
+							Description: "Domain name of the zone of the record.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Computed:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "Content of the record, without the priority.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "TTL of the record in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the record. Zero for types without a priority.",
+							Computed:    true,
+						},
+						"comments": schema.StringAttribute{
+							Description: "Comments of the record.",
+							Computed:    true,
+						},
+						"read_only": schema.BoolAttribute{
+							Description: "Whether hosting.de manages the record for the zone itself, i.e. the SOA record and the NS records at the apex.",
+							Computed:    true,
+						},
+						"managed": schema.BoolAttribute{
+							Description: "Whether the comments of the record contain the managed-by comment.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *managedInventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state managedInventoryDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfigs, err := d.client.listAllZoneConfigs(ctx, FilterOrChain{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zones",
+			"Could not list hosting.de DNS zones: "+err.Error(),
+		)
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not list records of the hosting.de DNS zones: "+err.Error(),
+		)
+		return
+	}
+	warnUnknownRecordTypes(ctx, records)
+
+	marker := d.client.options.ManagedByComment
+	if !state.ManagedByComment.IsNull() {
+		marker = state.ManagedByComment.ValueString()
+	}
+
+	state.Zones, state.Records = managedInventory(zoneConfigs, records, marker)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// managedInventory returns the inventory of the zones and their records,
+// marking the records whose comments contain the marker. Records of zones
+// created after the zones were listed are left out.
+func managedInventory(zoneConfigs []ZoneConfig, records []DNSRecord, marker string) ([]managedInventoryZoneModel, []managedInventoryRecordModel) {
+	zoneIndex := make(map[string]int, len(zoneConfigs))
+	zones := make([]managedInventoryZoneModel, 0, len(zoneConfigs))
+	for i, zoneConfig := range zoneConfigs {
+		zoneIndex[zoneConfig.ID] = i
+		zones = append(zones, managedInventoryZoneModel{
+			ID:   types.StringValue(zoneConfig.ID),
+			Name: types.StringValue(zoneConfig.Name),
+			Type: types.StringValue(zoneConfig.Type),
+		})
+	}
+
+	recordCounts := make([]int64, len(zoneConfigs))
+	managedCounts := make([]int64, len(zoneConfigs))
+	inventory := []managedInventoryRecordModel{}
+	for _, record := range records {
+		i, ok := zoneIndex[record.ZoneID]
+		if !ok {
+			continue
+		}
+
+		managed := hasManagedComment(record, marker)
+		recordCounts[i]++
+		if managed {
+			managedCounts[i]++
+		}
+
+		content, priority := splitPriority(record)
+		inventory = append(inventory, managedInventoryRecordModel{
+			ID:       types.StringValue(record.ID),
+			ZoneID:   types.StringValue(record.ZoneID),
+			ZoneName: types.StringValue(zoneConfigs[i].Name),
+			Name:     types.StringValue(record.Name),
+			Type:     types.StringValue(record.Type),
+			Content:  types.StringValue(content),
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Priority: types.Int64Value(priority),
+			Comments: types.StringValue(record.Comments),
+			ReadOnly: types.BoolValue(isSystemRecord(record, zoneConfigs[i].Name)),
+			Managed:  types.BoolValue(managed),
+		})
+	}
+
+	for i := range zones {
+		zones[i].RecordCount = types.Int64Value(recordCounts[i])
+		zones[i].ManagedRecordCount = types.Int64Value(managedCounts[i])
+	}
+
+	return zones, inventory
+}
+
+// hasManagedComment returns whether the comments of the record contain the
+// managed-by comment as a whole, delimited by the start or end of the
+// comments or by characters other than letters, digits, "-" and "_", so
+// "team-a" doesn't match "team-ab". Without a comment no record matches.
+func hasManagedComment(record DNSRecord, comment string) bool {
+	if comment == "" {
+		return false
+	}
+
+	comments := record.Comments
+	for offset := 0; ; {
+		i := strings.Index(comments[offset:], comment)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(comment)
+		before, _ := utf8.DecodeLastRuneInString(comments[:start])
+		after, _ := utf8.DecodeRuneInString(comments[end:])
+		if !isCommentWordRune(before) && !isCommentWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+// isCommentWordRune reports whether a character continues a managed-by
+// comment, utf8.RuneError standing for the start or end of the comments.
+func isCommentWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_')
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *managedInventoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,122 @@
+package hostingde
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccManagedInventoryDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example9.test"
+  type = "NATIVE"
+  email = "hostmaster@example9.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "www"
+  type = "A"
+  content = "192.0.2.1"
+}
+data "hostingde_managed_inventory" "test" {
+  depends_on = [hostingde_record.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the zone and the record are listed.
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_managed_inventory.test", "zones.*", map[string]string{
+						"name": "example9.test",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_managed_inventory.test", "records.*", map[string]string{
+						"zone_name": "example9.test",
+						"name":      "www.example9.test",
+						"type":      "A",
+						"content":   "192.0.2.1",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestManagedInventory(t *testing.T) {
+	zoneConfigs := []ZoneConfig{
+		{ID: "1", Name: "example.test", Type: "NATIVE"},
+		{ID: "2", Name: "example2.test", Type: "NATIVE"},
+	}
+	records := []DNSRecord{
+		{ID: "a", ZoneID: "1", Name: "example.test", Type: "SOA", Content: "ns1.hosting.de. hostmaster.example.test. 1 86400 7200 3600000 3600"},
+		{ID: "b", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", Comments: "managed by terraform"},
+		{ID: "c", ZoneID: "1", Name: "example.test", Type: "MX", Content: "mail.example.test", Priority: 10, Comments: "managed by terraform, ticket 42"},
+		{ID: "d", ZoneID: "2", Name: "www.example2.test", Type: "A", Content: "192.0.2.2", Comments: "manual"},
+		// Zone created after the zones were listed
+		{ID: "e", ZoneID: "3", Name: "www.example3.test", Type: "A", Content: "192.0.2.3"},
+	}
+
+	zones, inventory := managedInventory(zoneConfigs, records, "managed by terraform")
+
+	wantCounts := map[string][2]int64{
+		"example.test":  {3, 2},
+		"example2.test": {1, 0},
+	}
+	if len(zones) != len(wantCounts) {
+		t.Fatalf("got %d zones, want %d", len(zones), len(wantCounts))
+	}
+	for _, zone := range zones {
+		want := wantCounts[zone.Name.ValueString()]
+		if got := [2]int64{zone.RecordCount.ValueInt64(), zone.ManagedRecordCount.ValueInt64()}; got != want {
+			t.Errorf("zone %s: record counts = %v, want %v", zone.Name.ValueString(), got, want)
+		}
+	}
+
+	wantManaged := map[string]bool{"a": false, "b": true, "c": true, "d": false}
+	if len(inventory) != len(wantManaged) {
+		t.Fatalf("got %d records, want %d", len(inventory), len(wantManaged))
+	}
+	for _, record := range inventory {
+		if got, want := record.Managed.ValueBool(), wantManaged[record.ID.ValueString()]; got != want {
+			t.Errorf("record %s: managed = %t, want %t", record.ID.ValueString(), got, want)
+		}
+	}
+	if !inventory[0].ReadOnly.ValueBool() {
+		t.Errorf("SOA record is not read-only")
+	}
+	if content, priority := inventory[2].Content.ValueString(), inventory[2].Priority.ValueInt64(); content != "mail.example.test" || priority != 10 {
+		t.Errorf("MX record content, priority = %q, %d, want %q, 10", content, priority, "mail.example.test")
+	}
+
+	// Without a marker nothing is managed
+	_, inventory = managedInventory(zoneConfigs, records, "")
+	for _, record := range inventory {
+		if record.Managed.ValueBool() {
+			t.Errorf("record %s is managed without a marker", record.ID.ValueString())
+		}
+	}
+}
+
+func TestHasManagedComment(t *testing.T) {
+	for _, tc := range []struct {
+		comments string
+		comment  string
+		want     bool
+	}{
+		{comments: "team-a", comment: "team-a", want: true},
+		{comments: "owner: team-a, ticket 42", comment: "team-a", want: true},
+		{comments: "managed by terraform", comment: "managed by terraform", want: true},
+		{comments: "team-ab", comment: "team-a", want: false},
+		{comments: "old-team-a", comment: "team-a", want: false},
+		{comments: "team-ab team-a", comment: "team-a", want: true},
+		{comments: "team-a", comment: "", want: false},
+		{comments: "", comment: "team-a", want: false},
+	} {
+		if got := hasManagedComment(DNSRecord{Comments: tc.comments}, tc.comment); got != tc.want {
+			t.Errorf("hasManagedComment(%q, %q) = %t, want %t", tc.comments, tc.comment, got, tc.want)
+		}
+	}
+}
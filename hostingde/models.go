@@ -58,6 +58,7 @@ type ZoneConfig struct {
 	Type                  string          `json:"type"`
 	EMailAddress          string          `json:"emailAddress,omitempty"`
 	ZoneTransferWhitelist []string        `json:"zoneTransferWhitelist,omitempty"`
+	AddDate               string          `json:"addDate,omitempty"`
 	LastChangeDate        string          `json:"lastChangeDate"`
 	DNSServerGroupID      string          `json:"dnsServerGroupId,omitempty"`
 	DNSSecMode            string          `json:"dnsSecMode,omitempty"`
@@ -65,14 +66,59 @@ type ZoneConfig struct {
 	TemplateValues        json.RawMessage `json:"templateValues,omitempty"`
 }
 
+// TemplateValues The template values object references the DNS template a zone was created from.
+// https://www.hosting.de/api/?json#the-template-values-object
+type TemplateValues struct {
+	TemplateID           string          `json:"templateId"`
+	TemplateName         string          `json:"templateName,omitempty"`
+	TieToTemplate        bool            `json:"tieToTemplate"`
+	TemplateReplacements json.RawMessage `json:"templateReplacements,omitempty"`
+}
+
+// TemplateReplacements The values replacing the placeholders in the records of a DNS template.
+// https://www.hosting.de/api/?json#the-template-replacements-object
+type TemplateReplacements struct {
+	IPv4Replacement     string `json:"ipv4Replacement,omitempty"`
+	IPv6Replacement     string `json:"ipv6Replacement,omitempty"`
+	MailIPv4Replacement string `json:"mailIpv4Replacement,omitempty"`
+	MailIPv6Replacement string `json:"mailIpv6Replacement,omitempty"`
+}
+
 // SOAValues The SOA values object contains the time (seconds) used in a zone’s SOA record.
 // https://www.hosting.de/api/?json#the-soa-values-object
 type SOAValues struct {
-	Refresh     int `json:"refresh"`
-	Retry       int `json:"retry"`
-	Expire      int `json:"expire"`
-	TTL         int `json:"ttl"`
-	NegativeTTL int `json:"negativeTtl"`
+	Refresh     int `json:"refresh,omitempty"`
+	Retry       int `json:"retry,omitempty"`
+	Expire      int `json:"expire,omitempty"`
+	TTL         int `json:"ttl,omitempty"`
+	NegativeTTL int `json:"negativeTtl,omitempty"`
+}
+
+// DNSSecOptions The DNSSEC options object configures DNSSEC signing of a zone.
+// https://www.hosting.de/api/?json#the-dnssec-options-object
+type DNSSecOptions struct {
+	Keys       []DNSSecKey `json:"keys,omitempty"`
+	Algorithms []string    `json:"algorithms,omitempty"`
+	NSECMode   string      `json:"nsecMode,omitempty"`
+	PublishKSK bool        `json:"publishKsk"`
+}
+
+// DNSSecKey A DNSSEC key of a zone.
+// https://www.hosting.de/api/?json#the-dnssec-key-object
+type DNSSecKey struct {
+	KeyData KeyData `json:"keyData"`
+	Comment string  `json:"comment,omitempty"`
+	KeyTag  int     `json:"keyTag,omitempty"`
+	Status  string  `json:"status,omitempty"`
+}
+
+// KeyData The DNSKEY data of a DNSSEC key.
+// https://www.hosting.de/api/?json#the-dnssec-key-object
+type KeyData struct {
+	Flags     int    `json:"flags"`
+	Protocol  int    `json:"protocol"`
+	Algorithm int    `json:"algorithm"`
+	PublicKey string `json:"publicKey"`
 }
 
 // DNSRecord The DNS Record object is part of a zone. It is used to manage DNS resource records.
@@ -86,6 +132,8 @@ type DNSRecord struct {
 	Content          string `json:"content,omitempty"`
 	TTL              int    `json:"ttl,omitempty"`
 	Priority         int    `json:"priority"`
+	Comments         string `json:"comments,omitempty"`
+	AddDate          string `json:"addDate,omitempty"`
 	LastChangeDate   string `json:"lastChangeDate,omitempty"`
 }
 
@@ -101,8 +149,9 @@ type Zone struct {
 type ZoneUpdateRequest struct {
 	*BaseRequest
 	ZoneConfig      `json:"zoneConfig"`
-	RecordsToAdd    []DNSRecord `json:"recordsToAdd"`
-	RecordsToDelete []DNSRecord `json:"recordsToDelete"`
+	RecordsToAdd    []DNSRecord    `json:"recordsToAdd"`
+	RecordsToDelete []DNSRecord    `json:"recordsToDelete"`
+	DNSSecOptions   *DNSSecOptions `json:"dnsSecOptions,omitempty"`
 }
 
 // ZoneUpdateResponse represents a response from the API.
@@ -117,9 +166,10 @@ type ZoneUpdateResponse struct {
 type ZoneCreateRequest struct {
 	*BaseRequest
 	ZoneConfig              `json:"zoneConfig"`
-	Records                 []DNSRecord `json:"records"`
-	NameserverSetId         string      `json:"nameserverSetId,omitempty"`
-	UseDefaultNameserverSet bool        `json:"useDefaultNameserverSet,omitempty"`
+	Records                 []DNSRecord    `json:"records"`
+	NameserverSetId         string         `json:"nameserverSetId,omitempty"`
+	UseDefaultNameserverSet bool           `json:"useDefaultNameserverSet,omitempty"`
+	DNSSecOptions           *DNSSecOptions `json:"dnsSecOptions,omitempty"`
 }
 
 // ZoneCreateResponse represents a response from the API.
@@ -143,6 +193,21 @@ type ZoneDeleteResponse struct {
 	BaseResponse
 }
 
+// DNSSecOptionsGetRequest represents a API zoneDnsSecOptionsGet request.
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+type DNSSecOptionsGetRequest struct {
+	*BaseRequest
+	ZoneConfigId string `json:"zoneConfigId,omitempty"`
+	ZoneName     string `json:"zoneName,omitempty"`
+}
+
+// DNSSecOptionsGetResponse represents a response from the API.
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+type DNSSecOptionsGetResponse struct {
+	BaseResponse
+	Response DNSSecOptions `json:"response"`
+}
+
 // ZoneConfigsFindRequest represents a API zoneConfigsFind request.
 // https://www.hosting.de/api/?json#list-zoneconfigs
 type ZoneConfigsFindRequest struct {
@@ -165,6 +230,9 @@ type ZoneConfigsFindResponse struct {
 		Type         string       `json:"type"`
 		Data         []ZoneConfig `json:"data"`
 	} `json:"response"`
+
+	// raw is the response body, for fields not modeled in ZoneConfig
+	raw []byte
 }
 
 // ZonesFindRequest represents a API zonesFind request.
@@ -215,6 +283,41 @@ type RecordsFindResponse struct {
 	} `json:"response"`
 }
 
+// RecordTemplate A record of a DNS template, with placeholders for the zone.
+// https://www.hosting.de/api/?json#the-record-template-object
+type RecordTemplate struct {
+	ID         string `json:"id,omitempty"`
+	TemplateID string `json:"templateId,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Content    string `json:"content,omitempty"`
+	TTL        int    `json:"ttl,omitempty"`
+	Priority   int    `json:"priority"`
+}
+
+// RecordTemplatesFindRequest represents a API recordTemplatesFind request.
+// https://www.hosting.de/api/?json#listing-record-templates
+type RecordTemplatesFindRequest struct {
+	*BaseRequest
+	Filter FilterOrChain `json:"filter"`
+	Limit  int           `json:"limit"`
+	Page   int           `json:"page"`
+}
+
+// RecordTemplatesFindResponse represents the API response for recordTemplatesFind.
+// https://www.hosting.de/api/?json#listing-record-templates
+type RecordTemplatesFindResponse struct {
+	BaseResponse
+	Response struct {
+		Limit        int              `json:"limit"`
+		Page         int              `json:"page"`
+		TotalEntries int              `json:"totalEntries"`
+		TotalPages   int              `json:"totalPages"`
+		Type         string           `json:"type"`
+		Data         []RecordTemplate `json:"data"`
+	} `json:"response"`
+}
+
 // RecordsUpdateRequest represents a API RecordsUpdate request.
 // https://www.hosting.de/api/?json#updating-records-in-a-zone
 type RecordsUpdateRequest struct {
@@ -233,6 +336,75 @@ type RecordsUpdateResponse struct {
 	Response Zone `json:"response"`
 }
 
+// NameserverSet The nameserver set object defines the nameservers used for zones.
+// https://www.hosting.de/api/?json#the-nameserver-set-object
+type NameserverSet struct {
+	ID                   string       `json:"id,omitempty"`
+	AccountID            string       `json:"accountId,omitempty"`
+	Name                 string       `json:"name"`
+	DefaultNameserverSet bool         `json:"defaultNameserverSet"`
+	Nameservers          []Nameserver `json:"nameservers"`
+}
+
+// Nameserver A single nameserver within a nameserver set.
+// https://www.hosting.de/api/?json#the-nameserver-set-object
+type Nameserver struct {
+	Name  string   `json:"name"`
+	IPs   []string `json:"ips,omitempty"`
+	IPv6s []string `json:"ipv6s,omitempty"`
+}
+
+// NameserverSetsFindRequest represents a API nameserverSetsFind request.
+// https://www.hosting.de/api/?json#listing-nameserver-sets
+type NameserverSetsFindRequest struct {
+	*BaseRequest
+	Filter FilterOrChain `json:"filter"`
+	Limit  int           `json:"limit"`
+	Page   int           `json:"page"`
+	Sort   *Sort         `json:"sort,omitempty"`
+}
+
+// NameserverSetsFindResponse represents the API response for nameserverSetsFind.
+// https://www.hosting.de/api/?json#listing-nameserver-sets
+type NameserverSetsFindResponse struct {
+	BaseResponse
+	Response struct {
+		Limit        int             `json:"limit"`
+		Page         int             `json:"page"`
+		TotalEntries int             `json:"totalEntries"`
+		TotalPages   int             `json:"totalPages"`
+		Type         string          `json:"type"`
+		Data         []NameserverSet `json:"data"`
+	} `json:"response"`
+}
+
+// NameserverSetRequest represents a API nameserverSetCreate or nameserverSetUpdate request.
+// https://www.hosting.de/api/?json#creating-nameserver-sets
+type NameserverSetRequest struct {
+	*BaseRequest
+	NameserverSet NameserverSet `json:"nameserverSet"`
+}
+
+// NameserverSetResponse represents the API response for nameserverSetCreate and nameserverSetUpdate.
+// https://www.hosting.de/api/?json#creating-nameserver-sets
+type NameserverSetResponse struct {
+	BaseResponse
+	Response NameserverSet `json:"response"`
+}
+
+// NameserverSetDeleteRequest represents a API nameserverSetDelete request.
+// https://www.hosting.de/api/?json#deleting-nameserver-sets
+type NameserverSetDeleteRequest struct {
+	*BaseRequest
+	NameserverSetID string `json:"nameserverSetId"`
+}
+
+// NameserverSetDeleteResponse represents the API response for nameserverSetDelete.
+// https://www.hosting.de/api/?json#deleting-nameserver-sets
+type NameserverSetDeleteResponse struct {
+	BaseResponse
+}
+
 // BaseResponse Common response struct.
 // https://www.hosting.de/api/?json#responses
 type BaseResponse struct {
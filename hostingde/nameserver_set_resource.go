@@ -0,0 +1,383 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &nameserverSetResource{}
+	_ resource.ResourceWithConfigure   = &nameserverSetResource{}
+	_ resource.ResourceWithImportState = &nameserverSetResource{}
+)
+
+// nameserverHostnamePattern matches a fully qualified host name in ASCII
+// form, with an optional trailing dot.
+var nameserverHostnamePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?\.)+[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?\.?$`)
+
+// nameserverHostnameValidator validates that a string is the fully qualified
+// host name of a nameserver. Internationalized names are checked in their
+// ASCII form.
+type nameserverHostnameValidator struct{}
+
+func (v nameserverHostnameValidator) Description(_ context.Context) string {
+	return "value must be the fully qualified host name of a nameserver, like ns1.example.com"
+}
+
+func (v nameserverHostnameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nameserverHostnameValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	hostname := req.ConfigValue.ValueString()
+	ascii, err := asciiHostname(hostname)
+	if err == nil && !nameserverHostnamePattern.MatchString(ascii) {
+		err = errors.New("expected a fully qualified host name like ns1.example.com, got: " + hostname)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid nameserver host name",
+			err.Error(),
+		)
+	}
+}
+
+// NewNameserverSetResource is a helper function to simplify the provider implementation.
+func NewNameserverSetResource() resource.Resource {
+	return &nameserverSetResource{}
+}
+
+// nameserverSetResource is the resource implementation.
+type nameserverSetResource struct {
+	client *Client
+}
+
+// nameserverSetResourceModel maps the nameserver set resource schema data.
+type nameserverSetResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Nameservers []string     `tfsdk:"nameservers"`
+}
+
+// Metadata returns the resource type name.
+func (r *nameserverSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nameserver_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *nameserverSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom nameserver set of the hosting.de account. " +
+			"Zones use the set by setting nameserver_set of hostingde_zone to its name. " +
+			"Changes to the name and the nameservers are applied in place. " +
+			"Changing the nameservers doesn't change the NS records of zones already using the set. " +
+			"Renaming a set replaces the zones referencing it by name, as their nameserver_set changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the nameserver set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the nameserver set, unique within the account.",
+				Required:    true,
+				Validators: []validator.String{
+					nameserverSetNameValidator,
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Fully qualified host names of the nameservers of the set, like ns1.example.com. " +
+					"Internationalized names are stored in their ASCII form.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(nameserverHostnameValidator{}),
+				},
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *nameserverSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create nameserver set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan nameserverSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	nameserverSet := NameserverSet{
+		Name:        plan.Name.ValueString(),
+		Nameservers: nameserverSetMembers(plan.Nameservers, nil),
+	}
+
+	// Strict warnings fail before the nameserver set changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.createNameserverSet(ctx, nameserverSet)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating nameserver set",
+			"Could not create nameserver set "+nameserverSet.Name+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(created.ID)
+	plan.Nameservers = nameserverSetStateNames(plan.Nameservers, created.Nameservers)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *nameserverSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state nameserverSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameserverSet, err := r.client.getNameserverSet(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de nameserver set",
+			"Could not read nameserver set ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(nameserverSet.Name)
+	state.Nameservers = nameserverSetStateNames(state.Nameservers, nameserverSet.Nameservers)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *nameserverSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update nameserver set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan nameserverSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	// The update replaces the whole set, so the fields not managed here,
+	// like the IP addresses of the nameservers, are taken from the current set
+	nameserverSet, err := r.client.getNameserverSet(ctx, plan.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de nameserver set",
+			"Could not read nameserver set ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	nameserverSet.Name = plan.Name.ValueString()
+	nameserverSet.Nameservers = nameserverSetMembers(plan.Nameservers, nameserverSet.Nameservers)
+
+	// Strict warnings fail before the nameserver set changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.client.updateNameserverSet(ctx, *nameserverSet)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating nameserver set",
+			"Could not update nameserver set ID "+plan.ID.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.Nameservers = nameserverSetStateNames(plan.Nameservers, updated.Nameservers)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *nameserverSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete nameserver set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state nameserverSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getNameserverSet(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Nameserver set already deleted", map[string]any{
+			"hostingde_nameserver_set_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de nameserver set",
+			"Could not read nameserver set ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Strict warnings fail before the nameserver set changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.deleteNameserverSet(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting hosting.de nameserver set",
+			"Could not delete nameserver set ID "+state.ID.ValueString()+", unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *nameserverSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ImportState imports a nameserver set by its ID.
+func (r *nameserverSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// nameserverSetMembers returns the nameservers of a set for the API, in the
+// ASCII form and without a trailing dot. Nameservers that are already members
+// of the set keep their IP addresses.
+func nameserverSetMembers(names []string, current []Nameserver) []Nameserver {
+	nameservers := make([]Nameserver, 0, len(names))
+	for _, name := range names {
+		nameserver := Nameserver{Name: nameserverAPIName(name)}
+		for _, member := range current {
+			if strings.EqualFold(member.Name, nameserver.Name) {
+				nameserver.IPs = member.IPs
+				nameserver.IPv6s = member.IPv6s
+			}
+		}
+		nameservers = append(nameservers, nameserver)
+	}
+
+	return nameservers
+}
+
+// nameserverSetStateNames returns the nameservers of a set to store in state.
+// Configured names that only differ from the API's by their form, like a
+// trailing dot or Unicode labels, are kept as configured, so they don't show
+// up as drift.
+func nameserverSetStateNames(configured []string, nameservers []Nameserver) []string {
+	names := make([]string, 0, len(nameservers))
+	for i, nameserver := range nameservers {
+		if i < len(configured) && strings.EqualFold(nameserverAPIName(configured[i]), nameserver.Name) {
+			names = append(names, configured[i])
+			continue
+		}
+		names = append(names, nameserver.Name)
+	}
+
+	return names
+}
+
+// nameserverAPIName returns the host name of a nameserver as stored by the
+// API. Invalid host names are rejected when validating the configuration, so
+// they are passed through here.
+func nameserverAPIName(name string) string {
+	if ascii, err := asciiHostname(name); err == nil {
+		name = ascii
+	}
+
+	return strings.TrimSuffix(name, ".")
+}
@@ -0,0 +1,104 @@
+package hostingde
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNameserverSetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_nameserver_set" "test" {
+  name        = "terraform test"
+  nameservers = ["ns1.example.net", "ns2.example.net"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "name", "terraform test"),
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "nameservers.#", "2"),
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "nameservers.0", "ns1.example.net"),
+					resource.TestCheckResourceAttrSet("hostingde_nameserver_set.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "hostingde_nameserver_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Membership change is applied in place
+			{
+				Config: providerConfig + `
+resource "hostingde_nameserver_set" "test" {
+  name        = "terraform test"
+  nameservers = ["ns1.example.net", "ns3.example.org", "ns4.example.org"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "nameservers.#", "3"),
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "nameservers.1", "ns3.example.org"),
+					resource.TestCheckResourceAttr("hostingde_nameserver_set.test", "nameservers.2", "ns4.example.org"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestNameserverHostnameValidator(t *testing.T) {
+	for hostname, wantError := range map[string]bool{
+		"ns1.example.net":  false,
+		"ns1.example.net.": false,
+		"ns1.bücher.de":    false,
+		"ns1":              true,
+		"_ns.example.net":  true,
+		"ns1..example.net": true,
+		"-ns.example.net":  true,
+		"192.0.2.1 x":      true,
+	} {
+		resp := validator.StringResponse{}
+		nameserverHostnameValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("nameservers"),
+			ConfigValue: types.StringValue(hostname),
+		}, &resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("%q: got error %t, want %t: %v", hostname, resp.Diagnostics.HasError(), wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestNameserverSetMembers(t *testing.T) {
+	current := []Nameserver{
+		{Name: "ns1.example.net", IPs: []string{"192.0.2.1"}},
+		{Name: "ns2.example.net", IPs: []string{"192.0.2.2"}},
+	}
+
+	got := nameserverSetMembers([]string{"NS1.example.net.", "ns3.bücher.de"}, current)
+	want := []Nameserver{
+		{Name: "NS1.example.net", IPs: []string{"192.0.2.1"}},
+		{Name: "ns3.xn--bcher-kva.de"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nameserverSetMembers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNameserverSetStateNames(t *testing.T) {
+	nameservers := []Nameserver{{Name: "ns1.example.net"}, {Name: "ns3.xn--bcher-kva.de"}, {Name: "ns4.example.org"}}
+
+	got := nameserverSetStateNames([]string{"ns1.example.net.", "ns3.bücher.de", "ns5.example.org"}, nameservers)
+	want := []string{"ns1.example.net.", "ns3.bücher.de", "ns4.example.org"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("nameserverSetStateNames() = %v, want %v", got, want)
+	}
+}
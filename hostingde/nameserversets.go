@@ -0,0 +1,118 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// https://www.hosting.de/api/?json#listing-nameserver-sets
+func (c *Client) listNameserverSets(ctx context.Context, findRequest NameserverSetsFindRequest) (*NameserverSetsFindResponse, error) {
+	uri := c.baseURL + "/nameserverSetsFind"
+
+	findRequest.Limit = clampLimit(findRequest.Limit)
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeEnvelope[NameserverSetsFindResponse](ctx, uri, rawResp)
+}
+
+// getNameserverSetByName returns the nameserver set with the given name.
+func (c *Client) getNameserverSetByName(ctx context.Context, name string) (*NameserverSet, error) {
+	findRequest := NameserverSetsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "NameserverSetName",
+			Value: name,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	findResponse, err := c.listNameserverSets(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("nameserver set %q not found", name)
+	}
+
+	return &findResponse.Response.Data[0], nil
+}
+
+// getNameserverSet returns the nameserver set with the given ID.
+func (c *Client) getNameserverSet(ctx context.Context, nameserverSetId string) (*NameserverSet, error) {
+	findRequest := NameserverSetsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "NameserverSetId",
+			Value: nameserverSetId,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	findResponse, err := c.listNameserverSets(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("nameserver set %s %w", nameserverSetId, errNotFound)
+	}
+
+	return &findResponse.Response.Data[0], nil
+}
+
+// https://www.hosting.de/api/?json#creating-nameserver-sets
+func (c *Client) createNameserverSet(ctx context.Context, nameserverSet NameserverSet) (*NameserverSet, error) {
+	return c.writeNameserverSet(ctx, c.baseURL+"/nameserverSetCreate", nameserverSet)
+}
+
+// https://www.hosting.de/api/?json#updating-nameserver-sets
+func (c *Client) updateNameserverSet(ctx context.Context, nameserverSet NameserverSet) (*NameserverSet, error) {
+	return c.writeNameserverSet(ctx, c.baseURL+"/nameserverSetUpdate", nameserverSet)
+}
+
+// writeNameserverSet sends a nameserver set to the API and returns the set
+// as stored by hosting.de.
+func (c *Client) writeNameserverSet(ctx context.Context, uri string, nameserverSet NameserverSet) (*NameserverSet, error) {
+	request := NameserverSetRequest{
+		BaseRequest:   &BaseRequest{},
+		NameserverSet: nameserverSet,
+	}
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := decodeEnvelope[NameserverSetResponse](ctx, uri, rawResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Response, nil
+}
+
+// https://www.hosting.de/api/?json#deleting-nameserver-sets
+func (c *Client) deleteNameserverSet(ctx context.Context, nameserverSetId string) error {
+	uri := c.baseURL + "/nameserverSetDelete"
+
+	request := NameserverSetDeleteRequest{
+		BaseRequest:     &BaseRequest{},
+		NameserverSetID: nameserverSetId,
+	}
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, request)
+	if err != nil {
+		return err
+	}
+
+	_, err = decodeEnvelope[NameserverSetDeleteResponse](ctx, uri, rawResp)
+	return err
+}
@@ -0,0 +1,226 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miekg/dns"
+)
+
+// defaultPropagationTimeout is the timeout of a single DNS query when
+// checking the propagation of records.
+const defaultPropagationTimeout = 5 * time.Second
+
+// defaultPropagationWait is how long to wait for a record to propagate if
+// the resource doesn't configure a timeout.
+const defaultPropagationWait = 5 * time.Minute
+
+// propagationPollInterval is the delay between checks of the nameservers
+// while waiting for a record to propagate.
+const propagationPollInterval = 5 * time.Second
+
+// nameserverResult is the outcome of querying a single nameserver for the
+// records of a name.
+type nameserverResult struct {
+	Nameserver string
+	Values     []string
+	Propagated bool
+	Err        error
+}
+
+// ednsBufferSize is the UDP payload size advertised with EDNS0, so larger
+// record sets like DKIM keys fit into a single UDP answer.
+const ednsBufferSize = 1232
+
+// queryNameserver asks a nameserver for the records of the given name and
+// type, without recursion, and returns their sorted content in the
+// representation of the API, without the priority. Truncated answers are
+// repeated over TCP.
+func queryNameserver(ctx context.Context, nameserver string, name string, recordType string, timeout time.Duration) ([]string, error) {
+	rrType, ok := dns.StringToType[recordType]
+	if !ok {
+		return nil, fmt.Errorf("record type %s can not be queried", recordType)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), rrType)
+	msg.RecursionDesired = false
+	msg.SetEdns0(ednsBufferSize, false)
+
+	client := &dns.Client{Timeout: timeout}
+	answer, _, err := client.ExchangeContext(ctx, msg, nameserverAddress(nameserver))
+	if err == nil && answer.Truncated {
+		client.Net = "tcp"
+		answer, _, err = client.ExchangeContext(ctx, msg, nameserverAddress(nameserver))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if answer.Rcode != dns.RcodeSuccess && answer.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("nameserver answered with %s", dns.RcodeToString[answer.Rcode])
+	}
+
+	values := []string{}
+	for _, rr := range answer.Answer {
+		if rr.Header().Rrtype != rrType {
+			continue
+		}
+		record := recordFromRR(rr)
+		record.Content, _ = splitPriority(record)
+		values = append(values, nameserverValue(record.Type, record.Content))
+	}
+	slices.Sort(values)
+
+	return values, nil
+}
+
+// checkPropagation queries every nameserver for the records of the given name
+// and type and reports whether all of them serve exactly the expected values,
+// which don't contain the priority of the records.
+func checkPropagation(ctx context.Context, nameservers []string, name string, recordType string, expected []string, timeout time.Duration) (bool, []nameserverResult) {
+	expected = slices.Clone(expected)
+	for i, value := range expected {
+		expected[i] = nameserverValue(recordType, value)
+	}
+	slices.Sort(expected)
+
+	propagated := len(nameservers) > 0
+	results := []nameserverResult{}
+	for _, nameserver := range nameservers {
+		values, err := queryNameserver(ctx, nameserver, name, recordType, timeout)
+		result := nameserverResult{
+			Nameserver: nameserver,
+			Values:     values,
+			Propagated: err == nil && slices.Equal(values, expected),
+			Err:        err,
+		}
+		propagated = propagated && result.Propagated
+		results = append(results, result)
+	}
+
+	return propagated, results
+}
+
+// waitForRecordPropagation polls the nameservers until all of them serve the
+// given content for the name and type, or the timeout expires. Other records
+// of the same name and type may be served as well. Without nameservers the
+// propagation can't be checked, which is an error.
+func waitForRecordPropagation(ctx context.Context, nameservers []string, name string, recordType string, content string, timeout time.Duration) error {
+	if len(nameservers) == 0 {
+		return fmt.Errorf("there are no nameservers to check")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	want := nameserverValue(recordType, content)
+	for {
+		var pending []string
+		for _, nameserver := range nameservers {
+			values, err := queryNameserver(ctx, nameserver, name, recordType, defaultPropagationTimeout)
+			if err != nil || !slices.Contains(values, want) {
+				pending = append(pending, nameserver)
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("record is still not served by %s after %s", strings.Join(pending, ", "), timeout)
+		case <-time.After(propagationPollInterval):
+		}
+	}
+}
+
+// nameserverValue normalizes the content of a record for comparing the
+// answer of a nameserver with the content configured in hosting.de: the data
+// of TXT records, and other content without the trailing dot of host names.
+func nameserverValue(recordType string, content string) string {
+	if recordType == "TXT" {
+		return txtValue(content)
+	}
+
+	return strings.TrimSuffix(content, ".")
+}
+
+// nameserverAddress returns the address of a nameserver given as host name
+// or IP address, optionally with a port. The port defaults to 53.
+func nameserverAddress(nameserver string) string {
+	if _, _, err := net.SplitHostPort(nameserver); err == nil {
+		return nameserver
+	}
+
+	return net.JoinHostPort(strings.TrimSuffix(nameserver, "."), "53")
+}
+
+// waitForZoneRecordPropagation waits until all nameservers of the zone serve
+// the record. A timeout is reported on the propagation_timeout attribute, so
+// the record, which exists at this point, is tainted.
+func waitForZoneRecordPropagation(ctx context.Context, client *Client, zoneConfigId string, name string, recordType string, content string, propagationTimeout types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	timeout := defaultPropagationWait
+	if !propagationTimeout.IsNull() {
+		// Validated in ValidateConfig
+		timeout, _ = time.ParseDuration(propagationTimeout.ValueString())
+	}
+
+	zoneConfig, err := client.getZoneConfig(ctx, zoneConfigId)
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone ID "+zoneConfigId+": "+err.Error(),
+		)
+		return diags
+	}
+
+	nameservers, err := client.listZoneNameservers(ctx, zoneConfig.ID, zoneConfig.Name)
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read nameservers of hosting.de DNS zone ID "+zoneConfig.ID+": "+err.Error(),
+		)
+		return diags
+	}
+
+	err = waitForRecordPropagation(ctx, nameservers, name, recordType, content, timeout)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("propagation_timeout"),
+			"Timeout waiting for record propagation",
+			"The record "+name+" was saved in hosting.de, but did not propagate to the nameservers of the zone: "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// validatePropagationTimeout checks that the propagation timeout is a
+// positive duration.
+func validatePropagationTimeout(propagationTimeout types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if propagationTimeout.IsNull() || propagationTimeout.IsUnknown() {
+		return diags
+	}
+
+	if timeout, err := time.ParseDuration(propagationTimeout.ValueString()); err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("propagation_timeout"),
+			"Invalid propagation timeout",
+			"The propagation_timeout value must be a positive duration like \"30s\" or \"2m\", got: "+propagationTimeout.ValueString(),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,158 @@
+package hostingde
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// newTestNameserver starts a DNS server answering queries with the given
+// records and returns its address.
+func newTestNameserver(t *testing.T, records ...string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+		answer := new(dns.Msg)
+		answer.SetReply(r)
+		for _, record := range records {
+			rr, err := dns.NewRR(record)
+			if err != nil {
+				t.Errorf("invalid test record %q: %v", record, err)
+				continue
+			}
+			if rr.Header().Name == r.Question[0].Name && rr.Header().Rrtype == r.Question[0].Qtype {
+				answer.Answer = append(answer.Answer, rr)
+			}
+		}
+		_ = w.WriteMsg(answer)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheckPropagation(t *testing.T) {
+	current := newTestNameserver(t,
+		"www.example.test. 60 IN A 192.0.2.2",
+		"www.example.test. 60 IN A 192.0.2.1",
+		"example.test. 60 IN MX 10 mail.example.test.",
+		`example.test. 60 IN TXT "v=spf1 " "-all"`,
+	)
+	stale := newTestNameserver(t, "www.example.test. 60 IN A 192.0.2.1")
+
+	for name, tc := range map[string]struct {
+		nameservers []string
+		recordName  string
+		recordType  string
+		expected    []string
+		want        bool
+	}{
+		"all values":        {nameservers: []string{current}, recordName: "www.example.test", recordType: "A", expected: []string{"192.0.2.1", "192.0.2.2"}, want: true},
+		"stale nameserver":  {nameservers: []string{current, stale}, recordName: "www.example.test", recordType: "A", expected: []string{"192.0.2.1", "192.0.2.2"}, want: false},
+		"priority stripped": {nameservers: []string{current}, recordName: "example.test", recordType: "MX", expected: []string{"mail.example.test"}, want: true},
+		"split TXT":         {nameservers: []string{current}, recordName: "example.test", recordType: "TXT", expected: []string{"v=spf1 -all"}, want: true},
+		"missing record":    {nameservers: []string{current}, recordName: "new.example.test", recordType: "A", expected: []string{"192.0.2.3"}, want: false},
+		"no nameservers":    {recordName: "www.example.test", recordType: "A", expected: []string{"192.0.2.1"}, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			propagated, results := checkPropagation(context.Background(), tc.nameservers, tc.recordName, tc.recordType, tc.expected, time.Second)
+			if propagated != tc.want {
+				t.Errorf("got propagated %t, want %t, results: %+v", propagated, tc.want, results)
+			}
+			if len(results) != len(tc.nameservers) {
+				t.Errorf("got %d results, want one per nameserver", len(results))
+			}
+		})
+	}
+}
+
+func TestNameserverAddress(t *testing.T) {
+	for nameserver, want := range map[string]string{
+		"ns1.example.net":    "ns1.example.net:53",
+		"ns1.example.net.":   "ns1.example.net:53",
+		"192.0.2.53":         "192.0.2.53:53",
+		"192.0.2.53:5353":    "192.0.2.53:5353",
+		"2001:db8::53":       "[2001:db8::53]:53",
+		"[2001:db8::53]:853": "[2001:db8::53]:853",
+	} {
+		if got := nameserverAddress(nameserver); got != want {
+			t.Errorf("nameserverAddress(%q) = %q, want %q", nameserver, got, want)
+		}
+	}
+}
+
+func TestWaitForRecordPropagation(t *testing.T) {
+	nameserver := newTestNameserver(t,
+		"_acme-challenge.example.test. 60 IN TXT \"old\"",
+		"_acme-challenge.example.test. 60 IN TXT \"token\"",
+	)
+
+	// Other records of the same name don't matter
+	err := waitForRecordPropagation(context.Background(), []string{nameserver}, "_acme-challenge.example.test", "TXT", `"token"`, time.Second)
+	if err != nil {
+		t.Errorf("waitForRecordPropagation returned an error for a served record: %v", err)
+	}
+
+	err = waitForRecordPropagation(context.Background(), []string{nameserver}, "_acme-challenge.example.test", "TXT", `"missing"`, 100*time.Millisecond)
+	if err == nil {
+		t.Errorf("waitForRecordPropagation returned no error for a record that is not served")
+	}
+
+	err = waitForRecordPropagation(context.Background(), nil, "_acme-challenge.example.test", "TXT", `"token"`, time.Second)
+	if err == nil {
+		t.Errorf("waitForRecordPropagation returned no error without nameservers")
+	}
+}
+
+func TestQueryNameserverTruncated(t *testing.T) {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	// Answers over UDP are truncated, only TCP returns the record
+	handler := func(truncated bool) dns.Handler {
+		return dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			if r.IsEdns0() == nil {
+				t.Errorf("query has no EDNS0 option")
+			}
+			answer := new(dns.Msg)
+			answer.SetReply(r)
+			answer.Truncated = truncated
+			if !truncated {
+				rr, _ := dns.NewRR(`default._domainkey.example.test. 60 IN TXT "v=DKIM1; k=rsa; p=key"`)
+				answer.Answer = append(answer.Answer, rr)
+			}
+			_ = w.WriteMsg(answer)
+		})
+	}
+	for _, server := range []*dns.Server{
+		{PacketConn: udp, Handler: handler(true)},
+		{Listener: tcp, Handler: handler(false)},
+	} {
+		server := server
+		go func() { _ = server.ActivateAndServe() }()
+		t.Cleanup(func() { _ = server.Shutdown() })
+	}
+
+	values, err := queryNameserver(context.Background(), udp.LocalAddr().String(), "default._domainkey.example.test", "TXT", time.Second)
+	if err != nil {
+		t.Fatalf("queryNameserver returned an error: %v", err)
+	}
+	if len(values) != 1 || values[0] != "v=DKIM1; k=rsa; p=key" {
+		t.Errorf("got values %q, want the record from the TCP answer", values)
+	}
+}
@@ -2,18 +2,54 @@ package hostingde
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpguts"
 )
 
-const defaultBaseURL = "https://secure.hosting.de/api/dns/v1/json"
+// The base URL of the API is made of the host, the API version and the
+// response format.
+const (
+	defaultAPIHost    = "https://secure.hosting.de"
+	defaultAPIVersion = "v1"
+)
+
+// apiVersionValidator validates the version in the API path, like v1.
+var apiVersionValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^v[1-9][0-9]*$`),
+	"must be a version like v1 or v2",
+)
+
+// defaultAPILanguage is the language requested for the messages of the API,
+// so errors don't depend on the locale of the account.
+const defaultAPILanguage = "en"
+
+// apiLanguageValidator validates a language tag like en or de-DE, see
+// RFC 5646. Private use and grandfathered tags are not accepted.
+var apiLanguageValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`),
+	"must be a language tag like en or de-DE",
+)
 
 // Ensure the implementation satisfies the expected interfaces
 var (
@@ -22,9 +58,47 @@ var (
 
 // hostingdeProviderModel maps provider schema data to a Go type.
 type hostingdeProviderModel struct {
-	AccountId types.String `tfsdk:"account_id"`
-	AuthToken types.String `tfsdk:"auth_token"`
-	BaseUrl   types.String `tfsdk:"base_url"`
+	AccountId             types.String               `tfsdk:"account_id"`
+	AuthToken             types.String               `tfsdk:"auth_token"`
+	AuthTokenCommand      types.List                 `tfsdk:"auth_token_command"`
+	AuthTokenFile         types.String               `tfsdk:"auth_token_file"`
+	BaseUrl               types.String               `tfsdk:"base_url"`
+	FallbackBaseUrl       types.String               `tfsdk:"fallback_base_url"`
+	APIVersion            types.String               `tfsdk:"api_version"`
+	APILanguage           types.String               `tfsdk:"api_language"`
+	RecordMatchStrategy   types.String               `tfsdk:"record_match_strategy"`
+	ZoneDefaults          *providerZoneDefaultsModel `tfsdk:"zone_defaults"`
+	MaxIdleConns          types.Int64                `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost       types.Int64                `tfsdk:"max_conns_per_host"`
+	DefaultNameserverSet  types.String               `tfsdk:"default_nameserver_set"`
+	OperationJitter       types.String               `tfsdk:"operation_jitter"`
+	ReadOnly              types.Bool                 `tfsdk:"read_only"`
+	MaxRedirects          types.Int64                `tfsdk:"max_redirects"`
+	NewRecordDefaultTTL   types.Int64                `tfsdk:"new_record_default_ttl"`
+	RequireExplicitTTL    types.Bool                 `tfsdk:"require_explicit_ttl"`
+	StrictWarnings        types.Bool                 `tfsdk:"strict_warnings"`
+	ExtraHeaders          types.Map                  `tfsdk:"extra_headers"`
+	ManagedByComment      types.String               `tfsdk:"managed_by_comment"`
+	MaxConcurrentRequests types.Int64                `tfsdk:"max_concurrent_requests"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+	ReadRetries             types.Int64  `tfsdk:"read_retries"`
+
+	RateLimitWarningThreshold types.Int64 `tfsdk:"rate_limit_warning_threshold"`
+}
+
+// providerZoneDefaultsModel maps the zone_defaults attribute of the provider.
+type providerZoneDefaultsModel struct {
+	Type            types.String `tfsdk:"type"`
+	NameserverSet   types.String `tfsdk:"nameserver_set"`
+	SOARefresh      types.Int64  `tfsdk:"soa_refresh"`
+	SOARetry        types.Int64  `tfsdk:"soa_retry"`
+	SOAExpire       types.Int64  `tfsdk:"soa_expire"`
+	SOANegativeTTL  types.Int64  `tfsdk:"soa_negative_ttl"`
+	DefaultTTL      types.Int64  `tfsdk:"default_ttl"`
+	DNSSECAlgorithm types.String `tfsdk:"dnssec_algorithm"`
+	DNSSECNSECMode  types.String `tfsdk:"dnssec_nsec_mode"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -52,10 +126,254 @@ func (p *hostingdeProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 				Description: "Auth token for hosting.de API. May also be provided via HOSTINGDE_AUTH_TOKEN environment variable.",
 				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("auth_token_command"), path.MatchRoot("auth_token_file")),
+				},
+			},
+			"auth_token_command": schema.ListAttribute{
+				Description: "Command printing the auth token for hosting.de API, as the program followed by its arguments, " +
+					"for example to fetch a short-lived token from a secrets manager. It is run when the provider is configured, " +
+					"and again if the API rejects the token with HTTP 401, after which the rejected request is retried once. " +
+					"The command is run without a shell, with the environment and permissions of Terraform, and must print the token " +
+					"to stdout within 30s. Overrides HOSTINGDE_AUTH_TOKEN.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("auth_token_file")),
+				},
+			},
+			"auth_token_file": schema.StringAttribute{
+				Description: "Path of a file containing the auth token for hosting.de API, for example written by an agent that rotates the token. " +
+					"It is read when the provider is configured, and again if the API rejects the token with HTTP 401, " +
+					"after which the rejected request is retried once. Surrounding whitespace is removed. Overrides HOSTINGDE_AUTH_TOKEN.",
+				Optional: true,
 			},
 			"base_url": schema.StringAttribute{
-				Description: "Base URL for hosting.de API. May also be provided via HOSTINGDE_BASE_URL environment variable.",
+				Description: "Base URL for hosting.de API. May also be provided via HOSTINGDE_BASE_URL environment variable. " +
+					"Overrides api_version, the URL has to include the version.",
+				Optional: true,
+			},
+			"fallback_base_url": schema.StringAttribute{
+				Description: "Base URL of a secondary hosting.de API endpoint, tried if base_url is unreachable. " +
+					"Only connection failures are retried against it, HTTP errors like 4xx are returned as they are. " +
+					"Writes are only sent to it if no connection to base_url could be made, not after a timeout or a dropped connection, as base_url may have applied them. " +
+					"May also be provided via HOSTINGDE_FALLBACK_BASE_URL environment variable. Disabled by default.",
+				Optional: true,
+			},
+			"api_version": schema.StringAttribute{
+				Description: "Version of the hosting.de DNS API, like v1. Used to build the default base URL " +
+					"https://secure.hosting.de/api/dns/<version>/json, ignored if base_url is set. Defaults to v1.",
+				Optional: true,
+				Validators: []validator.String{
+					apiVersionValidator,
+				},
+			},
+			"api_language": schema.StringAttribute{
+				Description: "Language tag sent in the Accept-Language header of every API request, like en or de, " +
+					"so error messages of the API are in the same language regardless of the locale of the account. " +
+					"An Accept-Language header in extra_headers takes precedence. Defaults to en.",
+				Optional: true,
+				Validators: []validator.String{
+					apiLanguageValidator,
+				},
+			},
+			"record_match_strategy": schema.StringAttribute{
+				Description: "How hostingde_record finds its record when refreshing the state. \"id\" looks up the record by the ID in the state. " +
+					"\"name_type_content\" looks up the record by its name, type and content, and replaces the ID in the state with the ID of the record found. " +
+					"It is meant for migrating state whose record IDs are outdated, see Migrating record state in the provider documentation. Imported records are always looked up by ID. Defaults to \"id\".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(recordMatchID, recordMatchNameTypeContent),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "Maximum number of idle (keep-alive) connections to the hosting.de API, at least 1. Defaults to 10.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_conns_per_host": schema.Int64Attribute{
+				Description: "Maximum number of connections to the hosting.de API host, including connections in use. Use this to stay within the API rate limits during large parallel applies. The limit applies per provider configuration. Defaults to 0, which means no limit.",
 				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at the same time, regardless of Terraform's -parallelism. " +
+					"Further requests wait for a free slot. Unlike max_conns_per_host this also bounds requests over HTTP/2, " +
+					"which share a single connection. The limit applies per provider configuration. Defaults to 0, which means no limit.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Description: "Number of consecutive failed API requests, like connection errors or HTTP 5xx responses, " +
+					"after which further requests fail immediately for circuit_breaker_cooldown instead of being sent. " +
+					"Shortens a futile apply during an API outage. The first successful request resets the count. " +
+					"Defaults to 0, which disables the circuit breaker.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				Description: "How long requests fail immediately once circuit_breaker_threshold is reached, as a duration like \"1m\". " +
+					"Afterwards requests are sent again, and the next failure restarts the cooldown. Defaults to 30s.",
+				Optional: true,
+			},
+			"read_retries": schema.Int64Attribute{
+				Description: "Number of times a request that only reads data, like listing zones or records, is retried after " +
+					"a connection error, an HTTP 5xx or an HTTP 429 response, waiting 1s before the first retry and doubling the wait up to 30s. " +
+					"Requests creating, updating or deleting zones and records are not retried after these failures, as a request failing after it reached " +
+					"the API may have been applied, and sending it again could create duplicate records. Defaults to 0, at most 10.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10),
+				},
+			},
+			"rate_limit_warning_threshold": schema.Int64Attribute{
+				Description: "Number of remaining API requests below which a warning is reported, if the API reports its rate limit " +
+					"in X-RateLimit-* response headers. The headers are also logged at debug level with every request. " +
+					"Defaults to 10, 0 disables the warning.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"default_nameserver_set": schema.StringAttribute{
+				Description: "Name of the nameserver set used for new zones that don't configure nameserver_set. Defaults to the account's default nameserver set.",
+				Optional:    true,
+				Validators: []validator.String{
+					nameserverSetNameValidator,
+				},
+			},
+			"zone_defaults": schema.SingleNestedAttribute{
+				Description: "Defaults for new hostingde_zone resources. Attributes configured on a zone take precedence over these defaults. " +
+					"The defaults only apply when a zone is created, or when DNSSEC is enabled for the dnssec defaults, " +
+					"so changing them causes no drift for existing zones.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "Type of zones that don't configure type, one of NATIVE, MASTER, and SLAVE. Without it, the type defaults to NATIVE.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(zoneTypes...),
+						},
+					},
+					"nameserver_set": schema.StringAttribute{
+						Description: "Name of the nameserver set of zones that don't configure nameserver_set. Takes precedence over default_nameserver_set.",
+						Optional:    true,
+						Validators: []validator.String{
+							nameserverSetNameValidator,
+						},
+					},
+					"soa_refresh": schema.Int64Attribute{
+						Description: "Refresh time in seconds of the SOA record of new zones. Defaults to the value of hosting.de.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.Between(60, 31556926)},
+					},
+					"soa_retry": schema.Int64Attribute{
+						Description: "Retry time in seconds of the SOA record of new zones. Defaults to the value of hosting.de.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.Between(60, 31556926)},
+					},
+					"soa_expire": schema.Int64Attribute{
+						Description: "Expire time in seconds of the SOA record of new zones. Defaults to the value of hosting.de.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.Between(60, 31556926)},
+					},
+					"soa_negative_ttl": schema.Int64Attribute{
+						Description: "TTL in seconds of negative answers of new zones, the minimum field of the SOA record. Defaults to the value of hosting.de.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.Between(60, 31556926)},
+					},
+					"default_ttl": schema.Int64Attribute{
+						Description: "Default TTL in seconds of new zones, used by records with a ttl of 0. Defaults to the value of hosting.de.",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.Between(60, 31556926)},
+					},
+					"dnssec_algorithm": schema.StringAttribute{
+						Description: "Signing algorithm of zones enabling DNSSEC without configuring dnssec.algorithm. " +
+							"Valid algorithms are " + strings.Join(dnssecAlgorithms, ", ") + ".",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(dnssecAlgorithms...),
+						},
+					},
+					"dnssec_nsec_mode": schema.StringAttribute{
+						Description: "Authenticated denial of existence mode of zones enabling DNSSEC without configuring dnssec.nsec_mode, either nsec or nsec3.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("nsec", "nsec3"),
+						},
+					},
+				},
+			},
+			"operation_jitter": schema.StringAttribute{
+				Description: "Maximum random delay before creating or updating a zone or record, as a duration like \"2s\". " +
+					"Spreads out the burst of requests when Terraform creates many resources in parallel, " +
+					"which together with max_conns_per_host helps to avoid API throttling, at the cost of a slower apply. " +
+					"Disabled by default.",
+				Optional: true,
+			},
+			"max_redirects": schema.Int64Attribute{
+				Description: "Maximum number of redirects followed per API request, 0 disables redirects. " +
+					"Only redirects to the host of base_url are followed, as the request body contains the auth token. " +
+					"Only 307 and 308 redirects are followed, other redirects would send the request without its body. Defaults to 3.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"new_record_default_ttl": schema.Int64Attribute{
+				Description: "TTL in seconds planned for new hostingde_record resources that don't configure ttl, instead of 3600. " +
+					"The default only seeds records on creation: existing records keep their TTL when this setting changes, " +
+					"so changing it causes no drift. Unlike ttl = 0, which makes a record follow the default TTL of its zone, " +
+					"the record keeps a fixed TTL once created. Set to 0 to create records inheriting the zone default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Any(
+						int64validator.OneOf(0),
+						int64validator.Between(60, 31556926),
+					),
+				},
+			},
+			"require_explicit_ttl": schema.BoolAttribute{
+				Description: "If true, planning a hostingde_record that sets neither ttl nor ttl_duration fails, instead of using new_record_default_ttl or 3600. " +
+					"Enforces a policy that every record declares its TTL, ttl = 0 still declares that the record follows the default TTL of its zone. " +
+					"ALIAS records are exempt, as hosting.de controls their TTL. Defaults to false.",
+				Optional: true,
+			},
+			"strict_warnings": schema.BoolAttribute{
+				Description: "If true, warnings of the provider fail the plan or apply as errors, for pipelines that require a clean run. " +
+					"Affects the warnings about an ignored api_version, a hostingde_record or hostingde_record_set name including the zone name, a missing glue record, " +
+					"zones hostingde_zones_records couldn't read, an almost exhausted API rate limit and warnings of the hosting.de API, as long as no change was made yet. " +
+					"Warnings about a change already made, like an unreadable zone serial, records raised by enforce_min_ttl or warnings of the API about the change itself, " +
+					"stay warnings, so the changed resource isn't tainted. Notes explaining a plan, like a record replaced due to a changed type, also stay warnings. Defaults to false.",
+				Optional: true,
+			},
+			"extra_headers": schema.MapAttribute{
+				Description: "HTTP headers added to every API request, for example when the API is reached through a gateway that requires its own authentication header. " +
+					"The auth token is always sent in the request body. An Accept-Language header overrides api_language. " +
+					"The headers Connection, Content-Length, Content-Type, Host and Transfer-Encoding are controlled by the provider and can not be set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"managed_by_comment": schema.StringAttribute{
+				Description: "Comment stored with every record the provider creates, for example \"managed by terraform\", " +
+					"to tell provider-managed records apart in the hosting.de UI. Records created before it was set, " +
+					"or changed afterwards, keep their comments, so setting or changing it causes no drift. Disabled by default.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "If true, creating, updating or deleting resources fails without calling the API. " +
+					"Data sources still work. Useful as a safety belt for plan-only pipelines. Defaults to false.",
+				Optional: true,
 			},
 		},
 	}
@@ -91,6 +409,14 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.AuthTokenCommand.IsUnknown() || config.AuthTokenFile.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown hosting.de API auth token source",
+			"The provider cannot create the hosting.de API client as there is an unknown configuration value for auth_token_command or auth_token_file. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -101,6 +427,7 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 	account_id := os.Getenv("HOSTINGDE_ACCOUNT_ID")
 	auth_token := os.Getenv("HOSTINGDE_AUTH_TOKEN")
 	base_url := os.Getenv("HOSTINGDE_BASE_URL")
+	fallback_base_url := os.Getenv("HOSTINGDE_FALLBACK_BASE_URL")
 
 	if !config.AccountId.IsNull() {
 		account_id = config.AccountId.ValueString()
@@ -110,13 +437,53 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 		auth_token = config.AuthToken.ValueString()
 	}
 
+	var tokenOpts ClientOptions
+	if !config.AuthTokenCommand.IsNull() {
+		resp.Diagnostics.Append(config.AuthTokenCommand.ElementsAs(ctx, &tokenOpts.AuthTokenCommand, false)...)
+	}
+	tokenOpts.AuthTokenFile = config.AuthTokenFile.ValueString()
+	if tokenOpts.hasAuthTokenSource() {
+		token, err := readAuthToken(ctx, tokenOpts)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading hosting.de API auth token",
+				"The provider cannot create the hosting.de API client as the auth token could not be read: "+err.Error(),
+			)
+			return
+		}
+		auth_token = token
+	}
+
 	if !config.BaseUrl.IsNull() {
 		base_url = config.BaseUrl.ValueString()
 	}
 
+	if !config.FallbackBaseUrl.IsNull() {
+		fallback_base_url = config.FallbackBaseUrl.ValueString()
+	}
+
 	// Default for API Base URL
 	if base_url == "" {
-		base_url = defaultBaseURL
+		apiVersion := defaultAPIVersion
+		if !config.APIVersion.IsNull() {
+			apiVersion = config.APIVersion.ValueString()
+		}
+		base_url = apiBaseURL(apiVersion)
+	} else if !config.APIVersion.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("api_version"),
+			"API version ignored",
+			"The api_version is ignored, because the base_url "+base_url+" is set and already contains the API version.",
+		)
+	}
+
+	if config.StrictWarnings.ValueBool() {
+		resp.Diagnostics = promoteWarnings(resp.Diagnostics)
+	}
+
+	resp.Diagnostics.Append(validateBaseURL(path.Root("base_url"), base_url)...)
+	if fallback_base_url != "" {
+		resp.Diagnostics.Append(validateBaseURL(path.Root("fallback_base_url"), fallback_base_url)...)
 	}
 
 	// If any of the expected configurations are missing, return
@@ -126,7 +493,7 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 			path.Root("auth_token"),
 			"Missing hosting.de API auth token",
 			"The provider cannot create the hosting.de API client as there is a missing or empty value for the hosting.de API auth token. "+
-				"Set the auth_token value in the configuration or use the HOSTINGDE_AUTH_TOKEN environment variable. "+
+				"Set the auth_token, auth_token_command or auth_token_file value in the configuration or use the HOSTINGDE_AUTH_TOKEN environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -138,12 +505,115 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 	ctx = tflog.SetField(ctx, "hostingde_account_id", account_id)
 	ctx = tflog.SetField(ctx, "hostingde_auth_token", auth_token)
 	ctx = tflog.SetField(ctx, "hostingde_base_url", base_url)
+	ctx = tflog.SetField(ctx, "hostingde_fallback_base_url", fallback_base_url)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "hostingde_auth_token")
 
 	tflog.Debug(ctx, "Creating hosting.de client")
 
+	clientOpts := ClientOptions{
+		MaxIdleConns:              defaultMaxIdleConns,
+		MaxConnsPerHost:           defaultMaxConnsPerHost,
+		MaxRedirects:              defaultMaxRedirects,
+		CircuitBreakerCooldown:    defaultCircuitBreakerCooldown,
+		RateLimitWarningThreshold: defaultRateLimitWarningThreshold,
+		APILanguage:               defaultAPILanguage,
+		RecordMatchStrategy:       recordMatchID,
+	}
+
+	if !config.RecordMatchStrategy.IsNull() {
+		clientOpts.RecordMatchStrategy = config.RecordMatchStrategy.ValueString()
+	}
+
+	if !config.APILanguage.IsNull() {
+		clientOpts.APILanguage = config.APILanguage.ValueString()
+	}
+
+	if !config.MaxIdleConns.IsNull() {
+		clientOpts.MaxIdleConns = int(config.MaxIdleConns.ValueInt64())
+	}
+
+	if !config.MaxConnsPerHost.IsNull() {
+		clientOpts.MaxConnsPerHost = int(config.MaxConnsPerHost.ValueInt64())
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		clientOpts.MaxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !config.CircuitBreakerThreshold.IsNull() {
+		clientOpts.CircuitBreakerThreshold = int(config.CircuitBreakerThreshold.ValueInt64())
+	}
+
+	if !config.ReadRetries.IsNull() {
+		clientOpts.ReadRetries = int(config.ReadRetries.ValueInt64())
+	}
+
+	if !config.RateLimitWarningThreshold.IsNull() {
+		clientOpts.RateLimitWarningThreshold = int(config.RateLimitWarningThreshold.ValueInt64())
+	}
+
+	if !config.CircuitBreakerCooldown.IsNull() {
+		cooldown, err := time.ParseDuration(config.CircuitBreakerCooldown.ValueString())
+		if err != nil || cooldown <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("circuit_breaker_cooldown"),
+				"Invalid circuit breaker cooldown",
+				"The circuit_breaker_cooldown value must be a positive duration like \"30s\" or \"1m\", got: "+config.CircuitBreakerCooldown.ValueString(),
+			)
+			return
+		}
+		clientOpts.CircuitBreakerCooldown = cooldown
+	}
+
+	if !config.MaxRedirects.IsNull() {
+		clientOpts.MaxRedirects = int(config.MaxRedirects.ValueInt64())
+	}
+
+	if !config.NewRecordDefaultTTL.IsNull() {
+		ttl := config.NewRecordDefaultTTL.ValueInt64()
+		clientOpts.NewRecordDefaultTTL = &ttl
+	}
+
+	clientOpts.RequireExplicitTTL = config.RequireExplicitTTL.ValueBool()
+	clientOpts.StrictWarnings = config.StrictWarnings.ValueBool()
+
+	if config.ZoneDefaults != nil {
+		clientOpts.ZoneDefaults = zoneDefaults(*config.ZoneDefaults)
+	}
+
+	if !config.DefaultNameserverSet.IsNull() {
+		clientOpts.DefaultNameserverSet = config.DefaultNameserverSet.ValueString()
+	}
+
+	if !config.OperationJitter.IsNull() {
+		jitter, err := time.ParseDuration(config.OperationJitter.ValueString())
+		if err != nil || jitter < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("operation_jitter"),
+				"Invalid operation jitter",
+				"The operation_jitter value must be a non-negative duration like \"500ms\" or \"2s\", got: "+config.OperationJitter.ValueString(),
+			)
+			return
+		}
+		clientOpts.OperationJitter = jitter
+	}
+
+	if !config.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(config.ExtraHeaders.ElementsAs(ctx, &clientOpts.ExtraHeaders, false)...)
+		resp.Diagnostics.Append(validateExtraHeaders(clientOpts.ExtraHeaders)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	clientOpts.FallbackBaseURL = strings.TrimSuffix(fallback_base_url, "/")
+	clientOpts.ManagedByComment = config.ManagedByComment.ValueString()
+	clientOpts.ReadOnly = config.ReadOnly.ValueBool()
+	clientOpts.AuthTokenCommand = tokenOpts.AuthTokenCommand
+	clientOpts.AuthTokenFile = tokenOpts.AuthTokenFile
+
 	// Create a new hosting.de client using the configuration values
-	client := NewClient(&account_id, &auth_token, &base_url)
+	client := NewClient(&account_id, &auth_token, &base_url, clientOpts)
 
 	// Make the hosting.de client available during DataSource and Resource
 	// type Configure methods.
@@ -155,7 +625,19 @@ func (p *hostingdeProvider) Configure(ctx context.Context, req provider.Configur
 
 // DataSources defines the data sources implemented in the provider.
 func (p *hostingdeProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewZoneDataSource,
+		NewZoneRecordsDataSource,
+		NewZonesDataSource,
+		NewRecordValuesDataSource,
+		NewZoneExportDataSource,
+		NewRecordPropagationDataSource,
+		NewZoneStatusDataSource,
+		NewDelegationStatusDataSource,
+		NewManagedInventoryDataSource,
+		NewZoneDriftDataSource,
+		NewZonesRecordsDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
@@ -163,5 +645,179 @@ func (p *hostingdeProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewRecordResource,
+		NewAcmeChallengeResource,
+		NewRecordSetResource,
+		NewDkimRecordResource,
+		NewSpfRecordResource,
+		NewDmarcRecordResource,
+		NewNameserverSetResource,
+	}
+}
+
+// zoneDefaults returns the client options of the zone_defaults attribute.
+func zoneDefaults(model providerZoneDefaultsModel) ZoneDefaults {
+	defaults := ZoneDefaults{
+		Type:            model.Type.ValueString(),
+		NameserverSet:   model.NameserverSet.ValueString(),
+		DNSSECAlgorithm: model.DNSSECAlgorithm.ValueString(),
+		DNSSECNSECMode:  model.DNSSECNSECMode.ValueString(),
 	}
+
+	soaValues := SOAValues{
+		Refresh:     int(model.SOARefresh.ValueInt64()),
+		Retry:       int(model.SOARetry.ValueInt64()),
+		Expire:      int(model.SOAExpire.ValueInt64()),
+		TTL:         int(model.DefaultTTL.ValueInt64()),
+		NegativeTTL: int(model.SOANegativeTTL.ValueInt64()),
+	}
+	if soaValues != (SOAValues{}) {
+		defaults.SOAValues = &soaValues
+	}
+
+	return defaults
+}
+
+// validateExtraHeaders checks that the extra headers are valid HTTP headers
+// and don't override the headers controlled by the client.
+func validateExtraHeaders(headers map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for name, value := range headers {
+		attributePath := path.Root("extra_headers").AtMapKey(name)
+		switch {
+		case !httpguts.ValidHeaderFieldName(name):
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid header name",
+				"The extra header name "+strconv.Quote(name)+" is not a valid HTTP header name.",
+			)
+		case slices.Contains(reservedHeaders, http.CanonicalHeaderKey(name)):
+			diags.AddAttributeError(
+				attributePath,
+				"Reserved header",
+				"The header "+http.CanonicalHeaderKey(name)+" is controlled by the provider and can not be set in extra_headers. "+
+					"Reserved headers are "+strings.Join(reservedHeaders, ", ")+".",
+			)
+		case !httpguts.ValidHeaderFieldValue(value):
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid header value",
+				"The value of the extra header "+name+" is not a valid HTTP header value.",
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateBaseURL checks that a base URL of the API is an absolute HTTP or
+// HTTPS URL.
+func validateBaseURL(attributePath path.Path, baseURL string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid base URL",
+			"The base URL must be an absolute HTTP or HTTPS URL like "+apiBaseURL(defaultAPIVersion)+", got: "+baseURL,
+		)
+	}
+
+	return diags
+}
+
+// apiBaseURL returns the base URL of the given version of the hosting.de
+// DNS API.
+func apiBaseURL(apiVersion string) string {
+	return defaultAPIHost + "/api/dns/" + apiVersion + "/json"
+}
+
+// checkReadOnly returns an error diagnostic if the provider is configured as
+// read only, to be called before any resource modifies data.
+func checkReadOnly(client *Client, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client.options.ReadOnly {
+		diags.AddError(
+			"Provider is read only",
+			"Refusing to "+operation+", because the hostingde provider is configured with read_only = true. "+
+				"Set read_only to false to allow modifications.",
+		)
+	}
+
+	return diags
+}
+
+// promoteWarnings returns the diagnostics with the warnings turned into
+// errors, for the strict_warnings option of the provider.
+func promoteWarnings(diags diag.Diagnostics) diag.Diagnostics {
+	promoted := make(diag.Diagnostics, 0, len(diags))
+	for _, d := range diags {
+		if d.Severity() != diag.SeverityWarning {
+			promoted = append(promoted, d)
+			continue
+		}
+
+		detail := d.Detail() + " This warning is an error, because the hostingde provider is configured with strict_warnings = true."
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			promoted = append(promoted, diag.NewAttributeErrorDiagnostic(withPath.Path(), d.Summary(), detail))
+		} else {
+			promoted = append(promoted, diag.NewErrorDiagnostic(d.Summary(), detail))
+		}
+	}
+
+	return promoted
+}
+
+// strictWarnings turns the warnings into errors if the provider is configured
+// with strict_warnings. Operations changing remote objects call it before the
+// change only, warnings about a change already made stay warnings, so the
+// resource isn't tainted.
+func (c *Client) strictWarnings(diags *diag.Diagnostics) {
+	if c == nil || !c.options.StrictWarnings {
+		return
+	}
+
+	*diags = promoteWarnings(*diags)
+}
+
+// clientWarningsKey is the context key of the clientWarnings of an operation.
+type clientWarningsKey struct{}
+
+// clientWarnings collects the warnings of the API requests of an operation,
+// like the warnings in the responses of the API, to report them as
+// diagnostics.
+type clientWarnings struct {
+	mu    sync.Mutex
+	diags diag.Diagnostics
+}
+
+// withClientWarnings returns a context collecting the warnings of the API
+// requests made with it.
+func withClientWarnings(ctx context.Context) (context.Context, *clientWarnings) {
+	warnings := &clientWarnings{}
+	return context.WithValue(ctx, clientWarningsKey{}, warnings), warnings
+}
+
+// addClientWarning adds a warning to the warnings collected for the context.
+// Without collection it is only logged.
+func addClientWarning(ctx context.Context, summary string, detail string) {
+	warnings, ok := ctx.Value(clientWarningsKey{}).(*clientWarnings)
+	if !ok {
+		tflog.Warn(ctx, summary+": "+detail)
+		return
+	}
+
+	warnings.mu.Lock()
+	defer warnings.mu.Unlock()
+	warnings.diags.AddWarning(summary, detail)
+}
+
+// appendTo moves the collected warnings to the diagnostics.
+func (w *clientWarnings) appendTo(diags *diag.Diagnostics) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	diags.Append(w.diags...)
+	w.diags = nil
 }
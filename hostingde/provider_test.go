@@ -1,8 +1,18 @@
 package hostingde
 
 import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 const (
@@ -26,3 +36,310 @@ var (
 		"hostingde": providerserver.NewProtocol6WithError(New()),
 	}
 )
+
+// testValidateResourceConfig runs the provider's validation of a resource
+// configuration, including the schema validators, without a Terraform binary.
+// Attributes missing from the given values are null.
+func testValidateResourceConfig(t *testing.T, res resource.Resource, values map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	metadataResp := resource.MetadataResponse{}
+	res.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "hostingde"}, &metadataResp)
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, attributes))
+	if err != nil {
+		t.Fatalf("could not encode config: %v", err)
+	}
+
+	server, err := providerserver.NewProtocol6WithError(New())()
+	if err != nil {
+		t.Fatalf("could not create provider server: %v", err)
+	}
+
+	validateResp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: metadataResp.TypeName,
+		Config:   &config,
+	})
+	if err != nil {
+		t.Fatalf("could not validate config: %v", err)
+	}
+
+	return validateResp.Diagnostics
+}
+
+// testModifyPlan runs ModifyPlan of a resource on the given configuration,
+// plan and state values, attributes missing from the values are null. A nil
+// state plans the creation of the resource.
+func testModifyPlan(t *testing.T, res resource.ResourceWithModifyPlan, config, plan, state map[string]tftypes.Value) (tfsdk.Plan, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	value := func(values map[string]tftypes.Value) tftypes.Value {
+		if values == nil {
+			return tftypes.NewValue(objectType, nil)
+		}
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			if value, ok := values[name]; ok {
+				attributes[name] = value
+			}
+		}
+		return tftypes.NewValue(objectType, attributes)
+	}
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: value(config)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(plan)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: value(state)},
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	res.ModifyPlan(ctx, req, &resp)
+
+	return resp.Plan, resp.Diagnostics
+}
+
+// testDeleteResource runs Delete of a resource on a state with the given
+// attribute values, attributes missing from the values are null.
+func testDeleteResource(t *testing.T, res resource.Resource, values map[string]tftypes.Value) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	deleteResp := resource.DeleteResponse{State: state}
+	res.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+
+	return deleteResp.Diagnostics
+}
+
+// testCreateResource runs Create of a resource on a plan with the given
+// attribute values, attributes missing from the values are null.
+func testCreateResource(t *testing.T, res resource.Resource, values map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	res.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+
+	return createResp.State, createResp.Diagnostics
+}
+
+// testUpdateResource runs Update of a resource from a state to a plan with
+// the given attribute values, attributes missing from the values are null.
+func testUpdateResource(t *testing.T, res resource.Resource, config, plan, state map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	value := func(values map[string]tftypes.Value) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			if value, ok := values[name]; ok {
+				attributes[name] = value
+			}
+		}
+		return tftypes.NewValue(objectType, attributes)
+	}
+
+	req := resource.UpdateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: value(config)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(plan)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: value(state)},
+	}
+	updateResp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: value(plan)}}
+	res.Update(ctx, req, &updateResp)
+
+	return updateResp.State, updateResp.Diagnostics
+}
+
+// testReadResource runs Read of a resource on a state with the given
+// attribute values, attributes missing from the values are null.
+func testReadResource(t *testing.T, res resource.Resource, values map[string]tftypes.Value) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+	readResp := resource.ReadResponse{State: state}
+	res.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+
+	return readResp.State, readResp.Diagnostics
+}
+
+func TestValidateExtraHeaders(t *testing.T) {
+	for name, tc := range map[string]struct {
+		headers   map[string]string
+		wantError bool
+	}{
+		"custom header":  {headers: map[string]string{"X-Edge-Auth": "secret"}},
+		"invalid name":   {headers: map[string]string{"X Edge": "secret"}, wantError: true},
+		"invalid value":  {headers: map[string]string{"X-Edge-Auth": "secret\n"}, wantError: true},
+		"reserved":       {headers: map[string]string{"content-type": "text/plain"}, wantError: true},
+		"reserved other": {headers: map[string]string{"Host": "example.test"}, wantError: true},
+		"language":       {headers: map[string]string{"Accept-Language": "de"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if diags := validateExtraHeaders(tc.headers); diags.HasError() != tc.wantError {
+				t.Errorf("got error %t, want %t, diagnostics: %v", diags.HasError(), tc.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	if got, want := apiBaseURL(defaultAPIVersion), "https://secure.hosting.de/api/dns/v1/json"; got != want {
+		t.Errorf("default base URL is %s, want %s", got, want)
+	}
+
+	for version, wantError := range map[string]bool{
+		"v1":   false,
+		"v2":   false,
+		"v10":  false,
+		"1":    true,
+		"v0":   true,
+		"v1.1": true,
+		"v1/":  true,
+	} {
+		req := validator.StringRequest{ConfigValue: types.StringValue(version)}
+		resp := validator.StringResponse{}
+		apiVersionValidator.ValidateString(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("api_version %q: got error %t, want %t", version, resp.Diagnostics.HasError(), wantError)
+		}
+	}
+}
+
+func TestAPILanguage(t *testing.T) {
+	for language, wantError := range map[string]bool{
+		"en":         false,
+		"de":         false,
+		"de-DE":      false,
+		"zh-Hant-TW": false,
+		"":           true,
+		"english":    true,
+		"en_US":      true,
+		"en-":        true,
+		"de, en":     true,
+	} {
+		req := validator.StringRequest{ConfigValue: types.StringValue(language)}
+		resp := validator.StringResponse{}
+		apiLanguageValidator.ValidateString(context.Background(), req, &resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("api_language %q: got error %t, want %t", language, resp.Diagnostics.HasError(), wantError)
+		}
+	}
+}
+
+func TestZoneDefaults(t *testing.T) {
+	if got := zoneDefaults(providerZoneDefaultsModel{Type: types.StringValue("MASTER")}); got.SOAValues != nil {
+		t.Errorf("got SOA values %v without SOA defaults, want nil", *got.SOAValues)
+	}
+
+	got := zoneDefaults(providerZoneDefaultsModel{
+		NameserverSet:  types.StringValue("external"),
+		SOARefresh:     types.Int64Value(86400),
+		SOANegativeTTL: types.Int64Value(300),
+		DefaultTTL:     types.Int64Value(3600),
+	})
+	want := SOAValues{Refresh: 86400, TTL: 3600, NegativeTTL: 300}
+	if got.NameserverSet != "external" || got.SOAValues == nil || *got.SOAValues != want {
+		t.Errorf("got zone defaults %+v, want nameserver set external with SOA values %+v", got, want)
+	}
+}
+
+func TestValidateBaseURL(t *testing.T) {
+	for baseURL, wantError := range map[string]bool{
+		"https://secure.hosting.de/api/dns/v1/json": false,
+		"http://localhost:8080/api":                 false,
+		"secure.hosting.de/api/dns/v1/json":         true,
+		"ftp://secure.hosting.de":                   true,
+		"https://":                                  true,
+		"":                                          true,
+	} {
+		if diags := validateBaseURL(path.Root("base_url"), baseURL); diags.HasError() != wantError {
+			t.Errorf("base URL %q: got error %t, want %t, diagnostics: %v", baseURL, diags.HasError(), wantError, diags)
+		}
+	}
+}
+
+func TestPromoteWarnings(t *testing.T) {
+	var diags diag.Diagnostics
+	diags.AddAttributeWarning(path.Root("name"), "Record name includes the zone name", "Set a relative name.")
+	diags.AddWarning("Record not deleted", "The record was kept.")
+	diags.AddError("Error creating record", "Could not create record.")
+
+	promoted := promoteWarnings(diags)
+	if promoted.WarningsCount() != 0 || promoted.ErrorsCount() != 3 {
+		t.Fatalf("got %d warnings and %d errors, want only 3 errors: %v", promoted.WarningsCount(), promoted.ErrorsCount(), promoted)
+	}
+	if withPath, ok := promoted[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("name")) {
+		t.Errorf("got diagnostic %v, want the attribute path kept", promoted[0])
+	}
+
+	// Without strict_warnings, or before the provider is configured, the
+	// warnings are kept
+	for _, client := range []*Client{nil, {}} {
+		kept := diags
+		client.strictWarnings(&kept)
+		if kept.WarningsCount() != 2 {
+			t.Errorf("got %d warnings, want 2", kept.WarningsCount())
+		}
+	}
+}
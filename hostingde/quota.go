@@ -0,0 +1,89 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// quotaErrorPattern matches the values and texts of API errors rejecting a
+// request because a limit of the account is reached.
+var quotaErrorPattern = regexp.MustCompile(`(?i)quota|limit (is )?(reached|exceeded)|too many (zones|records)`)
+
+// rateLimitErrorPattern matches API errors of a throttled request, like "rate
+// limit exceeded". They match quotaErrorPattern as well, but the request can
+// simply be repeated later, no limit of the account is reached.
+var rateLimitErrorPattern = regexp.MustCompile(`(?i)rate.?limit|too many requests|throttl`)
+
+// quotaLimitDetails are the keys of API error details that may carry the
+// limit that was reached, checked in order.
+var quotaLimitDetails = []string{"limit", "maximum", "max", "quota"}
+
+// QuotaExceededError is returned if the API rejected a request because a
+// limit of the account, like the number of zones, is reached.
+type QuotaExceededError struct {
+	URI  string
+	Body []byte
+	// Reason is the text of the API error.
+	Reason string
+	// Limit is the limit from the details of the API error, empty if the API
+	// didn't report it.
+	Limit string
+}
+
+func (e *QuotaExceededError) Error() string {
+	return "account limit reached: " + e.Reason + ": " + toErrorWithNewlines(e.URI, e.Body)
+}
+
+// quotaExceeded returns a QuotaExceededError if one of the API errors
+// reports a reached limit, or nil otherwise.
+func quotaExceeded(uri string, body []byte, apiErrors []APIError) *QuotaExceededError {
+	for _, apiError := range apiErrors {
+		if !quotaErrorPattern.MatchString(apiError.Value) && !quotaErrorPattern.MatchString(apiError.Text) {
+			continue
+		}
+		if rateLimitErrorPattern.MatchString(apiError.Value) || rateLimitErrorPattern.MatchString(apiError.Text) {
+			continue
+		}
+
+		quotaErr := &QuotaExceededError{URI: uri, Body: body, Reason: apiError.Text}
+		if quotaErr.Reason == "" {
+			quotaErr.Reason = apiError.Value
+		}
+		for _, key := range quotaLimitDetails {
+			for _, detail := range apiError.Details {
+				if quotaErr.Limit == "" && strings.EqualFold(detail.Key, key) {
+					quotaErr.Limit = detail.Value
+				}
+			}
+		}
+
+		return quotaErr
+	}
+
+	return nil
+}
+
+// quotaMessage returns the detail of the diagnostic of a reached limit,
+// naming the limit and the current usage. The usage is only counted here, so
+// the requests are made only once a limit was actually reached.
+func quotaMessage(ctx context.Context, quotaErr *QuotaExceededError, objects string, count func(context.Context) (int, error)) string {
+	limit := "The limit of the account"
+	if quotaErr.Limit != "" {
+		limit = "The limit of " + quotaErr.Limit + " " + objects
+	}
+
+	usage := "could not be read"
+	if n, err := count(ctx); err != nil {
+		usage += ": " + err.Error()
+	} else {
+		usage = "is " + strconv.Itoa(n) + " " + objects
+	}
+
+	return fmt.Sprintf("hosting.de rejected the request because a limit of the account is reached: %s. "+
+		"%s is reached, the current usage %s. "+
+		"Delete %s that are no longer needed, or ask hosting.de to raise the limit.",
+		quotaErr.Reason, limit, usage, objects)
+}
@@ -0,0 +1,65 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQuotaExceeded(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		apiError  APIError
+		wantQuota bool
+		wantLimit string
+	}{
+		{
+			name:      "limit in details",
+			apiError:  APIError{Value: "zoneLimitReached", Text: "The zone limit is reached", Details: []APIErrorDetail{{Key: "Limit", Value: "50"}}},
+			wantQuota: true,
+			wantLimit: "50",
+		},
+		{name: "quota without limit", apiError: APIError{Text: "Quota exceeded"}, wantQuota: true},
+		{name: "other error", apiError: APIError{Value: "invalidValue", Text: "Invalid record content"}},
+		{name: "rate limit", apiError: APIError{Value: "rateLimitExceeded", Text: "Rate limit exceeded"}},
+		{name: "rate limit text", apiError: APIError{Text: "API rate limit reached, too many requests"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			quotaErr := quotaExceeded("https://example.test/zoneCreate", nil, []APIError{tc.apiError})
+			if (quotaErr != nil) != tc.wantQuota {
+				t.Fatalf("got quota error %v, want %t", quotaErr, tc.wantQuota)
+			}
+			if quotaErr != nil && quotaErr.Limit != tc.wantLimit {
+				t.Errorf("got limit %q, want %q", quotaErr.Limit, tc.wantLimit)
+			}
+		})
+	}
+}
+
+func TestCreateZoneQuotaExceeded(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneCreate": func(t *testing.T, body []byte) any {
+			return json.RawMessage(`{"status": "error", "errors": [{"value": "zoneLimitReached", "text": "The zone limit is reached",
+				"details": [{"key": "limit", "value": "3"}]}]}`)
+		},
+		// The usage is only counted once the limit was reached
+		"/zoneConfigsFind": func(t *testing.T, body []byte) any {
+			return json.RawMessage(`{"status": "success", "response": {"totalEntries": 3, "data": [{"id": "1"}]}}`)
+		},
+	})
+
+	_, err := client.createZone(context.Background(), ZoneCreateRequest{BaseRequest: &BaseRequest{}})
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("got error %v, want a QuotaExceededError", err)
+	}
+
+	message := quotaMessage(context.Background(), quotaErr, "zones", client.countZones)
+	for _, want := range []string{"The zone limit is reached", "limit of 3 zones", "current usage is 3 zones"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message %q doesn't contain %q", message, want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package hostingde
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &recordPropagationDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordPropagationDataSource{}
+)
+
+// NewRecordPropagationDataSource is a helper function to simplify the provider implementation.
+func NewRecordPropagationDataSource() datasource.DataSource {
+	return &recordPropagationDataSource{}
+}
+
+// recordPropagationDataSource is the data source implementation.
+type recordPropagationDataSource struct {
+	client *Client
+}
+
+// recordPropagationDataSourceModel maps the data source schema data.
+type recordPropagationDataSourceModel struct {
+	ZoneName       types.String                   `tfsdk:"zone_name"`
+	Name           types.String                   `tfsdk:"name"`
+	Type           types.String                   `tfsdk:"type"`
+	ExpectedValues []string                       `tfsdk:"expected_values"`
+	Nameservers    []string                       `tfsdk:"nameservers"`
+	Timeout        types.String                   `tfsdk:"timeout"`
+	Propagated     types.Bool                     `tfsdk:"propagated"`
+	Results        []recordPropagationResultModel `tfsdk:"results"`
+}
+
+// recordPropagationResultModel maps the result of a single nameserver.
+type recordPropagationResultModel struct {
+	Nameserver types.String `tfsdk:"nameserver"`
+	Values     []string     `tfsdk:"values"`
+	Propagated types.Bool   `tfsdk:"propagated"`
+	Error      types.String `tfsdk:"error"`
+}
+
+// Metadata returns the data source type name.
+func (d *recordPropagationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_propagation"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordPropagationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks whether the records with a given name and type are served by the authoritative nameservers of the zone. " +
+			"The nameservers are queried directly over DNS, without recursion.",
+		Attributes: map[string]schema.Attribute{
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the zone.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the records. Example: mail.example.com.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the records.",
+				Required:    true,
+			},
+			"expected_values": schema.ListAttribute{
+				Description: "Content the nameservers must serve, without the priority. " +
+					"Defaults to the content of the matching records in hosting.de.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Nameservers to query, as host names or IP addresses with an optional port. " +
+					"Defaults to the nameservers of the zone's NS records at the apex.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Timeout of the query to each nameserver, as a duration like \"5s\". Defaults to 5s.",
+				Optional:    true,
+			},
+			"propagated": schema.BoolAttribute{
+				Description: "Whether every nameserver serves exactly the expected values.",
+				Computed:    true,
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "Answer of each nameserver.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"nameserver": schema.StringAttribute{
+							Description: "The queried nameserver.",
+							Computed:    true,
+						},
+						"values": schema.ListAttribute{
+							Description: "Sorted content served by the nameserver, without the priority.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"propagated": schema.BoolAttribute{
+							Description: "Whether the nameserver serves exactly the expected values.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Error querying the nameserver, null if it answered.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordPropagationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state recordPropagationDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPropagationTimeout
+	if !state.Timeout.IsNull() {
+		var err error
+		timeout, err = time.ParseDuration(state.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid timeout",
+				"The timeout value must be a positive duration like \"500ms\" or \"5s\", got: "+state.Timeout.ValueString(),
+			)
+			return
+		}
+	}
+
+	if state.ExpectedValues == nil || state.Nameservers == nil {
+		zoneConfig, err := d.client.getZoneConfigByName(ctx, state.ZoneName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone",
+				"Could not read hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		if state.ExpectedValues == nil {
+			records, err := d.client.listAllRecords(ctx, FilterOrChain{
+				SubFilterConnective: "AND",
+				SubFilter: []Filter{
+					{Field: "ZoneConfigId", Value: zoneConfig.ID},
+					{Field: "RecordName", Value: state.Name.ValueString()},
+					{Field: "RecordType", Value: state.Type.ValueString()},
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading hosting.de DNS zone records",
+					"Could not read records of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+
+			state.ExpectedValues = []string{}
+			for _, record := range records {
+				content, _ := splitPriority(record)
+				state.ExpectedValues = append(state.ExpectedValues, content)
+			}
+		}
+
+		if state.Nameservers == nil {
+			state.Nameservers, err = d.client.listZoneNameservers(ctx, zoneConfig.ID, zoneConfig.Name)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error Reading hosting.de DNS zone records",
+					"Could not read nameservers of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+				)
+				return
+			}
+		}
+	}
+
+	propagated, results := checkPropagation(ctx, state.Nameservers, state.Name.ValueString(), state.Type.ValueString(), state.ExpectedValues, timeout)
+
+	state.Propagated = types.BoolValue(propagated)
+	state.Results = []recordPropagationResultModel{}
+	for _, result := range results {
+		resultModel := recordPropagationResultModel{
+			Nameserver: types.StringValue(result.Nameserver),
+			Values:     result.Values,
+			Propagated: types.BoolValue(result.Propagated),
+			Error:      types.StringNull(),
+		}
+		if result.Err != nil {
+			resultModel.Error = types.StringValue(result.Err.Error())
+		}
+		state.Results = append(state.Results, resultModel)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordPropagationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -2,23 +2,72 @@ package hostingde
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// How the priority of a record type is transported to the API.
+const (
+	// priorityField uses the separate priority field of the record.
+	priorityField = iota + 1
+	// priorityContent prepends the priority to the record content.
+	priorityContent
+)
+
+// recordTypes lists the record types documented for the type attribute of a
+// record. Other types are passed to hosting.de as they are.
+var recordTypes = []string{
+	"A", "AAAA", "ALIAS", "CAA", "CERT", "CNAME", "DNSKEY", "DS", "MX", "NAPTR", "NS", "NSEC", "NSEC3",
+	"NSEC3PARAM", "NULLMX", "OPENPGPKEY", "PTR", "RRSIG", "SRV", "SSHFP", "TLSA", "TXT", "URI",
+}
+
+// knownRecordTypes lists the record types handled by the provider, which
+// includes the SOA record managed by hosting.de. Records of other types are
+// still read, with their content passed through unchanged.
+var knownRecordTypes = append(slices.Clone(recordTypes), "SOA")
+
+// priorityRecordTypes lists the record types that have a priority.
+var priorityRecordTypes = map[string]int{
+	"MX":    priorityField,
+	"SRV":   priorityField,
+	"URI":   priorityContent,
+	"NAPTR": priorityContent,
+}
+
+// serverTTLRecordTypes lists the record types whose TTL is controlled by
+// hosting.de, a configured TTL never applies to them.
+var serverTTLRecordTypes = []string{"ALIAS"}
+
+// defaultRecordTTL is the TTL of records that don't configure one.
+const defaultRecordTTL = 3600
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &recordResource{}
-	_ resource.ResourceWithConfigure   = &recordResource{}
-	_ resource.ResourceWithImportState = &recordResource{}
+	_ resource.Resource                   = &recordResource{}
+	_ resource.ResourceWithConfigure      = &recordResource{}
+	_ resource.ResourceWithImportState    = &recordResource{}
+	_ resource.ResourceWithValidateConfig = &recordResource{}
+	_ resource.ResourceWithModifyPlan     = &recordResource{}
 )
 
 // NewRecordResource is a helper function to simplify the provider implementation.
@@ -33,13 +82,31 @@ type recordResource struct {
 
 // recordResourceModel maps the DNSRecord resource schema data.
 type recordResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	ZoneID   types.String `tfsdk:"zone_id"`
-	Name     types.String `tfsdk:"name"`
-	Type     types.String `tfsdk:"type"`
-	Content  types.String `tfsdk:"content"`
-	TTL      types.Int64  `tfsdk:"ttl"`
-	Priority types.Int64  `tfsdk:"priority"`
+	ID           types.String `tfsdk:"id"`
+	RecordID     types.String `tfsdk:"record_id"`
+	ZoneID       types.String `tfsdk:"zone_id"`
+	ZoneName     types.String `tfsdk:"zone_name"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Content      types.String `tfsdk:"content"`
+	TTL          types.Int64  `tfsdk:"ttl"`
+	TTLDuration  types.String `tfsdk:"ttl_duration"`
+	EffectiveTTL types.Int64  `tfsdk:"effective_ttl"`
+	Priority     types.Int64  `tfsdk:"priority"`
+
+	// ResolvedContent is the content with the ${zone} placeholder replaced
+	ResolvedContent types.String `tfsdk:"resolved_content"`
+
+	AddDate        types.String `tfsdk:"add_date"`
+	LastChangeDate types.String `tfsdk:"last_change_date"`
+
+	ReadZoneSerial types.Bool  `tfsdk:"read_zone_serial"`
+	ZoneSerial     types.Int64 `tfsdk:"zone_serial"`
+
+	Upsert              types.Bool   `tfsdk:"upsert"`
+	CreateZoneIfMissing types.Bool   `tfsdk:"create_zone_if_missing"`
+	WaitForPropagation  types.Bool   `tfsdk:"wait_for_propagation"`
+	PropagationTimeout  types.String `tfsdk:"propagation_timeout"`
 }
 
 // Metadata returns the resource type name.
@@ -50,6 +117,13 @@ func (r *recordResource) Metadata(_ context.Context, req resource.MetadataReques
 // Schema defines the schema for the resource.
 func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Manages a single DNS record in a hosting.de zone. " +
+			"Changes to content, TTL or priority are applied in place, so the record name keeps resolving during the update. " +
+			"Replacing a value that is managed by two separate hostingde_record resources (removing one, adding the other) " +
+			"results in two independent API calls and can not be made atomic. " +
+			"NS records below the zone apex delegate a subzone. The SOA record and the NS records at the apex are managed by hosting.de " +
+			"for the zone itself and can't be created, changed or imported. Destroying one imported by an earlier version only removes it from the state. " +
+			"An NS record pointing to a nameserver within the zone causes a warning if the zone has no A or AAAA glue record for it.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "DNS record ID",
@@ -58,37 +132,157 @@ func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"record_id": schema.StringAttribute{
+				Description: "Native hosting.de identifier of the record, as shown in the hosting.de UI. " +
+					"Use it to cross-reference the record, for example in support tickets.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"zone_id": schema.StringAttribute{
-				Description: "ID of DNS zone that the record belongs to.",
-				Required:    true,
+				Description: "ID of DNS zone that the record belongs to. Required unless zone_name is set, " +
+					"in which case it is looked up when the record is created.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone_name")),
+				},
+			},
+			"zone_name": schema.StringAttribute{
+				Description: "Name of the DNS zone that the record belongs to, instead of zone_id. " +
+					"The zone is looked up by name when the record is created. Changing the zone name replaces the record.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Name of the record. Example: mail.example.com.",
-				Required:    true,
+				Description: "Name of the record relative to the zone, \"@\" for the zone apex. Example: mail. " +
+					"A name ending with the zone name, like mail.example.com in the zone example.com, is used without the zone suffix and causes a warning. " +
+					"Both forms refer to the same record, so switching between them updates the state without changing the record. " +
+					"Each label may be at most 63 bytes and the name including the zone at most 255 bytes in DNS messages, internationalized labels are measured in punycode.",
+				Required: true,
 			},
 			"type": schema.StringAttribute{
-				Description: "Type of the DNS record. Valid types are A, AAAA, ALIAS, CAA, CERT, CNAME, DNSKEY, DS, MX, NS, NSEC, NSEC3, NSEC3PARAM, NULLMX, OPENPGPKEY, PTR, RRSIG, SRV, SSHFP, TLSA, and TXT.",
-				Required:    true,
+				Description: "Type of the DNS record. Valid types are " + strings.Join(recordTypes[:len(recordTypes)-1], ", ") +
+					", and " + recordTypes[len(recordTypes)-1] + ". " +
+					"Changing the type replaces the record. CNAME records can't be at the zone apex, use ALIAS there instead.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"content": schema.StringAttribute{
-				Description: "Content of the DNS record.",
-				Required:    true,
+				Description: "Content of the DNS record. Host names in the content of ALIAS, CNAME, MX, NS, PTR and SRV records " +
+					"may be internationalized, they are sent to hosting.de in lower case punycode and kept in the configured form. " +
+					"The hex digests of DS, SSHFP and TLSA records are sent in lower case and kept in the configured form as well, " +
+					"other content like the base64 keys of DKIM records is case sensitive and sent unchanged. " +
+					"The target of CNAME, MX, NS and SRV records must be a host name, IP addresses are rejected. " +
+					"The placeholder ${zone} is replaced with the name of the zone, written as $${zone} in Terraform strings. " +
+					"A literal ${zone} is written as $${zone} in the content, or $$${zone} in Terraform strings. " +
+					"Quoted strings in the content of TXT records are limited to 255 bytes, the whole content to 65535 bytes in DNS messages. " +
+					"TXT content that hosting.de returns quoted or split differently, but with the same data, is kept in the configured form.",
+				Required: true,
 			},
-			"ttl": schema.Int64Attribute{
-				Description: "TTL of the DNS record in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+			"resolved_content": schema.StringAttribute{
+				Description: "Content of the DNS record as sent to hosting.de, with the ${zone} placeholder replaced. Equals content, unless it contains the placeholder.",
 				Computed:    true,
-				Required:    false,
-				Optional:    true,
-				Default:     int64default.StaticInt64(3600),
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the DNS record in seconds. Minimum is 60, maximum is 31556926. " +
+					"Defaults to the provider's new_record_default_ttl for new records, or 3600, unless the provider sets require_explicit_ttl. " +
+					"Set to 0 to use the default TTL of the zone, the resolved value is available in effective_ttl. " +
+					"The record follows changes of the zone default TTL on the next apply. " +
+					"For zones without SOA values the default isn't known, a changed TTL of such a record is only visible in effective_ttl. " +
+					"Must not be set for ALIAS records, whose TTL is controlled by hosting.de.",
+				Computed: true,
+				Required: false,
+				Optional: true,
+				Default:  int64default.StaticInt64(defaultRecordTTL),
 				Validators: []validator.Int64{
-					int64validator.Between(60, 31556926),
+					int64validator.Any(
+						int64validator.OneOf(0),
+						int64validator.Between(60, 31556926),
+					),
 				},
 			},
+			// A separate attribute, as ttl can't take strings like "1h": a
+			// number attribute rejects them before the provider sees the
+			// configuration, and a string ttl would need a state upgrade and
+			// turn ttl into a string for everything referencing it.
+			"ttl_duration": schema.StringAttribute{
+				Description: "TTL of the DNS record as a duration like \"1h\" or \"300s\", an alternative to ttl for readability. " +
+					"The duration is converted to seconds, which are stored in ttl. It must be a whole number of seconds within the limits of ttl. " +
+					"Conflicts with ttl.",
+				Optional: true,
+			},
+			"effective_ttl": schema.Int64Attribute{
+				Description: "TTL of the DNS record in seconds as applied by hosting.de. Equals ttl, unless ttl is 0.",
+				Computed:    true,
+			},
 			"priority": schema.Int64Attribute{
-				Description: "Priority of MX and SRV records.",
+				Description: "Priority of MX, NAPTR, SRV and URI records, required for these types. " +
+					"The content must not contain the priority, the provider adds it where the record format requires it.",
+				Computed: true,
+				Required: false,
+				Optional: true,
+			},
+			"add_date": schema.StringAttribute{
+				Description: "Time the record was created, as reported by the API. Null if the API doesn't return it.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_change_date": schema.StringAttribute{
+				Description: "Time of the last change to the record, as reported by the API. Null if the API doesn't return it.",
 				Computed:    true,
-				Required:    false,
-				Optional:    true,
+			},
+			"upsert": schema.BoolAttribute{
+				Description: "Whether creating the resource adopts an existing record with the same name, type and content, " +
+					"for example one left behind by an apply that failed halfway, instead of adding a duplicate. " +
+					"The TTL and priority of the adopted record are updated to the configured values. Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"create_zone_if_missing": schema.BoolAttribute{
+				Description: "Whether creating the record creates the zone given by zone_name if it doesn't exist yet, " +
+					"for example for a quickstart with a single resource. The zone is created like a hostingde_zone configuring only its name, " +
+					"with the zone_defaults and default_nameserver_set of the provider. It is not managed by this resource: " +
+					"destroying the record leaves the zone in place, and changes to the zone are not detected. " +
+					"Import the zone into a hostingde_zone resource to manage it. Requires zone_name. Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"wait_for_propagation": schema.BoolAttribute{
+				Description: "Whether creating or updating the record waits until all nameservers of the zone serve it, " +
+					"for example when the next step of an ACME DNS-01 challenge needs the record. Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"propagation_timeout": schema.StringAttribute{
+				Description: "How long to wait for the record to propagate if wait_for_propagation is true, " +
+					"as a duration like \"2m\". The apply fails once the timeout expired. Defaults to 5m.",
+				Optional: true,
+			},
+			"read_zone_serial": schema.BoolAttribute{
+				Description: "Whether creating or updating the record reads the serial of the zone afterwards into zone_serial, " +
+					"for example to correlate the change with monitoring of the nameservers. Costs an extra API request per apply. Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"zone_serial": schema.Int64Attribute{
+				Description: "Serial of the SOA record of the zone right after the record was last created or updated by Terraform. " +
+					"Not updated on refresh. Null unless read_zone_serial is true.",
+				Computed: true,
 			},
 		},
 	}
@@ -96,6 +290,14 @@ func (r *recordResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 
 // Create a new resource
 func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan recordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -104,34 +306,93 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Generate API request body from plan
-	record := DNSRecord{
-		Name:     plan.Name.ValueString(),
-		ZoneID:   plan.ZoneID.ValueString(),
-		Type:     plan.Type.ValueString(),
-		Content:  plan.Content.ValueString(),
-		TTL:      int(plan.TTL.ValueInt64()),
-		Priority: int(plan.Priority.ValueInt64()),
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
 	}
 
-	recordReq := RecordsUpdateRequest{
-		BaseRequest:  &BaseRequest{},
-		ZoneConfigId: plan.ZoneID.ValueString(),
-		RecordsToAdd: []DNSRecord{record},
+	if plan.ZoneID.IsUnknown() || plan.ZoneID.IsNull() {
+		resp.Diagnostics.Append(r.resolveZoneName(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	recordResp, err := r.client.updateRecords(recordReq)
+	zoneDefaultTTL, err := r.zoneDefaultTTL(ctx, plan.ZoneID.ValueString(), plan.TTL.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error updating records",
-			"Could not update records, unexpected error: "+err.Error(),
+			"Error Reading hosting.de DNS zone",
+			"Could not read default TTL of hosting.de DNS zone ID "+plan.ZoneID.ValueString()+": "+err.Error(),
 		)
 		return
 	}
 
+	zoneName, diags := lookupZoneName(ctx, r.client, plan.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(warnAbsoluteRecordName(plan.Name.ValueString(), zoneName)...)
+	resp.Diagnostics.Append(checkSystemRecord(plan.Type.ValueString(), recordFQDN(plan.Name.ValueString(), zoneName), zoneName)...)
+	resp.Diagnostics.Append(checkApexCNAME(plan.Type.ValueString(), recordFQDN(plan.Name.ValueString(), zoneName), zoneName)...)
+	resp.Diagnostics.Append(checkRecordName(plan.Name.ValueString(), zoneName)...)
+	resp.Diagnostics.Append(validateRecordHostname(plan, zoneName)...)
+	resp.Diagnostics.Append(validateRecordContentLength(plan, zoneName)...)
+	resolvedContent := expandZonePlaceholder(plan.Content.ValueString(), zoneName)
+	resp.Diagnostics.Append(r.warnMissingGlue(ctx, plan.ZoneID.ValueString(), plan.Type.ValueString(), resolvedContent, zoneName)...)
+	// Strict warnings about the configuration fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	record := DNSRecord{
+		Name:     recordFQDN(plan.Name.ValueString(), zoneName),
+		ZoneID:   plan.ZoneID.ValueString(),
+		Type:     plan.Type.ValueString(),
+		TTL:      requestTTL(plan.TTL.ValueInt64(), zoneDefaultTTL),
+		Comments: r.client.options.ManagedByComment,
+	}
+	record = withPriority(record, normalizeContent(record.Type, resolvedContent), plan.Priority.ValueInt64())
+
+	var recordResp *RecordsUpdateResponse
+	if plan.Upsert.ValueBool() {
+		recordResp, err = r.adoptRecord(ctx, record)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating records",
+				"Could not adopt existing record, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if recordResp == nil {
+		recordReq := RecordsUpdateRequest{
+			BaseRequest:  &BaseRequest{},
+			ZoneConfigId: plan.ZoneID.ValueString(),
+			RecordsToAdd: []DNSRecord{record},
+		}
+
+		recordResp, err = r.client.updateRecords(ctx, recordReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating records",
+				"Could not update records, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	var returnedRecord DNSRecord
 	for _, r := range recordResp.Response.Records {
-		if r.Name == record.Name && r.Type == record.Type && r.Content == record.Content && r.TTL == record.TTL {
+		// A TTL left to the API can't be matched against the request
+		if r.Name == record.Name && r.Type == record.Type && sameRecordContent(r.Type, record.Content, r.Content) && (record.TTL == 0 || r.TTL == record.TTL) {
 			returnedRecord = r
 		}
 	}
@@ -139,11 +400,18 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Overwrite DNS record with refreshed state
 	plan.ZoneID = types.StringValue(recordResp.Response.ZoneConfig.ID)
 	plan.ID = types.StringValue(returnedRecord.ID)
-	plan.Name = types.StringValue(returnedRecord.Name)
+	plan.RecordID = types.StringValue(returnedRecord.ID)
+	plan.Name = types.StringValue(recordStateName(plan.Name.ValueString(), returnedRecord.Name, zoneName))
 	plan.Type = types.StringValue(returnedRecord.Type)
-	plan.Content = types.StringValue(returnedRecord.Content)
-	plan.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	plan.Priority = types.Int64Value(int64(returnedRecord.Priority))
+	content, priority := splitPriority(returnedRecord)
+	plan.Content, plan.ResolvedContent = recordTemplateStateContent(returnedRecord.Type, plan.Content.ValueString(), content, zoneName)
+	plan.TTL = recordStateTTL(returnedRecord.Type, plan.TTL, returnedRecord.TTL, zoneDefaultTTL)
+	plan.EffectiveTTL = types.Int64Value(int64(returnedRecord.TTL))
+	plan.Priority = types.Int64Value(priority)
+	plan.AddDate = recordDate(returnedRecord.AddDate)
+	plan.LastChangeDate = recordDate(returnedRecord.LastChangeDate)
+
+	resp.Diagnostics.Append(r.readZoneSerial(ctx, &plan, zoneName)...)
 
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
@@ -151,10 +419,16 @@ func (r *recordResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// The record exists at this point, a timeout taints it
+	resp.Diagnostics.Append(r.waitForPropagation(ctx, plan, record.Name)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
 	// Get current state
 	var state recordResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -163,35 +437,99 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	recordReq := RecordsFindRequest{
-		BaseRequest: &BaseRequest{},
-		Filter: FilterOrChain{Filter: Filter{
-			Field: "RecordId",
-			Value: state.ID.ValueString(),
-		}},
-		Limit: 1,
-		Page:  1,
+	var returnedRecord DNSRecord
+	// Imported records only have an ID to go by
+	if r.client.options.RecordMatchStrategy == recordMatchNameTypeContent && !state.ZoneID.IsNull() {
+		record, diags := r.findStateRecord(ctx, state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		returnedRecord = *record
+	} else {
+		recordReq := RecordsFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter: FilterOrChain{Filter: Filter{
+				Field: "RecordId",
+				Value: state.ID.ValueString(),
+			}},
+			Limit: 1,
+			Page:  1,
+		}
+
+		// Get refreshed DNS record from hostingde
+		recordResp, err := r.client.listRecords(ctx, recordReq)
+		if errors.Is(err, errNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone",
+				"Could not read hosting.de DNS zone ID "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		returnedRecord = recordResp.Response.Data[0]
 	}
+	warnUnknownRecordTypes(ctx, []DNSRecord{returnedRecord})
 
-	// Get refreshed DNS record from hostingde
-	recordResp, err := r.client.listRecords(recordReq)
+	zoneDefaultTTL, err := r.zoneDefaultTTL(ctx, returnedRecord.ZoneID, state.TTL.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
-			"Could not read hosting.de DNS zone ID "+state.ID.ValueString()+": "+err.Error(),
+			"Could not read default TTL of hosting.de DNS zone ID "+returnedRecord.ZoneID+": "+err.Error(),
 		)
 		return
 	}
 
-	returnedRecord := recordResp.Response.Data[0]
+	// The zone is only needed for names configured relative to it, and for
+	// content containing the ${zone} placeholder
+	var zoneName string
+	name := returnedRecord.Name
+	needsZone := !state.Name.IsNull() && strings.TrimSuffix(state.Name.ValueString(), ".") != returnedRecord.Name
+	if needsZone || strings.Contains(state.Content.ValueString(), zonePlaceholder) {
+		zoneName, diags = lookupZoneName(ctx, r.client, returnedRecord.ZoneID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if !state.Name.IsNull() {
+		name = state.Name.ValueString()
+		if needsZone {
+			name = recordStateName(name, returnedRecord.Name, zoneName)
+		}
+	}
+
 	// Overwrite DNS record with refreshed state
 	state.ZoneID = types.StringValue(returnedRecord.ZoneID)
 	state.ID = types.StringValue(returnedRecord.ID)
-	state.Name = types.StringValue(returnedRecord.Name)
+	state.RecordID = types.StringValue(returnedRecord.ID)
+	state.Name = types.StringValue(name)
 	state.Type = types.StringValue(returnedRecord.Type)
-	state.Content = types.StringValue(returnedRecord.Content)
-	state.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	state.Priority = types.Int64Value(int64(returnedRecord.Priority))
+	content, priority := splitPriority(returnedRecord)
+	state.Content, state.ResolvedContent = recordTemplateStateContent(returnedRecord.Type, state.Content.ValueString(), content, zoneName)
+	state.TTL = recordStateTTL(returnedRecord.Type, state.TTL, returnedRecord.TTL, zoneDefaultTTL)
+	state.EffectiveTTL = types.Int64Value(int64(returnedRecord.TTL))
+	state.Priority = types.Int64Value(priority)
+	state.AddDate = recordDate(returnedRecord.AddDate)
+	state.LastChangeDate = recordDate(returnedRecord.LastChangeDate)
+
+	// Not stored in the API, use the default for imported records
+	if state.Upsert.IsNull() {
+		state.Upsert = types.BoolValue(false)
+	}
+	if state.CreateZoneIfMissing.IsNull() {
+		state.CreateZoneIfMissing = types.BoolValue(false)
+	}
+	if state.WaitForPropagation.IsNull() {
+		state.WaitForPropagation = types.BoolValue(false)
+	}
+	if state.ReadZoneSerial.IsNull() {
+		state.ReadZoneSerial = types.BoolValue(false)
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -203,32 +541,92 @@ func (r *recordResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Retrieve values from plan
-	var plan recordResourceModel
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan and prior state
+	var plan, state recordResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Generate API request body from plan
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	zoneDefaultTTL, err := r.zoneDefaultTTL(ctx, plan.ZoneID.ValueString(), plan.TTL.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read default TTL of hosting.de DNS zone ID "+plan.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, plan.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(warnAbsoluteRecordName(plan.Name.ValueString(), zoneName)...)
+	resp.Diagnostics.Append(checkSystemRecord(plan.Type.ValueString(), recordFQDN(plan.Name.ValueString(), zoneName), zoneName)...)
+	resp.Diagnostics.Append(checkApexCNAME(plan.Type.ValueString(), recordFQDN(plan.Name.ValueString(), zoneName), zoneName)...)
+	resp.Diagnostics.Append(checkRecordName(plan.Name.ValueString(), zoneName)...)
+	resp.Diagnostics.Append(validateRecordHostname(plan, zoneName)...)
+	resp.Diagnostics.Append(validateRecordContentLength(plan, zoneName)...)
+	resolvedContent := expandZonePlaceholder(plan.Content.ValueString(), zoneName)
+	resp.Diagnostics.Append(r.warnMissingGlue(ctx, plan.ZoneID.ValueString(), plan.Type.ValueString(), resolvedContent, zoneName)...)
+	// Strict warnings about the configuration fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only send the fields that changed, so server-managed fields are kept
 	record := DNSRecord{
-		Name:     plan.Name.ValueString(),
-		ID:       plan.ID.ValueString(),
-		ZoneID:   plan.ZoneID.ValueString(),
-		Type:     plan.Type.ValueString(),
-		Content:  plan.Content.ValueString(),
-		TTL:      int(plan.TTL.ValueInt64()),
-		Priority: int(plan.Priority.ValueInt64()),
+		Name: recordFQDN(plan.Name.ValueString(), zoneName),
+		ID:   plan.ID.ValueString(),
+		Type: plan.Type.ValueString(),
+		TTL:  requestTTL(plan.TTL.ValueInt64(), zoneDefaultTTL),
 	}
+	record = withPriority(record, normalizeContent(record.Type, resolvedContent), plan.Priority.ValueInt64())
 
-	recordReq := RecordsUpdateRequest{
-		BaseRequest:     &BaseRequest{},
-		ZoneConfigId:    plan.ZoneID.ValueString(),
-		RecordsToModify: []DNSRecord{record},
+	priorRecord := DNSRecord{
+		Name: recordFQDN(state.Name.ValueString(), zoneName),
+		Type: state.Type.ValueString(),
+		TTL:  int(state.EffectiveTTL.ValueInt64()),
+	}
+	priorRecord = withPriority(priorRecord, normalizeContent(priorRecord.Type, expandZonePlaceholder(state.Content.ValueString(), zoneName)), state.Priority.ValueInt64())
+
+	var fields RecordFields
+	if record.Name != priorRecord.Name {
+		fields.Name = &record.Name
+	}
+	if record.Content != priorRecord.Content {
+		fields.Content = &record.Content
+	}
+	if record.TTL != priorRecord.TTL {
+		fields.TTL = &record.TTL
+	}
+	if record.Priority != priorRecord.Priority {
+		fields.Priority = &record.Priority
 	}
 
-	recordResp, err := r.client.updateRecords(recordReq)
+	recordResp, err := r.client.patchRecord(ctx, record.ID, fields)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating records",
@@ -237,9 +635,10 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// The record is modified in place, so it keeps its ID
 	var returnedRecord DNSRecord
 	for _, r := range recordResp.Response.Records {
-		if r.Name == record.Name && r.Type == record.Type && r.Content == record.Content && r.TTL == record.TTL {
+		if r.ID == record.ID {
 			returnedRecord = r
 		}
 	}
@@ -247,21 +646,38 @@ func (r *recordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Overwrite DNS record with refreshed state
 	plan.ZoneID = types.StringValue(recordResp.Response.ZoneConfig.ID)
 	plan.ID = types.StringValue(returnedRecord.ID)
-	plan.Name = types.StringValue(returnedRecord.Name)
+	plan.RecordID = types.StringValue(returnedRecord.ID)
+	plan.Name = types.StringValue(recordStateName(plan.Name.ValueString(), returnedRecord.Name, zoneName))
 	plan.Type = types.StringValue(returnedRecord.Type)
-	plan.Content = types.StringValue(returnedRecord.Content)
-	plan.TTL = types.Int64Value(int64(returnedRecord.TTL))
-	plan.Priority = types.Int64Value(int64(returnedRecord.Priority))
+	content, priority := splitPriority(returnedRecord)
+	plan.Content, plan.ResolvedContent = recordTemplateStateContent(returnedRecord.Type, plan.Content.ValueString(), content, zoneName)
+	plan.TTL = recordStateTTL(returnedRecord.Type, plan.TTL, returnedRecord.TTL, zoneDefaultTTL)
+	plan.EffectiveTTL = types.Int64Value(int64(returnedRecord.TTL))
+	plan.Priority = types.Int64Value(priority)
+	plan.AddDate = recordDate(returnedRecord.AddDate)
+	plan.LastChangeDate = recordDate(returnedRecord.LastChangeDate)
+
+	resp.Diagnostics.Append(r.readZoneSerial(ctx, &plan, zoneName)...)
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(r.waitForPropagation(ctx, plan, record.Name)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from state
 	var state recordResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -270,13 +686,52 @@ func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Record already deleted", map[string]any{
+			"hostingde_record_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, state.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	record := DNSRecord{
 		ID:   state.ID.ValueString(),
-		Name: state.Name.ValueString(),
+		Name: recordFQDN(state.Name.ValueString(), zoneName),
 		Type: state.Type.ValueString(),
 	}
 
+	// Imported apex NS records belong to the zone, only forget them
+	if isSystemRecord(record, zoneName) {
+		resp.Diagnostics.AddWarning(
+			"Record not deleted",
+			"The "+record.Type+" record "+record.Name+" is managed by hosting.de for the zone itself and was kept. "+
+				"It was only removed from the Terraform state.",
+		)
+		return
+	}
+
+	// Strict warnings of the lookups fail before the record is deleted
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	recordReq := RecordsUpdateRequest{
 		BaseRequest:     &BaseRequest{},
 		ZoneConfigId:    state.ZoneID.ValueString(),
@@ -284,7 +739,7 @@ func (r *recordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 
 	// Delete existing record
-	_, err := r.client.updateRecords(recordReq)
+	_, err = r.client.updateRecords(ctx, recordReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Record",
@@ -303,43 +758,1023 @@ func (r *recordResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = req.ProviderData.(*Client)
 }
 
+// ImportState imports a record by its ID. Records that hosting.de manages for
+// the zone itself are refused, like they are on create.
 func (r *recordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	record, err := r.client.getRecord(ctx, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Importing hosting.de DNS record",
+			"Could not read hosting.de DNS record ID "+req.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, record.ZoneID)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isSystemRecord(*record, zoneName) {
+		resp.Diagnostics.AddError(
+			"Record managed by hosting.de",
+			"The "+record.Type+" record "+record.Name+" is managed by hosting.de for the zone itself "+
+				"and can't be imported into hostingde_record. Use hostingde_zone to manage the zone's SOA values and nameservers.",
+		)
+		return
+	}
+
 	// Retrieve import ID and save to id attribute
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func (r *recordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	// Retrieve values from config
-	var configData recordResourceModel
-	diags := req.Config.Get(ctx, &configData)
-	resp.Diagnostics.Append(diags...)
+// adoptRecord looks for an existing record with the same name, type and
+// content and updates its TTL and priority to the ones of the given record.
+// It returns nil if there is no such record, so a new one can be added.
+func (r *recordResource) adoptRecord(ctx context.Context, record DNSRecord) (*RecordsUpdateResponse, error) {
+	existing, err := r.client.findMatchingRecord(ctx, record)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, "Adopting existing record", map[string]any{
+		"hostingde_record_id": existing.ID,
+	})
+
+	// A zero TTL means the API chooses it, so the TTL of the record is kept
+	var fields RecordFields
+	if record.TTL != 0 && existing.TTL != record.TTL {
+		fields.TTL = &record.TTL
+	}
+	if existing.Priority != record.Priority {
+		fields.Priority = &record.Priority
+	}
+
+	// Nothing to change, answer like the API would
+	if fields == (RecordFields{}) {
+		return &RecordsUpdateResponse{Response: Zone{
+			Records:    []DNSRecord{*existing},
+			ZoneConfig: ZoneConfig{ID: existing.ZoneID},
+		}}, nil
+	}
+
+	return r.client.patchRecord(ctx, existing.ID, fields)
+}
+
+// zoneDefaultTTL returns the default TTL of the zone from its SOA values.
+// The zone is only queried if the record inherits the zone default, i.e.
+// the configured TTL is zero. Zero means the default isn't known, as the
+// zone has no SOA values.
+func (r *recordResource) zoneDefaultTTL(ctx context.Context, zoneConfigId string, ttl int64) (int, error) {
+	if ttl != 0 {
+		return 0, nil
+	}
+
+	zoneConfig, err := r.client.getZoneConfig(ctx, zoneConfigId)
+	if err != nil {
+		return 0, err
+	}
 
+	// Without SOA values the API falls back to its own default when the TTL is omitted
+	if zoneConfig.SOAValues == nil {
+		return 0, nil
+	}
+
+	return zoneConfig.SOAValues.TTL, nil
+}
+
+// requestTTL returns the TTL to send to the API. A configured TTL of zero
+// resolves to the zone default, any other value is passed through unchanged.
+func requestTTL(ttl int64, zoneDefaultTTL int) int {
+	if ttl == 0 {
+		return zoneDefaultTTL
+	}
+
+	return int(ttl)
+}
+
+// stateTTL returns the TTL to store in state. A configured TTL of zero is
+// kept as long as the record uses the zone default, so there is no drift.
+// If the zone default isn't known, the TTL the API applied is taken as the
+// default, the actual TTL is only available in effective_ttl then.
+func stateTTL(ttl int64, actualTTL int, zoneDefaultTTL int) int64 {
+	if ttl == 0 && (zoneDefaultTTL == 0 || actualTTL == zoneDefaultTTL) {
+		return 0
+	}
+
+	return int64(actualTTL)
+}
+
+// ModifyPlan applies the provider's default TTL for new records and explains
+// why a change of the record type replaces the record.
+func (r *recordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.checkExplicitTTL(ctx, req)...)
+	resp.Diagnostics.Append(r.planNewRecordDefaultTTL(ctx, req, resp)...)
+	resp.Diagnostics.Append(planTTLDuration(ctx, req, resp)...)
+	// The note on a replacement below only explains the plan, it is never
+	// an error
+	r.client.strictWarnings(&resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If Type is MX or SRV, return without warning.
-	if configData.Type.ValueString() == "MX" || configData.Type.ValueString() == "SRV" {
-		if configData.Priority.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("Priority"),
-				"Missing attribute",
-				"Setting priority is required for records of type MX or SRV. "+
-					"Please add a priority to the resource, for example priority = 0.",
-			)
-		}
+	// Nothing to explain on create
+	if req.State.Raw.IsNull() {
 		return
 	}
 
-	// If Priority is not configured, return without warning.
-	if configData.Priority.IsNull() || configData.Priority.IsUnknown() {
+	var plan, state recordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.AddAttributeError(
-		path.Root("Type"),
-		"Unexpected combination of attributes",
-		"Priority is only relevant for records of type MX or SRV. "+
-			"Please remove priority from the resource or change its type.",
+	if plan.Type.IsUnknown() || plan.Type.Equal(state.Type) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("type"),
+		"Record will be replaced",
+		"The hosting.de API can not change the type of a record, so the "+state.Type.ValueString()+" record "+
+			state.Name.ValueString()+" will be deleted and a new "+plan.Type.ValueString()+" record created. "+
+			"The name does not resolve between both steps. Unless the old and the new record conflict, "+
+			"like a CNAME and any other record of the same name, set create_before_destroy in the "+
+			"lifecycle block of the resource to create the new record first.",
+	)
+}
+
+// planTTLDuration plans the TTL in seconds of records configuring
+// ttl_duration instead of ttl.
+func planTTLDuration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var ttlDuration types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("ttl_duration"), &ttlDuration)...)
+	if diags.HasError() || ttlDuration.IsNull() || ttlDuration.IsUnknown() {
+		return diags
+	}
+
+	// Invalid durations are reported by ValidateConfig
+	ttl, err := parseTTLDuration(ttlDuration.ValueString())
+	if err != nil {
+		return diags
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), types.Int64Value(ttl))...)
+
+	return diags
+}
+
+// checkExplicitTTL returns an error diagnostic if the provider requires an
+// explicit TTL and the record configures neither ttl nor ttl_duration.
+func (r *recordResource) checkExplicitTTL(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.client == nil || !r.client.options.RequireExplicitTTL {
+		return diags
+	}
+
+	var config recordResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() || !config.TTL.IsNull() || !config.TTLDuration.IsNull() || config.Type.ValueString() == "ALIAS" {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("ttl"),
+		"Missing TTL",
+		"The provider is configured with require_explicit_ttl, so every record must set ttl or ttl_duration. "+
+			"Set ttl = 0 to make the record follow the default TTL of its zone.",
 	)
+
+	return diags
+}
+
+// planNewRecordDefaultTTL plans the provider's new_record_default_ttl for
+// records that don't configure a TTL. The default only seeds new records,
+// existing records keep the TTL from state.
+func (r *recordResource) planNewRecordDefaultTTL(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if r.client == nil || r.client.options.NewRecordDefaultTTL == nil {
+		return diags
+	}
+
+	var configTTL types.Int64
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &configTTL)...)
+	if diags.HasError() || !configTTL.IsNull() {
+		return diags
+	}
+
+	ttl := types.Int64Value(*r.client.options.NewRecordDefaultTTL)
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), ttl)...)
+
+	return diags
+}
+
+// findStateRecord returns the record matching the name, type and content in
+// the state, for the name_type_content record_match_strategy. The ID in the
+// state is ignored, it is replaced with the ID of the matching record.
+func (r *recordResource) findStateRecord(ctx context.Context, state recordResourceModel) (*DNSRecord, diag.Diagnostics) {
+	zoneName, diags := lookupZoneName(ctx, r.client, state.ZoneID.ValueString())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	record := DNSRecord{
+		ZoneID: state.ZoneID.ValueString(),
+		Name:   recordFQDN(state.Name.ValueString(), zoneName),
+		Type:   state.Type.ValueString(),
+	}
+	record = withPriority(record, normalizeContent(record.Type, expandZonePlaceholder(state.Content.ValueString(), zoneName)), state.Priority.ValueInt64())
+
+	match, err := r.client.findMatchingRecord(ctx, record)
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read records of hosting.de DNS zone ID "+record.ZoneID+": "+err.Error(),
+		)
+		return nil, diags
+	}
+	if match == nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone",
+			"No record "+record.Name+" of type "+record.Type+" with content "+record.Content+" found in hosting.de DNS zone ID "+record.ZoneID+
+				", as required by record_match_strategy = \""+recordMatchNameTypeContent+"\".",
+		)
+		return nil, diags
+	}
+
+	if match.ID != state.ID.ValueString() {
+		tflog.Info(ctx, "Record matched by name, type and content", map[string]any{
+			"hostingde_record_id":       match.ID,
+			"hostingde_state_record_id": state.ID.ValueString(),
+		})
+	}
+
+	return match, diags
+}
+
+// readZoneSerial sets zone_serial to the serial of the zone after a change,
+// if the resource is configured to read it. The record was already changed,
+// so a failure is only a warning.
+func (r *recordResource) readZoneSerial(ctx context.Context, model *recordResourceModel, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ZoneSerial = types.Int64Null()
+	if !model.ReadZoneSerial.ValueBool() {
+		return diags
+	}
+
+	serial, err := r.client.zoneSerial(ctx, model.ZoneID.ValueString(), zoneName)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("zone_serial"),
+			"Could not read zone serial",
+			"The record was changed, but the serial of zone "+zoneName+" could not be read: "+err.Error(),
+		)
+		return diags
+	}
+
+	tflog.Debug(ctx, "Read zone serial after record change", map[string]any{
+		"hostingde_zone_config_id": model.ZoneID.ValueString(),
+		"hostingde_zone_serial":    serial,
+	})
+	model.ZoneSerial = types.Int64Value(serial)
+
+	return diags
+}
+
+// waitForPropagation waits until the zone's nameservers serve the record, if
+// the resource is configured to wait for propagation.
+func (r *recordResource) waitForPropagation(ctx context.Context, model recordResourceModel, recordName string) diag.Diagnostics {
+	if !model.WaitForPropagation.ValueBool() {
+		return nil
+	}
+
+	return waitForZoneRecordPropagation(ctx, r.client, model.ZoneID.ValueString(), recordName, model.Type.ValueString(), model.ResolvedContent.ValueString(), model.PropagationTimeout)
+}
+
+// resolveZoneName sets the zone ID of a new record configuring zone_name,
+// creating the zone first if create_zone_if_missing is set.
+func (r *recordResource) resolveZoneName(ctx context.Context, plan *recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	zoneName := strings.TrimSuffix(plan.ZoneName.ValueString(), ".")
+	zoneConfig, err := r.client.getZoneConfigByName(ctx, zoneName)
+	if errors.Is(err, errNotFound) && plan.CreateZoneIfMissing.ValueBool() {
+		tflog.Info(ctx, "Zone of the record is missing, creating it", map[string]any{
+			"hostingde_zone_name": zoneName,
+		})
+		zoneConfig, err = r.client.createZoneWithDefaults(ctx, zoneName)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("create_zone_if_missing"),
+				"Error creating zone",
+				"Could not create zone "+zoneName+" for the record, unexpected error: "+err.Error(),
+			)
+			return diags
+		}
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("zone_name"),
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+zoneName+": "+err.Error(),
+		)
+		return diags
+	}
+
+	plan.ZoneID = types.StringValue(zoneConfig.ID)
+
+	return diags
+}
+
+// lookupZoneName returns the name of the zone, which record names are relative to.
+func lookupZoneName(ctx context.Context, client *Client, zoneConfigId string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zoneConfig, err := client.getZoneConfig(ctx, zoneConfigId)
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone ID "+zoneConfigId+": "+err.Error(),
+		)
+		return "", diags
+	}
+
+	return zoneConfig.Name, diags
+}
+
+// recordFQDN returns the fully qualified record name expected by the API.
+// Names are relative to the zone, "@" refers to the zone apex. A name that
+// already ends with the zone name is taken as fully qualified.
+func recordFQDN(name string, zoneName string) string {
+	name = strings.TrimSuffix(name, ".")
+	switch {
+	case name == "@" || name == "":
+		return zoneName
+	case isAbsoluteRecordName(name, zoneName):
+		return name
+	default:
+		return name + "." + zoneName
+	}
+}
+
+// isAbsoluteRecordName reports whether the name includes the zone name.
+func isAbsoluteRecordName(name string, zoneName string) bool {
+	name = strings.TrimSuffix(name, ".")
+	return name == zoneName || strings.HasSuffix(name, "."+zoneName)
+}
+
+// relativeRecordName returns the name of a record relative to its zone.
+func relativeRecordName(fqdn string, zoneName string) string {
+	if fqdn == zoneName {
+		return "@"
+	}
+
+	return strings.TrimSuffix(fqdn, "."+zoneName)
+}
+
+// recordStateName returns the record name to store in state. A name that
+// still refers to the record is kept as configured, relative or fully
+// qualified, so switching between the forms causes no drift. Names changed
+// outside of Terraform are stored in the form of the configured name.
+func recordStateName(configuredName string, fqdn string, zoneName string) string {
+	switch {
+	case configuredName == "":
+		return fqdn
+	case recordFQDN(configuredName, zoneName) == fqdn:
+		return configuredName
+	case isAbsoluteRecordName(configuredName, zoneName):
+		return fqdn
+	default:
+		return relativeRecordName(fqdn, zoneName)
+	}
+}
+
+// warnAbsoluteRecordName warns if a record name includes the zone name.
+func warnAbsoluteRecordName(name string, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if isAbsoluteRecordName(name, zoneName) {
+		relative := relativeRecordName(strings.TrimSuffix(name, "."), zoneName)
+		diags.AddAttributeWarning(
+			path.Root("name"),
+			"Record name includes the zone name",
+			"The name "+name+" ends with the zone name "+zoneName+" and is used as "+relative+". "+
+				"Record names are relative to the zone, set name = \""+relative+"\" to silence this warning. "+
+				"Both forms refer to the same record, changing the name between them doesn't recreate it.",
+		)
+	}
+
+	return diags
+}
+
+// checkSystemRecord rejects records that hosting.de manages for the zone
+// itself. NS records at the apex list the zone's own nameservers, managing
+// them as separate records would break the zone. NS records below the apex
+// delegate a subzone and are accepted.
+func checkSystemRecord(recordType string, fqdn string, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if isSystemRecord(DNSRecord{Type: recordType, Name: fqdn}, zoneName) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Record managed by hosting.de",
+			"The "+recordType+" record at the apex of the zone "+zoneName+" is managed by hosting.de for the zone itself "+
+				"and can't be managed with hostingde_record. Use nameserver_set of hostingde_zone to change the apex NS records, "+
+				"or set a name below the apex to delegate a subzone.",
+		)
+	}
+
+	return diags
+}
+
+// checkApexCNAME rejects CNAME records at the apex of the zone. A CNAME can't
+// coexist with other records, and the apex always has the SOA and NS records.
+// Names spelling out the zone name are only recognized once the zone name
+// was looked up on apply.
+func checkApexCNAME(recordType string, fqdn string, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if recordType == "CNAME" && fqdn == zoneName {
+		diags.AddAttributeError(
+			path.Root("type"),
+			"CNAME record at the zone apex",
+			"The apex of a zone can't have a CNAME record, as a CNAME excludes all other records at its name "+
+				"and the apex always has the SOA and NS records of the zone. "+
+				"Use type = \"ALIAS\" instead, which hosting.de resolves to the A and AAAA records of the target.",
+		)
+	}
+
+	return diags
+}
+
+// checkRecordName checks the length of the labels of the record name, and of
+// the full name within the zone if the zone name is known.
+func checkRecordName(name string, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if err := checkRecordNameLength(recordFQDN(name, zoneName)); err != nil {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Invalid record name",
+			"The record name "+name+" is not a valid DNS name: "+err.Error()+". "+
+				"Labels are limited to 63 bytes and names including the zone to 255 bytes in DNS messages.",
+		)
+	}
+
+	return diags
+}
+
+// inZoneNameserver returns the nameserver host of NS record content and
+// whether it lies within the zone. Resolvers can only reach such a
+// nameserver through glue, the A and AAAA records of the host in the zone.
+func inZoneNameserver(content string, zoneName string) (string, bool) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	host := strings.TrimSuffix(fields[0], ".")
+	return host, isAbsoluteRecordName(host, zoneName)
+}
+
+// warnMissingGlue warns if an NS record points to a nameserver within the
+// zone that has neither an A nor an AAAA record in the zone.
+func (r *recordResource) warnMissingGlue(ctx context.Context, zoneConfigId string, recordType string, content string, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if recordType != "NS" {
+		return diags
+	}
+
+	host, ok := inZoneNameserver(normalizeContent(recordType, content), zoneName)
+	if !ok {
+		return diags
+	}
+
+	for _, glueType := range []string{"A", "AAAA"} {
+		records, err := r.client.listRecordsByName(ctx, zoneConfigId, host, glueType)
+		if err != nil {
+			diags.AddError(
+				"Error Reading hosting.de DNS zone records",
+				"Could not read glue records of hosting.de DNS zone ID "+zoneConfigId+": "+err.Error(),
+			)
+			return diags
+		}
+		if len(records) > 0 {
+			return diags
+		}
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("content"),
+		"Missing glue record",
+		"The nameserver "+host+" lies within the zone "+zoneName+", so resolvers can only reach it through an A or AAAA glue record, "+
+			"but the zone has none for "+host+". Add a hostingde_record of type A or AAAA named "+relativeRecordName(host, zoneName)+". "+
+			"If the glue record is created in the same apply, add it to depends_on of this record to silence the warning.",
+	)
+
+	return diags
+}
+
+// recordStateTTL returns the ttl to store in state. Record types with a TTL
+// controlled by hosting.de keep the TTL from the plan or prior state, so the
+// value chosen by the API doesn't show up as drift.
+func recordStateTTL(recordType string, ttl types.Int64, actualTTL int, zoneDefaultTTL int) types.Int64 {
+	if slices.Contains(serverTTLRecordTypes, recordType) {
+		if ttl.IsNull() {
+			return types.Int64Value(defaultRecordTTL)
+		}
+		return ttl
+	}
+
+	return types.Int64Value(stateTTL(ttl.ValueInt64(), actualTTL, zoneDefaultTTL))
+}
+
+func (r *recordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	// Retrieve values from config
+	var configData recordResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The checks don't return early, so all problems of the configuration
+	// are reported at once.
+	resp.Diagnostics.Append(validateRecordPriority(configData)...)
+	if configData.CreateZoneIfMissing.ValueBool() && configData.ZoneName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("create_zone_if_missing"),
+			"Missing attribute",
+			"create_zone_if_missing requires the zone to be given by zone_name instead of zone_id, "+
+				"as the provider needs the name to create the zone.",
+		)
+	}
+	// Without the zone name only "@" is known to be the apex
+	if !configData.Type.IsUnknown() && !configData.Name.IsUnknown() {
+		resp.Diagnostics.Append(checkApexCNAME(configData.Type.ValueString(), recordFQDN(configData.Name.ValueString(), ""), "")...)
+	}
+	// The labels are known without the zone, the full length only with it
+	if !configData.Name.IsUnknown() {
+		resp.Diagnostics.Append(checkRecordName(configData.Name.ValueString(), "")...)
+	}
+	resp.Diagnostics.Append(validateRecordContent(configData)...)
+	resp.Diagnostics.Append(validateRecordHostname(configData, "")...)
+	resp.Diagnostics.Append(validateRecordTarget(configData)...)
+	resp.Diagnostics.Append(validateRecordContentLength(configData, "")...)
+	resp.Diagnostics.Append(validateRecordTTL(configData)...)
+	resp.Diagnostics.Append(validateRecordTTLDuration(configData)...)
+	resp.Diagnostics.Append(validatePropagationTimeout(configData.PropagationTimeout)...)
+}
+
+// warnUnknownRecordTypes logs a warning listing the record types unknown to
+// the provider. Such records are not rejected, as hosting.de may add new
+// record types at any time.
+func warnUnknownRecordTypes(ctx context.Context, records []DNSRecord) {
+	unknown := map[string]bool{}
+	for _, record := range records {
+		if !slices.Contains(knownRecordTypes, record.Type) {
+			unknown[record.Type] = true
+		}
+	}
+
+	if len(unknown) == 0 {
+		return
+	}
+
+	var unknownTypes []string
+	for recordType := range unknown {
+		unknownTypes = append(unknownTypes, recordType)
+	}
+	sort.Strings(unknownTypes)
+
+	tflog.Warn(ctx, "Read records of types not handled by the provider, passing through their raw content", map[string]any{
+		"hostingde_record_types": unknownTypes,
+	})
+}
+
+// validateRecordPriority checks that priority is set exactly for the record
+// types that use it.
+func validateRecordPriority(configData recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() {
+		return diags
+	}
+
+	var priorityTypes []string
+	for recordType := range priorityRecordTypes {
+		priorityTypes = append(priorityTypes, recordType)
+	}
+	sort.Strings(priorityTypes)
+
+	if _, ok := priorityRecordTypes[configData.Type.ValueString()]; ok {
+		if configData.Priority.IsNull() {
+			diags.AddAttributeError(
+				path.Root("priority"),
+				"Missing attribute",
+				"Setting priority is required for records of type "+strings.Join(priorityTypes, ", ")+". "+
+					"Please add a priority to the resource, for example priority = 0.",
+			)
+		}
+		return diags
+	}
+
+	// If Priority is not configured, return without warning.
+	if configData.Priority.IsNull() || configData.Priority.IsUnknown() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("priority"),
+		"Unexpected combination of attributes",
+		"Priority is only relevant for records of type "+strings.Join(priorityTypes, ", ")+". "+
+			"Please remove priority from the resource or change its type.",
+	)
+
+	return diags
+}
+
+// withPriority sets the content and priority of a record for the API,
+// depending on how its type transports the priority.
+func withPriority(record DNSRecord, content string, priority int64) DNSRecord {
+	switch priorityRecordTypes[record.Type] {
+	case priorityField:
+		record.Content = content
+		record.Priority = int(priority)
+	case priorityContent:
+		record.Content = strconv.FormatInt(priority, 10) + " " + content
+	default:
+		record.Content = content
+	}
+
+	return record
+}
+
+// recordDate returns a date of a record for the state, or null if the API
+// didn't return it.
+func recordDate(date string) types.String {
+	if date == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(date)
+}
+
+// zonePlaceholder is replaced with the name of the zone in record content.
+const zonePlaceholder = "${zone}"
+
+// zonePlaceholderEscape keeps a literal placeholder. It is replaced first, as
+// the replacer tries the patterns in order at each position.
+const zonePlaceholderEscape = "$" + zonePlaceholder
+
+// expandZonePlaceholder replaces the ${zone} placeholder in the content with
+// the zone name. $${zone} is kept as a literal ${zone}.
+func expandZonePlaceholder(content string, zoneName string) string {
+	if !strings.Contains(content, zonePlaceholder) {
+		return content
+	}
+
+	return strings.NewReplacer(zonePlaceholderEscape, zonePlaceholder, zonePlaceholder, zoneName).Replace(content)
+}
+
+// recordTemplateStateContent returns the content and resolved content to
+// store in state. The configured content is kept while the record still
+// matches it, otherwise both are set to the content from the API.
+func recordTemplateStateContent(recordType string, configuredContent string, content string, zoneName string) (types.String, types.String) {
+	resolved := expandZonePlaceholder(configuredContent, zoneName)
+	stateContent := recordStateContent(recordType, resolved, content)
+	if stateContent == resolved {
+		return types.StringValue(configuredContent), types.StringValue(resolved)
+	}
+
+	return types.StringValue(stateContent), types.StringValue(stateContent)
+}
+
+// splitPriority returns the content and priority of a record from the API,
+// the inverse of withPriority.
+func splitPriority(record DNSRecord) (string, int64) {
+	if priorityRecordTypes[record.Type] == priorityContent {
+		fields := strings.SplitN(record.Content, " ", 2)
+		if priority, err := strconv.ParseInt(fields[0], 10, 64); err == nil && len(fields) == 2 {
+			return fields[1], priority
+		}
+	}
+
+	return record.Content, int64(record.Priority)
+}
+
+// parseTTLDuration returns the TTL in seconds of a duration like "1h",
+// checked against the same limits as ttl.
+func parseTTLDuration(value string) (int64, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration%time.Second != 0 {
+		return 0, fmt.Errorf("%s is not a whole number of seconds", value)
+	}
+
+	ttl := int64(duration / time.Second)
+	if ttl != 0 && (ttl < 60 || ttl > 31556926) {
+		return 0, fmt.Errorf("%s is %d seconds, the TTL must be 0 or between 60 and 31556926 seconds", value, ttl)
+	}
+
+	return ttl, nil
+}
+
+// validateRecordTTLDuration checks that ttl_duration is a valid TTL, and
+// that it isn't combined with ttl or set for records with a TTL controlled by
+// hosting.de.
+func validateRecordTTLDuration(configData recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.TTLDuration.IsNull() || configData.TTLDuration.IsUnknown() {
+		return diags
+	}
+
+	if !configData.TTL.IsNull() {
+		diags.AddAttributeError(
+			path.Root("ttl_duration"),
+			"Conflicting attributes",
+			"Only one of ttl and ttl_duration can be set. Please remove one of them from the resource.",
+		)
+	}
+
+	if !configData.Type.IsUnknown() && slices.Contains(serverTTLRecordTypes, configData.Type.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("ttl_duration"),
+			"Unexpected combination of attributes",
+			"The TTL of records of type "+strings.Join(serverTTLRecordTypes, ", ")+" is controlled by hosting.de, "+
+				"so a configured TTL would never apply. Please remove ttl_duration from the resource, "+
+				"the TTL applied by hosting.de is available in effective_ttl.",
+		)
+	}
+
+	if _, err := parseTTLDuration(configData.TTLDuration.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("ttl_duration"),
+			"Invalid TTL duration",
+			"The ttl_duration value must be a duration like \"1h\" or \"300s\": "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// validateRecordContent checks the content against the format required by
+// the record type.
+func validateRecordContent(configData recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.Content.IsUnknown() {
+		return diags
+	}
+
+	// The zone isn't known yet, any name stands in for it
+	content := expandZonePlaceholder(configData.Content.ValueString(), "example.com")
+
+	switch configData.Type.ValueString() {
+	case "A":
+		if ip := net.ParseIP(content); ip == nil || ip.To4() == nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid record content",
+				"Records of type A require an IPv4 address as content, got: "+content,
+			)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(content); ip == nil || ip.To4() != nil {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid record content",
+				"Records of type AAAA require an IPv6 address as content, got: "+content,
+			)
+		}
+	}
+
+	return diags
+}
+
+// maxRdataLength is the maximum length of the data of a record in the wire
+// format, see RFC 1035 section 3.2.1.
+const maxRdataLength = 65535
+
+// validateRecordContentLength checks the content of TXT records against the
+// length limits of the wire format, which the API only rejects with an
+// unspecific error. Host names are checked by validateRecordHostname. Content
+// with the ${zone} placeholder is only checked once the zone name is known.
+func validateRecordContentLength(configData recordResourceModel, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.Content.IsUnknown() || configData.Type.ValueString() != "TXT" {
+		return diags
+	}
+	if zoneName == "" && strings.Contains(configData.Content.ValueString(), zonePlaceholder) {
+		return diags
+	}
+
+	content := expandZonePlaceholder(configData.Content.ValueString(), zoneName)
+	lengths := txtStringLengths(content)
+
+	rdataLength := 0
+	for i, length := range lengths {
+		if length > maxTXTStringLength {
+			diags.AddAttributeError(
+				path.Root("content"),
+				"Invalid record content",
+				"String "+strconv.Itoa(i+1)+" of the TXT record is "+strconv.Itoa(length)+" bytes long, the maximum is "+
+					strconv.Itoa(maxTXTStringLength)+". Split the value into several quoted strings.",
+			)
+		}
+		// Each string is preceded by its length
+		rdataLength += 1 + length
+	}
+
+	if rdataLength > maxRdataLength {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Invalid record content",
+			"The TXT record is "+strconv.Itoa(rdataLength)+" bytes long in DNS messages, the maximum is "+strconv.Itoa(maxRdataLength)+".",
+		)
+	}
+
+	return diags
+}
+
+// txtStringLengths returns the lengths in bytes of the strings of the
+// content of a TXT record. Content consisting of quoted strings is split at
+// the quotes, with escape sequences counting as one byte. Any other content
+// is split into strings of at most 255 bytes, like the API does.
+func txtStringLengths(content string) []int {
+	txtStrings, ok := quotedTXTStrings(content)
+	if !ok {
+		return unquotedTXTStringLengths(content)
+	}
+	if len(txtStrings) == 0 {
+		return []int{0}
+	}
+
+	lengths := make([]int, 0, len(txtStrings))
+	for _, txtString := range txtStrings {
+		lengths = append(lengths, len(txtString))
+	}
+
+	return lengths
+}
+
+// quotedTXTStrings returns the strings of TXT content consisting of quoted
+// strings, with escape sequences decoded. It returns false for any other
+// content, which the API takes as a single unquoted value.
+func quotedTXTStrings(content string) ([]string, bool) {
+	txtStrings := []string{}
+
+	rest := strings.TrimSpace(content)
+	for rest != "" {
+		if rest[0] != '"' {
+			return nil, false
+		}
+
+		var txtString strings.Builder
+		i := 1
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] != '\\' {
+				txtString.WriteByte(rest[i])
+				continue
+			}
+			// \DDD is a byte given by its decimal value, anything else is
+			// the escaped character itself
+			if i+3 < len(rest) && isDigits(rest[i+1:i+4]) {
+				value, _ := strconv.Atoi(rest[i+1 : i+4])
+				txtString.WriteByte(byte(value))
+				i += 3
+			} else if i+1 < len(rest) {
+				txtString.WriteByte(rest[i+1])
+				i++
+			}
+		}
+		if i >= len(rest) {
+			return nil, false
+		}
+
+		txtStrings = append(txtStrings, txtString.String())
+		rest = strings.TrimSpace(rest[i+1:])
+	}
+
+	return txtStrings, true
+}
+
+// txtValue returns the data of TXT content as resolvers see it, with the
+// quoted strings decoded and joined. Other content is returned trimmed.
+func txtValue(content string) string {
+	txtStrings, ok := quotedTXTStrings(content)
+	if !ok {
+		return strings.TrimSpace(content)
+	}
+
+	return strings.Join(txtStrings, "")
+}
+
+func unquotedTXTStringLengths(content string) []int {
+	var lengths []int
+	for len(content) > maxTXTStringLength {
+		lengths = append(lengths, maxTXTStringLength)
+		content = content[maxTXTStringLength:]
+	}
+
+	return append(lengths, len(content))
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateRecordHostname checks the host name in the content of record types
+// pointing to another host, after converting it to its ASCII form. As the
+// zone name counts towards the length of the host name, content with the
+// ${zone} placeholder is only checked once the zone name is known.
+func validateRecordHostname(configData recordResourceModel, zoneName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.Content.IsUnknown() {
+		return diags
+	}
+	if zoneName == "" && strings.Contains(configData.Content.ValueString(), zonePlaceholder) {
+		return diags
+	}
+
+	content := expandZonePlaceholder(configData.Content.ValueString(), zoneName)
+	if _, err := normalizeHostnameContent(configData.Type.ValueString(), content); err != nil {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Invalid record content",
+			"Records of type "+configData.Type.ValueString()+" require a valid host name in the content: "+err.Error(),
+		)
+	}
+
+	return diags
+}
+
+// validateRecordTarget checks that the target of CNAME, MX, NS and SRV
+// records is not an IP address, which these types don't allow.
+func validateRecordTarget(configData recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.Content.IsUnknown() {
+		return diags
+	}
+
+	if ip := targetIPAddress(configData.Type.ValueString(), configData.Content.ValueString()); ip != "" {
+		diags.AddAttributeError(
+			path.Root("content"),
+			"Invalid record content",
+			"Records of type "+configData.Type.ValueString()+" require a host name as target, got the IP address "+ip+". "+
+				"Please point the record to a host name with an A or AAAA record for the address instead.",
+		)
+	}
+
+	return diags
+}
+
+// validateRecordTTL checks that no TTL is configured for record types whose
+// TTL is controlled by hosting.de.
+func validateRecordTTL(configData recordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.TTL.IsNull() {
+		return diags
+	}
+
+	if slices.Contains(serverTTLRecordTypes, configData.Type.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("ttl"),
+			"Unexpected combination of attributes",
+			"The TTL of records of type "+strings.Join(serverTTLRecordTypes, ", ")+" is controlled by hosting.de, "+
+				"so a configured TTL would never apply. Please remove ttl from the resource, "+
+				"the TTL applied by hosting.de is available in effective_ttl.",
+		)
+	}
+
+	return diags
 }
@@ -1,9 +1,21 @@
 package hostingde
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccRecordResource(t *testing.T) {
@@ -35,6 +47,86 @@ resource "hostingde_record" "test" {
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hostingde_record.test", "id"),
 					resource.TestCheckResourceAttrSet("hostingde_record.test", "zone_id"),
+					resource.TestCheckResourceAttrPair("hostingde_record.test", "record_id", "hostingde_record.test", "id"),
+				),
+			},
+			// Update content in place testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "test.example2.test"
+  type = "CNAME"
+  content = "www1.example.com"
+}
+`,
+				// Content changes must modify the record in place instead of replacing it
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hostingde_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify content attribute.
+					resource.TestCheckResourceAttr("hostingde_record.test", "content", "www1.example.com"),
+				),
+			},
+			// Relative name testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "test"
+  type = "CNAME"
+  content = "www1.example.com"
+}
+`,
+				// Both forms of the name refer to the same record
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hostingde_record.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify name attribute keeps the configured form.
+					resource.TestCheckResourceAttr("hostingde_record.test", "name", "test"),
+					resource.TestCheckResourceAttrPair("hostingde_record.test", "record_id", "hostingde_record.test", "id"),
+				),
+			},
+			// Change type testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "test.example2.test"
+  type = "A"
+  content = "192.0.2.1"
+}
+`,
+				// The CNAME conflicts with the new record, so it is deleted first
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hostingde_record.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify type attribute.
+					resource.TestCheckResourceAttr("hostingde_record.test", "type", "A"),
 				),
 			},
 			// Create and read MX testing
@@ -119,3 +211,1093 @@ resource "hostingde_record" "test_mx" {
 		},
 	})
 }
+
+func TestRecordTTL(t *testing.T) {
+	// Explicit TTLs are passed through unchanged
+	if ttl := requestTTL(300, 3600); ttl != 300 {
+		t.Errorf("requestTTL(300, 3600) = %d, want 300", ttl)
+	}
+	if ttl := stateTTL(300, 300, 3600); ttl != 300 {
+		t.Errorf("stateTTL(300, 300, 3600) = %d, want 300", ttl)
+	}
+
+	// A TTL of zero resolves to the zone default and stays zero in state
+	if ttl := requestTTL(0, 3600); ttl != 3600 {
+		t.Errorf("requestTTL(0, 3600) = %d, want 3600", ttl)
+	}
+	if ttl := stateTTL(0, 3600, 3600); ttl != 0 {
+		t.Errorf("stateTTL(0, 3600, 3600) = %d, want 0", ttl)
+	}
+
+	// A record that no longer uses the zone default shows up as drift
+	if ttl := stateTTL(0, 300, 3600); ttl != 300 {
+		t.Errorf("stateTTL(0, 300, 3600) = %d, want 300", ttl)
+	}
+
+	// Without a known zone default, the TTL applied by the API is the default
+	if ttl := stateTTL(0, 3600, 0); ttl != 0 {
+		t.Errorf("stateTTL(0, 3600, 0) = %d, want 0", ttl)
+	}
+}
+
+func TestRecordResourceCreateZoneWithoutSOAValues(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{{ID: "10", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600}}
+			return updateResponse
+		},
+	})
+
+	// The API applies its own default, the planned ttl of 0 must be kept
+	state, diags := testCreateResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id":                tftypes.NewValue(tftypes.String, "1"),
+		"name":                   tftypes.NewValue(tftypes.String, "www"),
+		"type":                   tftypes.NewValue(tftypes.String, "A"),
+		"content":                tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"ttl":                    tftypes.NewValue(tftypes.Number, 0),
+		"create_zone_if_missing": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if diags.HasError() {
+		t.Fatalf("creating the record returned errors: %v", diags)
+	}
+
+	var ttl, effectiveTTL types.Int64
+	state.GetAttribute(context.Background(), path.Root("ttl"), &ttl)
+	state.GetAttribute(context.Background(), path.Root("effective_ttl"), &effectiveTTL)
+	if ttl.ValueInt64() != 0 || effectiveTTL.ValueInt64() != 3600 {
+		t.Errorf("got ttl %s and effective_ttl %s, want 0 and 3600", ttl, effectiveTTL)
+	}
+}
+
+func TestRecordResourceCreateTXTRequoted(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		// The API returns the content quoted and split differently
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{{ID: "10", Name: "example.test", Type: "TXT", Content: `"v=spf1 " "-all"`, TTL: 3600}}
+			return updateResponse
+		},
+	})
+
+	state, diags := testCreateResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id":                tftypes.NewValue(tftypes.String, "1"),
+		"name":                   tftypes.NewValue(tftypes.String, "@"),
+		"type":                   tftypes.NewValue(tftypes.String, "TXT"),
+		"content":                tftypes.NewValue(tftypes.String, `"v=spf1 -all"`),
+		"ttl":                    tftypes.NewValue(tftypes.Number, 3600),
+		"create_zone_if_missing": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if diags.HasError() {
+		t.Fatalf("creating the record returned errors: %v", diags)
+	}
+
+	var id, content types.String
+	state.GetAttribute(context.Background(), path.Root("id"), &id)
+	state.GetAttribute(context.Background(), path.Root("content"), &content)
+	if id.ValueString() != "10" || content.ValueString() != `"v=spf1 -all"` {
+		t.Errorf("got id %s and content %s, want 10 and the configured content", id, content)
+	}
+}
+
+func TestRecordResourceValidateConfigMultipleErrors(t *testing.T) {
+	diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+		"zone_id":  tftypes.NewValue(tftypes.String, "1"),
+		"name":     tftypes.NewValue(tftypes.String, "www.example.test"),
+		"type":     tftypes.NewValue(tftypes.String, "A"),
+		"content":  tftypes.NewValue(tftypes.String, "not-an-ip"),
+		"ttl":      tftypes.NewValue(tftypes.Number, 5),
+		"priority": tftypes.NewValue(tftypes.Number, 10),
+	})
+
+	// Bad IP, unexpected priority and invalid TTL are reported in one pass
+	wantErrors := map[string]bool{
+		"content":  false,
+		"priority": false,
+		"ttl":      false,
+	}
+	for _, d := range diags {
+		if d.Severity != tfprotov6.DiagnosticSeverityError || d.Attribute == nil {
+			continue
+		}
+		if name, ok := d.Attribute.Steps()[0].(tftypes.AttributeName); ok {
+			wantErrors[string(name)] = true
+		}
+	}
+
+	for name, found := range wantErrors {
+		if !found {
+			t.Errorf("expected an error for attribute %s, got diagnostics: %v", name, diags)
+		}
+	}
+}
+
+func TestRecordPriority(t *testing.T) {
+	for _, tc := range []struct {
+		recordType    string
+		content       string
+		priority      int64
+		apiContent    string
+		apiPriority   int
+		statePriority int64
+	}{
+		{recordType: "MX", content: "mail.example.test", priority: 10, apiContent: "mail.example.test", apiPriority: 10, statePriority: 10},
+		{recordType: "SRV", content: "5 5060 sip.example.test", priority: 20, apiContent: "5 5060 sip.example.test", apiPriority: 20, statePriority: 20},
+		{recordType: "URI", content: "1 \"https://example.test/\"", priority: 10, apiContent: "10 1 \"https://example.test/\"", statePriority: 10},
+		{recordType: "NAPTR", content: "10 \"S\" \"SIP+D2U\" \"\" _sip._udp.example.test.", priority: 100, apiContent: "100 10 \"S\" \"SIP+D2U\" \"\" _sip._udp.example.test.", statePriority: 100},
+		{recordType: "CNAME", content: "www.example.test", apiContent: "www.example.test"},
+	} {
+		record := withPriority(DNSRecord{Type: tc.recordType}, tc.content, tc.priority)
+		if record.Content != tc.apiContent || record.Priority != tc.apiPriority {
+			t.Errorf("%s: withPriority = (%q, %d), want (%q, %d)", tc.recordType, record.Content, record.Priority, tc.apiContent, tc.apiPriority)
+		}
+
+		// Reading the record back yields the configured values
+		content, priority := splitPriority(record)
+		if content != tc.content || priority != tc.statePriority {
+			t.Errorf("%s: splitPriority = (%q, %d), want (%q, %d)", tc.recordType, content, priority, tc.content, tc.statePriority)
+		}
+	}
+}
+
+func TestRecordServerControlledTTL(t *testing.T) {
+	values := map[string]tftypes.Value{
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "example.test"),
+		"type":    tftypes.NewValue(tftypes.String, "ALIAS"),
+		"content": tftypes.NewValue(tftypes.String, "target.example.test"),
+	}
+	if diags := testValidateResourceConfig(t, NewRecordResource(), values); len(diags) != 0 {
+		t.Errorf("expected no diagnostics without ttl, got: %v", diags)
+	}
+
+	values["ttl"] = tftypes.NewValue(tftypes.Number, 300)
+	diags := testValidateResourceConfig(t, NewRecordResource(), values)
+	if len(diags) != 1 || diags[0].Attribute == nil || diags[0].Attribute.String() != `AttributeName("ttl")` {
+		t.Errorf("expected an error for attribute ttl, got diagnostics: %v", diags)
+	}
+
+	// The TTL chosen by hosting.de doesn't show up as drift
+	if ttl := recordStateTTL("ALIAS", types.Int64Value(3600), 60, 3600); ttl.ValueInt64() != 3600 {
+		t.Errorf("recordStateTTL for ALIAS = %d, want 3600", ttl.ValueInt64())
+	}
+	if ttl := recordStateTTL("A", types.Int64Value(3600), 60, 3600); ttl.ValueInt64() != 60 {
+		t.Errorf("recordStateTTL for A = %d, want 60", ttl.ValueInt64())
+	}
+}
+
+func TestAccRecordResourceNewRecordDefaultTTL(t *testing.T) {
+	config := func(defaultTTL int) string {
+		return fmt.Sprintf(`
+provider "hostingde" {
+  new_record_default_ttl = %d
+}
+resource "hostingde_zone" "test" {
+  name = "example7.test"
+  type = "NATIVE"
+  email = "hostmaster@example7.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "www.example7.test"
+  type = "A"
+  content = "192.0.2.1"
+}
+`, defaultTTL)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// New records are seeded with the provider default
+			{
+				Config: config(300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record.test", "ttl", "300"),
+				),
+			},
+			// Changing the default doesn't affect existing records
+			{
+				Config: config(600),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record.test", "ttl", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRecordResourceForEach(t *testing.T) {
+	// The records are keyed by name, the order of the map entries in the
+	// configuration is irrelevant to Terraform
+	config := func(records map[string]string, order []string) string {
+		var entries strings.Builder
+		for _, key := range order {
+			fmt.Fprintf(&entries, "    %q = %q\n", key, records[key])
+		}
+		return providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example10.test"
+  type = "NATIVE"
+  email = "hostmaster@example10.test"
+}
+locals {
+  records = {
+` + entries.String() + `  }
+}
+resource "hostingde_record" "test" {
+  for_each = local.records
+
+  zone_id = hostingde_zone.test.id
+  name    = each.key
+  type    = "A"
+  content = each.value
+}
+`
+	}
+
+	records := map[string]string{}
+	var order []string
+	for i := 1; i <= 10; i++ {
+		key := fmt.Sprintf("host%02d", i)
+		records[key] = fmt.Sprintf("192.0.2.%d", i)
+		order = append(order, key)
+	}
+	reversed := make([]string, len(order))
+	for i, key := range order {
+		reversed[len(order)-1-i] = key
+	}
+
+	changed := map[string]string{}
+	for key, value := range records {
+		changed[key] = value
+	}
+	changed["host03"] = "192.0.2.103"
+
+	resized := map[string]string{}
+	for key, value := range changed {
+		resized[key] = value
+	}
+	delete(resized, "host05")
+	resized["host11"] = "192.0.2.11"
+	resizedOrder := append(slices.DeleteFunc(slices.Clone(order), func(key string) bool { return key == "host05" }), "host11")
+
+	noOp := func(keys ...string) []plancheck.PlanCheck {
+		var checks []plancheck.PlanCheck
+		for _, key := range keys {
+			checks = append(checks, plancheck.ExpectResourceAction(`hostingde_record.test["`+key+`"]`, plancheck.ResourceActionNoop))
+		}
+		return checks
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(records, order),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(`hostingde_record.test["host01"]`, "content", "192.0.2.1"),
+					resource.TestCheckResourceAttr(`hostingde_record.test["host10"]`, "content", "192.0.2.10"),
+				),
+			},
+			// Reordering the map plans nothing
+			{
+				Config: config(records, reversed),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Changing one record updates only that record, in place
+			{
+				Config: config(changed, order),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: append(noOp("host01", "host02", "host04", "host10"),
+						plancheck.ExpectResourceAction(`hostingde_record.test["host03"]`, plancheck.ResourceActionUpdate),
+					),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(`hostingde_record.test["host03"]`, "content", "192.0.2.103"),
+				),
+			},
+			// Removing and adding keys leaves the other records alone
+			{
+				Config: config(resized, resizedOrder),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: append(noOp("host01", "host03", "host04", "host06", "host10"),
+						plancheck.ExpectResourceAction(`hostingde_record.test["host05"]`, plancheck.ResourceActionDestroy),
+						plancheck.ExpectResourceAction(`hostingde_record.test["host11"]`, plancheck.ResourceActionCreate),
+					),
+				},
+			},
+			// The state is stable after the changes
+			{
+				Config: config(resized, resizedOrder),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRecordResourceValidateType(t *testing.T) {
+	// Types unknown to the provider are passed to hosting.de
+	for recordType, wantError := range map[string]bool{
+		"TXT":   false,
+		"HINFO": false,
+	} {
+		diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, "www.example.test"),
+			"type":    tftypes.NewValue(tftypes.String, recordType),
+			"content": tftypes.NewValue(tftypes.String, "text"),
+		})
+		if gotError := len(diags) > 0; gotError != wantError {
+			t.Errorf("type %s: got error %t, want %t, diagnostics: %v", recordType, gotError, wantError, diags)
+		}
+	}
+}
+
+func TestRecordResourceValidatePropagationTimeout(t *testing.T) {
+	for timeout, wantError := range map[string]bool{
+		"90s":  false,
+		"2m":   false,
+		"0s":   true,
+		"soon": true,
+	} {
+		diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+			"zone_id":              tftypes.NewValue(tftypes.String, "1"),
+			"name":                 tftypes.NewValue(tftypes.String, "_acme-challenge.example.test"),
+			"type":                 tftypes.NewValue(tftypes.String, "TXT"),
+			"content":              tftypes.NewValue(tftypes.String, "token"),
+			"wait_for_propagation": tftypes.NewValue(tftypes.Bool, true),
+			"propagation_timeout":  tftypes.NewValue(tftypes.String, timeout),
+		})
+		if gotError := len(diags) > 0; gotError != wantError {
+			t.Errorf("propagation_timeout %q: got error %t, want %t, diagnostics: %v", timeout, gotError, wantError, diags)
+		}
+	}
+}
+
+func TestRecordResourceValidateCreateZoneIfMissing(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		zone      map[string]tftypes.Value
+		wantError bool
+	}{
+		{name: "zone name", zone: map[string]tftypes.Value{
+			"zone_name": tftypes.NewValue(tftypes.String, "example.test"),
+		}},
+		// The zone can only be created from its name
+		{name: "zone ID", zone: map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		}, wantError: true},
+	} {
+		config := map[string]tftypes.Value{
+			"name":                   tftypes.NewValue(tftypes.String, "www"),
+			"type":                   tftypes.NewValue(tftypes.String, "A"),
+			"content":                tftypes.NewValue(tftypes.String, "192.0.2.1"),
+			"create_zone_if_missing": tftypes.NewValue(tftypes.Bool, true),
+		}
+		for attribute, value := range tc.zone {
+			config[attribute] = value
+		}
+
+		diags := testValidateResourceConfig(t, NewRecordResource(), config)
+		if gotError := len(diags) > 0; gotError != tc.wantError {
+			t.Errorf("%s: got error %t, want %t, diagnostics: %v", tc.name, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestRecordResourceRequireExplicitTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		config    map[string]tftypes.Value
+		wantError bool
+	}{
+		{name: "no ttl", wantError: true},
+		{name: "ttl", config: map[string]tftypes.Value{"ttl": tftypes.NewValue(tftypes.Number, 300)}},
+		// Following the zone default is declared explicitly
+		{name: "zone default ttl", config: map[string]tftypes.Value{"ttl": tftypes.NewValue(tftypes.Number, 0)}},
+		{name: "ttl_duration", config: map[string]tftypes.Value{"ttl_duration": tftypes.NewValue(tftypes.String, "5m")}},
+		// hosting.de controls the TTL of ALIAS records
+		{name: "alias", config: map[string]tftypes.Value{
+			"type":    tftypes.NewValue(tftypes.String, "ALIAS"),
+			"content": tftypes.NewValue(tftypes.String, "lb.example.net"),
+		}},
+	} {
+		config := map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, "www"),
+			"type":    tftypes.NewValue(tftypes.String, "A"),
+			"content": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		}
+		for attribute, value := range tc.config {
+			config[attribute] = value
+		}
+
+		r := &recordResource{client: &Client{options: ClientOptions{RequireExplicitTTL: true}}}
+		_, diags := testModifyPlan(t, r, config, config, nil)
+		if diags.HasError() != tc.wantError {
+			t.Errorf("%s: got error %t, want %t, diagnostics: %v", tc.name, diags.HasError(), tc.wantError, diags)
+		}
+	}
+
+	// Without the policy the default TTL is planned
+	config := map[string]tftypes.Value{
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"content": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+	}
+	_, diags := testModifyPlan(t, &recordResource{client: &Client{}}, config, config, nil)
+	if diags.HasError() {
+		t.Errorf("got errors without require_explicit_ttl: %v", diags)
+	}
+}
+
+func TestRecordFQDN(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{name: "www", want: "www.example.test"},
+		{name: "www.sub", want: "www.sub.example.test"},
+		{name: "www.example.test", want: "www.example.test"},
+		{name: "www.example.test.", want: "www.example.test"},
+		{name: "@", want: "example.test"},
+		{name: "example.test", want: "example.test"},
+		// Only the zone name as a whole label counts as suffix
+		{name: "www.otherexample.test", want: "www.otherexample.test.example.test"},
+	} {
+		if got := recordFQDN(tc.name, "example.test"); got != tc.want {
+			t.Errorf("recordFQDN(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRecordStateName(t *testing.T) {
+	for _, tc := range []struct {
+		configured string
+		fqdn       string
+		want       string
+	}{
+		// Both forms are kept as configured
+		{configured: "www", fqdn: "www.example.test", want: "www"},
+		{configured: "www.example.test", fqdn: "www.example.test", want: "www.example.test"},
+		{configured: "@", fqdn: "example.test", want: "@"},
+		// Renamed outside of Terraform
+		{configured: "www", fqdn: "web.example.test", want: "web"},
+		{configured: "www.example.test", fqdn: "web.example.test", want: "web.example.test"},
+		{configured: "www", fqdn: "example.test", want: "@"},
+		// Imported
+		{configured: "", fqdn: "www.example.test", want: "www.example.test"},
+	} {
+		if got := recordStateName(tc.configured, tc.fqdn, "example.test"); got != tc.want {
+			t.Errorf("recordStateName(%q, %q) = %q, want %q", tc.configured, tc.fqdn, got, tc.want)
+		}
+	}
+}
+
+func TestAccRecordResourceDelegationNS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a delegation and check the apex NS records are kept
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+resource "hostingde_record" "delegation" {
+  zone_id = hostingde_zone.test.id
+  name = "sub"
+  type = "NS"
+  content = "ns1.example.com"
+}
+data "hostingde_zone_records" "test" {
+  zone_id = hostingde_record.delegation.zone_id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record.delegation", "name", "sub"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_zone_records.test", "records.*", map[string]string{
+						"name":      "sub.example2.test",
+						"type":      "NS",
+						"read_only": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_zone_records.test", "records.*", map[string]string{
+						"name":      "example2.test",
+						"type":      "NS",
+						"read_only": "true",
+					}),
+				),
+			},
+			// Removing the delegation leaves the apex NS records in place
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example2.test"
+  type = "NATIVE"
+  email = "hostmaster@example2.test"
+}
+data "hostingde_zone_records" "test" {
+  zone_id = hostingde_zone.test.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_zone_records.test", "records.*", map[string]string{
+						"name":      "example2.test",
+						"type":      "NS",
+						"read_only": "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestCheckSystemRecord(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		fqdn       string
+		wantError  bool
+	}{
+		{recordType: "NS", fqdn: "example.test", wantError: true},
+		{recordType: "SOA", fqdn: "example.test", wantError: true},
+		// Delegations of subzones are regular records
+		{recordType: "NS", fqdn: "sub.example.test", wantError: false},
+		{recordType: "NS", fqdn: "a.b.example.test", wantError: false},
+		{recordType: "A", fqdn: "example.test", wantError: false},
+	} {
+		diags := checkSystemRecord(tc.recordType, tc.fqdn, "example.test")
+		if diags.HasError() != tc.wantError {
+			t.Errorf("checkSystemRecord(%s %s): got error %t, want %t", tc.recordType, tc.fqdn, diags.HasError(), tc.wantError)
+		}
+	}
+}
+
+func TestWarnMissingGlue(t *testing.T) {
+	glue := map[string][]DNSRecord{
+		"ns1.sub.example.test/AAAA": {{ID: "1", Name: "ns1.sub.example.test", Type: "AAAA", Content: "2001:db8::53"}},
+	}
+	r := &recordResource{client: newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			name, recordType := findRequest.Filter.SubFilter[1].Value, findRequest.Filter.SubFilter[2].Value
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = glue[name+"/"+recordType]
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})}
+
+	for _, tc := range []struct {
+		recordType string
+		content    string
+		wantWarn   bool
+	}{
+		{recordType: "NS", content: "ns1.sub.example.test", wantWarn: false},
+		{recordType: "NS", content: "ns2.sub.example.test.", wantWarn: true},
+		// Nameservers outside the zone don't need glue in it
+		{recordType: "NS", content: "ns1.example.net", wantWarn: false},
+		{recordType: "CNAME", content: "ns2.sub.example.test", wantWarn: false},
+	} {
+		diags := r.warnMissingGlue(context.Background(), "1", tc.recordType, tc.content, "example.test")
+		if diags.HasError() {
+			t.Fatalf("%s %s: got errors %v", tc.recordType, tc.content, diags)
+		}
+		if gotWarn := diags.WarningsCount() > 0; gotWarn != tc.wantWarn {
+			t.Errorf("%s %s: got warning %t, want %t", tc.recordType, tc.content, gotWarn, tc.wantWarn)
+		}
+	}
+}
+
+func TestRecordResourceDeleteAbsent(t *testing.T) {
+	// Only the lookup is answered, a delete request fails the test
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	diags := testDeleteResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1"),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+	})
+	if diags.HasError() {
+		t.Errorf("deleting an absent record returned errors: %v", diags)
+	}
+}
+
+func TestRecordDate(t *testing.T) {
+	if got := recordDate("2024-03-01T12:00:00Z"); got.ValueString() != "2024-03-01T12:00:00Z" {
+		t.Errorf("got date %s, want the date of the API", got)
+	}
+	// Omitted dates must not show up as empty strings
+	if got := recordDate(""); !got.IsNull() {
+		t.Errorf("got date %s for an omitted date, want null", got)
+	}
+}
+
+func TestExpandZonePlaceholder(t *testing.T) {
+	for content, want := range map[string]string{
+		"mail.${zone}.": "mail.example.test.",
+		"v=spf1 include:${zone} include:_spf.${zone} -all": "v=spf1 include:example.test include:_spf.example.test -all",
+		"mail.example.test.":    "mail.example.test.",
+		"literal $${zone}":      "literal ${zone}",
+		"$$${zone} and ${zone}": "$${zone} and example.test",
+		"$zone {zone}":          "$zone {zone}",
+	} {
+		if got := expandZonePlaceholder(content, "example.test"); got != want {
+			t.Errorf("expandZonePlaceholder(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
+
+func TestRecordTemplateStateContent(t *testing.T) {
+	for _, tc := range []struct {
+		configured   string
+		content      string
+		wantContent  string
+		wantResolved string
+	}{
+		{configured: "mail.${zone}.", content: "mail.example.test.", wantContent: "mail.${zone}.", wantResolved: "mail.example.test."},
+		{configured: "mail.example.test.", content: "mail.example.test.", wantContent: "mail.example.test.", wantResolved: "mail.example.test."},
+		// Changed outside of Terraform
+		{configured: "mail.${zone}.", content: "mx.example.test.", wantContent: "mx.example.test.", wantResolved: "mx.example.test."},
+		// Imported
+		{configured: "", content: "mail.example.test.", wantContent: "mail.example.test.", wantResolved: "mail.example.test."},
+	} {
+		gotContent, gotResolved := recordTemplateStateContent("CNAME", tc.configured, tc.content, "example.test")
+		if gotContent.ValueString() != tc.wantContent || gotResolved.ValueString() != tc.wantResolved {
+			t.Errorf("recordTemplateStateContent(%q, %q) = %q, %q, want %q, %q",
+				tc.configured, tc.content, gotContent.ValueString(), gotResolved.ValueString(), tc.wantContent, tc.wantResolved)
+		}
+	}
+}
+
+func TestRecordResourceValidateContentLength(t *testing.T) {
+	quoted := func(lengths ...int) string {
+		var strs []string
+		for _, length := range lengths {
+			strs = append(strs, `"`+strings.Repeat("a", length)+`"`)
+		}
+		return strings.Join(strs, " ")
+	}
+	repeat := func(length int, n int) []int {
+		lengths := make([]int, n)
+		for i := range lengths {
+			lengths[i] = length
+		}
+		return lengths
+	}
+
+	for _, tc := range []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "string at limit", content: quoted(255)},
+		{name: "string over limit", content: quoted(1, 256), wantErr: "String 2 of the TXT record is 256 bytes long"},
+		{name: "escapes count as one byte", content: `"` + strings.Repeat(`\"`, 100) + strings.Repeat(`\065`, 155) + `"`},
+		{name: "rdata at limit", content: quoted(append(repeat(255, 255), 254)...)},
+		{name: "rdata over limit", content: quoted(repeat(255, 256)...), wantErr: "65536 bytes long in DNS messages"},
+		{name: "unquoted at limit", content: strings.Repeat("a", 65279)},
+		{name: "unquoted over limit", content: strings.Repeat("a", 65280), wantErr: "65536 bytes long in DNS messages"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+				"zone_id": tftypes.NewValue(tftypes.String, "1"),
+				"name":    tftypes.NewValue(tftypes.String, "@"),
+				"type":    tftypes.NewValue(tftypes.String, "TXT"),
+				"content": tftypes.NewValue(tftypes.String, tc.content),
+			})
+
+			var errs []string
+			for _, d := range diags {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Detail)
+				}
+			}
+			if tc.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0], tc.wantErr) {
+				t.Errorf("errors = %v, want one containing %q", errs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecordResourceValidateContentLengthZonePlaceholder(t *testing.T) {
+	// Fits with a short zone name, but not with the real one
+	content := `"` + strings.Repeat("a", 240) + `.${zone}"`
+	diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "@"),
+		"type":    tftypes.NewValue(tftypes.String, "TXT"),
+		"content": tftypes.NewValue(tftypes.String, content),
+	})
+	if len(diags) > 0 {
+		t.Errorf("content with the zone placeholder was checked before the zone is known: %v", diags)
+	}
+
+	model := recordResourceModel{Type: types.StringValue("TXT"), Content: types.StringValue(content)}
+	if diags := validateRecordContentLength(model, "example.test"); diags.HasError() {
+		t.Errorf("got errors %v for a string of 253 bytes", diags)
+	}
+	if diags := validateRecordContentLength(model, "subdomain.example.test"); !diags.HasError() {
+		t.Errorf("got no error for a string of 263 bytes")
+	}
+}
+
+func TestRecordResourceValidateApexCNAME(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		recordType string
+		wantErr    bool
+	}{
+		{name: "@", recordType: "CNAME", wantErr: true},
+		{name: "www", recordType: "CNAME"},
+		{name: "@", recordType: "ALIAS"},
+	} {
+		diags := testValidateResourceConfig(t, NewRecordResource(), map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, tc.name),
+			"type":    tftypes.NewValue(tftypes.String, tc.recordType),
+			"content": tftypes.NewValue(tftypes.String, "target.example.test"),
+		})
+
+		var gotErr bool
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary, "CNAME record at the zone apex") {
+				gotErr = true
+			}
+		}
+		if gotErr != tc.wantErr {
+			t.Errorf("%s %s: got apex CNAME error %t, want %t, diagnostics: %v", tc.name, tc.recordType, gotErr, tc.wantErr, diags)
+		}
+	}
+
+	// Names spelling out the zone name are checked on apply
+	if diags := checkApexCNAME("CNAME", recordFQDN("example.test", "example.test"), "example.test"); !diags.HasError() {
+		t.Errorf("got no error for a CNAME named after the zone")
+	}
+	if diags := checkApexCNAME("CNAME", recordFQDN("www.example.test", "example.test"), "example.test"); diags.HasError() {
+		t.Errorf("got error for a CNAME below the apex: %v", diags)
+	}
+}
+
+func TestRecordResourceFindStateRecord(t *testing.T) {
+	r := &recordResource{client: newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			return json.RawMessage(`{"status":"success","response":{"totalEntries":1,"data":[{"id":"1","name":"example.test"}]}}`)
+		},
+		"/recordsFind": func(t *testing.T, body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if name := findRequest.Filter.SubFilter[1].Value; name != "www.example.test" {
+				t.Errorf("got record name filter %q, want %q", name, "www.example.test")
+			}
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "new-1", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1"},
+				{ID: "new-2", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.2"},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})}
+
+	state := func(content string) recordResourceModel {
+		return recordResourceModel{
+			ID:       types.StringValue("old"),
+			ZoneID:   types.StringValue("1"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringValue("A"),
+			Content:  types.StringValue(content),
+			Priority: types.Int64Value(0),
+		}
+	}
+
+	record, diags := r.findStateRecord(context.Background(), state("192.0.2.2"))
+	if diags.HasError() {
+		t.Fatalf("findStateRecord returned errors: %v", diags)
+	}
+	if record.ID != "new-2" {
+		t.Errorf("got record %s, want new-2", record.ID)
+	}
+
+	if _, diags := r.findStateRecord(context.Background(), state("192.0.2.3")); !diags.HasError() {
+		t.Errorf("got no error for a record without a match")
+	}
+}
+
+func TestParseTTLDuration(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1h", want: 3600},
+		{value: "300s", want: 300},
+		{value: "1h30m", want: 5400},
+		{value: "0s", want: 0},
+		{value: "60s", want: 60},
+		{value: "59s", wantErr: true},
+		{value: "8766h", wantErr: true},
+		{value: "1.5s", wantErr: true},
+		{value: "1d", wantErr: true},
+		{value: "3600", wantErr: true},
+	} {
+		got, err := parseTTLDuration(tc.value)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("parseTTLDuration(%q) = %d, want error", tc.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTTLDuration(%q): %v", tc.value, err)
+		} else if got != tc.want {
+			t.Errorf("parseTTLDuration(%q) = %d, want %d", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestRecordResourceValidateTTLDuration(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		values  map[string]tftypes.Value
+		wantErr bool
+	}{
+		{name: "duration", values: map[string]tftypes.Value{"ttl_duration": tftypes.NewValue(tftypes.String, "1h")}},
+		{name: "invalid", values: map[string]tftypes.Value{"ttl_duration": tftypes.NewValue(tftypes.String, "an hour")}, wantErr: true},
+		{name: "with ttl", values: map[string]tftypes.Value{
+			"ttl_duration": tftypes.NewValue(tftypes.String, "1h"),
+			"ttl":          tftypes.NewValue(tftypes.Number, 3600),
+		}, wantErr: true},
+	} {
+		values := map[string]tftypes.Value{
+			"zone_id": tftypes.NewValue(tftypes.String, "1"),
+			"name":    tftypes.NewValue(tftypes.String, "www"),
+			"type":    tftypes.NewValue(tftypes.String, "A"),
+			"content": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		}
+		for name, value := range tc.values {
+			values[name] = value
+		}
+
+		var gotErr bool
+		for _, d := range testValidateResourceConfig(t, NewRecordResource(), values) {
+			gotErr = gotErr || d.Severity == tfprotov6.DiagnosticSeverityError
+		}
+		if gotErr != tc.wantErr {
+			t.Errorf("%s: got error %t, want %t", tc.name, gotErr, tc.wantErr)
+		}
+	}
+}
+
+func TestRecordResourceImportStateSystemRecord(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		name       string
+		wantError  bool
+	}{
+		{recordType: "SOA", name: "example.test", wantError: true},
+		{recordType: "NS", name: "example.test", wantError: true},
+		{recordType: "NS", name: "sub.example.test", wantError: false},
+		{recordType: "A", name: "example.test", wantError: false},
+	} {
+		client := newTestClient(t, map[string]testHandler{
+			"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+				return json.RawMessage(`{"status":"success","response":{"totalEntries":1,"data":[{"id":"1","name":"example.test"}]}}`)
+			},
+			"/recordsFind": func(t *testing.T, _ []byte) any {
+				findResponse := RecordsFindResponse{}
+				findResponse.Status = "success"
+				findResponse.Response.Data = []DNSRecord{{ID: "5", ZoneID: "1", Name: tc.name, Type: tc.recordType}}
+				return findResponse
+			},
+		})
+		res := &recordResource{client: client}
+
+		ctx := context.Background()
+		schemaResp := fwresource.SchemaResponse{}
+		res.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+		resp := fwresource.ImportStateResponse{State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}}
+		res.ImportState(ctx, fwresource.ImportStateRequest{ID: "5"}, &resp)
+		if resp.Diagnostics.HasError() != tc.wantError {
+			t.Errorf("%s %s: got error %t, want %t: %v", tc.recordType, tc.name, resp.Diagnostics.HasError(), tc.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestRecordResourceStrictWarnings(t *testing.T) {
+	state := map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1"),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"content": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"ttl":     tftypes.NewValue(tftypes.Number, 3600),
+	}
+	config := map[string]tftypes.Value{}
+	for attribute, value := range state {
+		config[attribute] = value
+	}
+	config["type"] = tftypes.NewValue(tftypes.String, "AAAA")
+	config["content"] = tftypes.NewValue(tftypes.String, "2001:db8::1")
+
+	// The note on the replacement only explains the plan, it never fails it
+	for _, strict := range []bool{false, true} {
+		r := &recordResource{client: &Client{options: ClientOptions{StrictWarnings: strict}}}
+		_, diags := testModifyPlan(t, r, config, config, state)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Errorf("strict_warnings %t: got diagnostics %v, want only the replacement warning", strict, diags)
+		}
+	}
+}
+
+func TestRecordResourceStrictWarningsAfterCreate(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Warnings = []string{"record TTL raised to the minimum"}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{{ID: "10", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600}}
+			return updateResponse
+		},
+	})
+	client.options.StrictWarnings = true
+
+	// The warning of the API arrives with the created record, failing would
+	// taint it
+	state, diags := testCreateResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id":                tftypes.NewValue(tftypes.String, "1"),
+		"name":                   tftypes.NewValue(tftypes.String, "www"),
+		"type":                   tftypes.NewValue(tftypes.String, "A"),
+		"content":                tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"ttl":                    tftypes.NewValue(tftypes.Number, 3600),
+		"create_zone_if_missing": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("got diagnostics %v, want only the warning of the API", diags)
+	}
+	if state.Raw.IsNull() {
+		t.Errorf("the created record wasn't saved in the state")
+	}
+
+	// A warning about the configuration fails before the record is created
+	requests := 0
+	client = newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			requests++
+			return RecordsUpdateResponse{}
+		},
+	})
+	client.options.StrictWarnings = true
+	_, diags = testCreateResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id":                tftypes.NewValue(tftypes.String, "1"),
+		"name":                   tftypes.NewValue(tftypes.String, "www.example.test"),
+		"type":                   tftypes.NewValue(tftypes.String, "A"),
+		"content":                tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"ttl":                    tftypes.NewValue(tftypes.Number, 3600),
+		"create_zone_if_missing": tftypes.NewValue(tftypes.Bool, false),
+	})
+	if !diags.HasError() || requests != 0 {
+		t.Errorf("got diagnostics %v and %d record requests, want an error before any request", diags, requests)
+	}
+}
+
+func TestRecordResourceReadAbsent(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	state, diags := testReadResource(t, &recordResource{client: client}, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1"),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+	})
+	if diags.HasError() {
+		t.Errorf("reading an absent record returned errors: %v", diags)
+	}
+	if !state.Raw.IsNull() {
+		t.Errorf("an absent record wasn't removed from the state")
+	}
+}
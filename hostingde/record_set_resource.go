@@ -0,0 +1,761 @@
+package hostingde
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &recordSetResource{}
+	_ resource.ResourceWithConfigure      = &recordSetResource{}
+	_ resource.ResourceWithImportState    = &recordSetResource{}
+	_ resource.ResourceWithValidateConfig = &recordSetResource{}
+	_ resource.ResourceWithModifyPlan     = &recordSetResource{}
+)
+
+// NewRecordSetResource is a helper function to simplify the provider implementation.
+func NewRecordSetResource() resource.Resource {
+	return &recordSetResource{}
+}
+
+// recordSetResource is the resource implementation.
+type recordSetResource struct {
+	client *Client
+}
+
+// recordSetResourceModel maps the record set resource schema data.
+type recordSetResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	ZoneID     types.String `tfsdk:"zone_id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Values     types.Set    `tfsdk:"values"`
+	Sorted     types.List   `tfsdk:"sorted_values"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	AllowEmpty types.Bool   `tfsdk:"allow_empty"`
+
+	ManagePrefix types.String `tfsdk:"manage_prefix"`
+}
+
+// Metadata returns the resource type name.
+func (r *recordSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_set"
+}
+
+// Schema defines the schema for the resource.
+func (r *recordSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages all records of one type at a name in a hosting.de zone as a set of values. " +
+			"The resource is authoritative: records of the type at the name that aren't in values are deleted. " +
+			"All changes of an apply are sent in a single request. " +
+			"An empty set of values is an error, unless allow_empty is set, in which case it deletes all records of the type at the name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Identifier of the record set, in the form zone_id/name/type.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone that the records belong to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the records relative to the zone, \"@\" for the zone apex. Example: mail. " +
+					"Changing the name replaces the record set.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the records, see hostingde_record for the valid types. Changing the type replaces the record set.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"values": schema.SetAttribute{
+				Description: "Content of the records, one record per value. For MX, NAPTR, SRV and URI records, " +
+					"each value starts with the priority, like \"10 mail.example.com\". " +
+					"Must not be empty unless allow_empty is true.",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"sorted_values": schema.ListAttribute{
+				Description: "The values in a stable order for display and outputs: by priority, then by content for MX, NAPTR, SRV and URI records, " +
+					"by content for other types. Changes are still planned on values as a set, so reordering the configured values plans no change.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of all records of the set in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(defaultRecordTTL),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 31556926),
+				},
+			},
+			"allow_empty": schema.BoolAttribute{
+				Description: "Whether values may be empty. If true, an empty set deletes all records of the type at the name " +
+					"and the resource stays in state. If false, an empty set is rejected when validating the configuration, " +
+					"so a mistake in an expression can't wipe the name. Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"manage_prefix": schema.StringAttribute{
+				Description: "Pattern of the record names this resource may change, for zones shared with other tools, like \"_acme-challenge.*\". " +
+					"The pattern is matched against the name relative to the zone, \"@\" for the zone apex, ignoring case. " +
+					"\"*\" matches any characters including dots, so \"_acme-challenge.*\" matches _acme-challenge.www and _acme-challenge.a.b, " +
+					"but not _acme-challenge itself. If the name of the record set is outside the pattern, applying or destroying it fails " +
+					"before any record is added, modified or deleted. Records outside the pattern are never deleted. Unset, the record set may change records at any name.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *recordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create record set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan recordSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	// Records already at the name are taken over, the set is authoritative
+	applied, diags := r.apply(ctx, plan, warnings)
+	resp.Diagnostics.Append(diags...)
+	if applied != nil {
+		resp.Diagnostics.Append(setAppliedRecordSet(ctx, &resp.State, plan, applied)...)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(recordSetID(plan))
+	plan.Sorted, diags = sortedRecordSetValues(ctx, plan.Type.ValueString(), plan.Values)
+	resp.Diagnostics.Append(diags...)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *recordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state recordSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, state.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordType := state.Type.ValueString()
+	name := recordFQDN(state.Name.ValueString(), zoneName)
+	records, err := r.client.listRecordsByName(ctx, state.ZoneID.ValueString(), name, recordType)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	records = recordsNamed(records, name)
+
+	// Only an allowed empty set exists without records
+	if len(records) == 0 && !state.AllowEmpty.ValueBool() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state, diags = recordSetFromRecords(ctx, state, records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Not stored in the API, use the default for imported record sets
+	if state.AllowEmpty.IsNull() {
+		state.AllowEmpty = types.BoolValue(false)
+	}
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *recordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update record set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan recordSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	applied, diags := r.apply(ctx, plan, warnings)
+	resp.Diagnostics.Append(diags...)
+	if applied != nil {
+		resp.Diagnostics.Append(setAppliedRecordSet(ctx, &resp.State, plan, applied)...)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(recordSetID(plan))
+	plan.Sorted, diags = sortedRecordSetValues(ctx, plan.Type.ValueString(), plan.Values)
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *recordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete record set")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state recordSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleting is applying an empty set
+	empty := state
+	empty.Values = types.SetValueMust(types.StringType, nil)
+	applied, diags := r.apply(ctx, empty, warnings)
+	resp.Diagnostics.Append(diags...)
+	if applied != nil {
+		resp.Diagnostics.Append(setAppliedRecordSet(ctx, &resp.State, state, applied)...)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *recordSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ImportState imports a record set by an ID of the form zone_id/name/type.
+func (r *recordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Expected an import ID of the form zone_id/name/type, like 123/mail/MX, got: "+req.ID,
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// ValidateConfig checks the values of the record set.
+func (r *recordSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData recordSetResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateRecordSetEmpty(configData)...)
+	resp.Diagnostics.Append(validateRecordSetValues(configData)...)
+	resp.Diagnostics.Append(validateManagePrefix(configData)...)
+}
+
+// ModifyPlan plans sorted_values from the planned values, so it only changes
+// together with them.
+func (r *recordSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the record set is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan recordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Type.IsUnknown() || plan.Values.IsUnknown() {
+		return
+	}
+	for _, element := range plan.Values.Elements() {
+		if element.IsUnknown() {
+			return
+		}
+	}
+
+	sorted, diags := sortedRecordSetValues(ctx, plan.Type.ValueString(), plan.Values)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sorted_values"), sorted)...)
+}
+
+// apply brings the records of the set in line with the values of the model,
+// adding, modifying and deleting records in a single request. If the API
+// rejected single records and applied the others, the records of the set
+// after the request are returned along with the error. The warnings collected
+// until then fail the request with strict_warnings.
+func (r *recordSetResource) apply(ctx context.Context, model recordSetResourceModel, warnings *clientWarnings) ([]DNSRecord, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	zoneName, d := lookupZoneName(ctx, r.client, model.ZoneID.ValueString())
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	name := recordFQDN(model.Name.ValueString(), zoneName)
+	recordType := model.Type.ValueString()
+	diags.Append(warnAbsoluteRecordName(model.Name.ValueString(), zoneName)...)
+	diags.Append(checkSystemRecord(recordType, name, zoneName)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Checked before any change, so nothing outside the boundary is touched
+	if pattern := model.ManagePrefix.ValueString(); pattern != "" && !withinManagePrefix(pattern, relativeRecordName(name, zoneName)) {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Record set outside manage_prefix",
+			"The record set "+recordType+" "+name+" is outside of manage_prefix "+pattern+", so its records are left untouched. "+
+				"Change the name or manage_prefix if the records are meant to be managed by this resource.",
+		)
+		return nil, diags
+	}
+
+	var values []string
+	diags.Append(model.Values.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	desired := []DNSRecord{}
+	for _, value := range values {
+		record, err := recordFromSetValue(recordType, value)
+		if err != nil {
+			diags.AddAttributeError(path.Root("values"), "Invalid record set value", err.Error())
+			return nil, diags
+		}
+		record.Name = name
+		record.ZoneID = model.ZoneID.ValueString()
+		record.TTL = int(model.TTL.ValueInt64())
+		record.Comments = r.client.options.ManagedByComment
+		desired = append(desired, record)
+	}
+
+	existing, err := r.client.listRecordsByName(ctx, model.ZoneID.ValueString(), name, recordType)
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone ID "+model.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return nil, diags
+	}
+	// The name filter of the API may match more names, like all names for
+	// the wildcard *, records at other names are never deleted. Together
+	// with the check above, records outside of manage_prefix are kept too.
+	existing = recordsNamed(existing, name)
+
+	recordReq := recordSetChanges(existing, desired)
+	if len(recordReq.RecordsToAdd)+len(recordReq.RecordsToModify)+len(recordReq.RecordsToDelete) == 0 {
+		return nil, diags
+	}
+	recordReq.BaseRequest = &BaseRequest{}
+	recordReq.ZoneConfigId = model.ZoneID.ValueString()
+
+	// Strict warnings fail before the records change
+	warnings.appendTo(&diags)
+	r.client.strictWarnings(&diags)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	updateResp, err := r.client.updateRecords(ctx, recordReq)
+	if err != nil {
+		diags.AddError(
+			"Error updating records",
+			"Could not update record set "+recordType+" "+name+", unexpected error: "+err.Error(),
+		)
+	}
+	var updateErr *RecordsUpdateError
+	if errors.As(err, &updateErr) && updateErr.Partial {
+		applied := []DNSRecord{}
+		for _, record := range updateResp.Response.Records {
+			if record.Name == name && record.Type == recordType {
+				applied = append(applied, record)
+			}
+		}
+		return applied, diags
+	}
+
+	return nil, diags
+}
+
+// recordsNamed returns the records with the given name. The name filter of
+// the API treats * as a wildcard, so a lookup of a wildcard record set also
+// returns the records at all other names.
+func recordsNamed(records []DNSRecord, name string) []DNSRecord {
+	return slices.DeleteFunc(records, func(record DNSRecord) bool {
+		return !strings.EqualFold(record.Name, name)
+	})
+}
+
+// setAppliedRecordSet saves the records of a partially applied record set in
+// the state, so a re-apply only retries the records the API rejected.
+func setAppliedRecordSet(ctx context.Context, state *tfsdk.State, model recordSetResourceModel, records []DNSRecord) diag.Diagnostics {
+	model, diags := recordSetFromRecords(ctx, model, records)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(state.Set(ctx, model)...)
+
+	return diags
+}
+
+// recordSetFromRecords returns the model with the values, TTL and ID of the
+// records of the set. Values keep their configured form where they match.
+func recordSetFromRecords(ctx context.Context, model recordSetResourceModel, records []DNSRecord) (recordSetResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var configured []string
+	if !model.Values.IsNull() && !model.Values.IsUnknown() {
+		diags.Append(model.Values.ElementsAs(ctx, &configured, false)...)
+		if diags.HasError() {
+			return model, diags
+		}
+	}
+
+	recordType := model.Type.ValueString()
+	values := []string{}
+	for _, record := range records {
+		values = append(values, recordSetStateValue(recordType, configured, recordSetValue(record)))
+	}
+
+	var d diag.Diagnostics
+	model.Values, d = types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	model.Sorted, d = sortedRecordSetValues(ctx, recordType, model.Values)
+	diags.Append(d...)
+	if len(records) > 0 {
+		model.TTL = types.Int64Value(int64(records[0].TTL))
+	}
+	model.ID = types.StringValue(recordSetID(model))
+
+	return model, diags
+}
+
+// recordSetChanges returns the request turning the existing records into the
+// desired ones. Records are matched by content and priority, matching
+// records are kept and only modified if their TTL differs.
+func recordSetChanges(existing []DNSRecord, desired []DNSRecord) RecordsUpdateRequest {
+	key := func(record DNSRecord) string {
+		return record.Content + " " + strconv.Itoa(record.Priority)
+	}
+
+	wanted := map[string]DNSRecord{}
+	for _, record := range desired {
+		wanted[key(record)] = record
+	}
+
+	var recordReq RecordsUpdateRequest
+	kept := map[string]bool{}
+	for _, record := range existing {
+		want, ok := wanted[key(record)]
+		// Duplicates of a value are deleted as well
+		if !ok || kept[key(record)] {
+			recordReq.RecordsToDelete = append(recordReq.RecordsToDelete, DNSRecord{
+				ID:   record.ID,
+				Name: record.Name,
+				Type: record.Type,
+			})
+			continue
+		}
+		kept[key(record)] = true
+
+		// The full record is sent back, so only the TTL changes
+		if record.TTL != want.TTL {
+			record.TTL = want.TTL
+			recordReq.RecordsToModify = append(recordReq.RecordsToModify, record)
+		}
+	}
+
+	for _, record := range desired {
+		if !kept[key(record)] {
+			kept[key(record)] = true
+			recordReq.RecordsToAdd = append(recordReq.RecordsToAdd, record)
+		}
+	}
+
+	return recordReq
+}
+
+// recordFromSetValue returns the record for a value of a record set. Values
+// of record types with a priority start with the priority.
+func recordFromSetValue(recordType string, value string) (DNSRecord, error) {
+	record := DNSRecord{Type: recordType}
+	if _, ok := priorityRecordTypes[recordType]; !ok {
+		return withPriority(record, normalizeContent(recordType, value), 0), nil
+	}
+
+	fields := strings.SplitN(value, " ", 2)
+	priority, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || len(fields) != 2 {
+		return DNSRecord{}, fmt.Errorf("values of %s records must start with the priority, like \"10 mail.example.com\", got: %s", recordType, value)
+	}
+
+	return withPriority(record, normalizeContent(recordType, fields[1]), priority), nil
+}
+
+// recordSetValue returns the value of a record set for a record from the
+// API, the inverse of recordFromSetValue.
+func recordSetValue(record DNSRecord) string {
+	content, priority := splitPriority(record)
+	if _, ok := priorityRecordTypes[record.Type]; !ok {
+		return content
+	}
+
+	return strconv.FormatInt(priority, 10) + " " + content
+}
+
+// recordSetStateValue returns the value to store in state for a value read
+// from the API. A configured value that results in the same record, for
+// example with a host name in Unicode form or a differently quoted TXT
+// value, is kept as configured.
+func recordSetStateValue(recordType string, configured []string, value string) string {
+	for _, c := range configured {
+		if record, err := recordFromSetValue(recordType, c); err == nil && recordSetValue(record) == value {
+			return c
+		}
+		if recordType == "TXT" && txtValue(c) == txtValue(value) {
+			return c
+		}
+	}
+
+	return value
+}
+
+// sortedRecordSetValues returns the values of a record set as a list sorted
+// by priority, then by the rest of the value. Values of record types without
+// a priority are sorted by content only.
+func sortedRecordSetValues(ctx context.Context, recordType string, values types.Set) (types.List, diag.Diagnostics) {
+	if values.IsNull() {
+		return types.ListNull(types.StringType), nil
+	}
+
+	var sorted []string
+	diags := values.ElementsAs(ctx, &sorted, false)
+	if diags.HasError() {
+		return types.ListNull(types.StringType), diags
+	}
+
+	key := func(value string) (int64, string) {
+		if _, ok := priorityRecordTypes[recordType]; ok {
+			fields := strings.SplitN(value, " ", 2)
+			if priority, err := strconv.ParseInt(fields[0], 10, 64); err == nil && len(fields) == 2 {
+				return priority, fields[1]
+			}
+		}
+		return 0, value
+	}
+	slices.SortFunc(sorted, func(a, b string) int {
+		aPriority, aContent := key(a)
+		bPriority, bContent := key(b)
+		if aPriority != bPriority {
+			return cmp.Compare(aPriority, bPriority)
+		}
+		return strings.Compare(aContent, bContent)
+	})
+
+	list, listDiags := types.ListValueFrom(ctx, types.StringType, sorted)
+	diags.Append(listDiags...)
+
+	return list, diags
+}
+
+// recordSetID returns the ID of a record set, as accepted by ImportState.
+func recordSetID(model recordSetResourceModel) string {
+	return model.ZoneID.ValueString() + "/" + model.Name.ValueString() + "/" + model.Type.ValueString()
+}
+
+// validateRecordSetEmpty rejects an empty set of values unless allow_empty
+// is set, as applying it deletes all records at the name.
+func validateRecordSetEmpty(configData recordSetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Values.IsNull() || configData.Values.IsUnknown() || configData.AllowEmpty.IsUnknown() {
+		return diags
+	}
+
+	if len(configData.Values.Elements()) == 0 && !configData.AllowEmpty.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("values"),
+			"Empty record set",
+			"An empty set of values would delete all "+configData.Type.ValueString()+" records at "+configData.Name.ValueString()+". "+
+				"Set allow_empty = true if that is intended, or remove the resource to delete the records.",
+		)
+	}
+
+	return diags
+}
+
+// validateRecordSetValues checks that the values of record types with a
+// priority start with it.
+func validateRecordSetValues(configData recordSetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.Type.IsUnknown() || configData.Values.IsNull() || configData.Values.IsUnknown() {
+		return diags
+	}
+
+	for _, element := range configData.Values.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		if _, err := recordFromSetValue(configData.Type.ValueString(), value.ValueString()); err != nil {
+			diags.AddAttributeError(path.Root("values"), "Invalid record set value", err.Error())
+		}
+	}
+
+	return diags
+}
+
+// managePrefixPattern returns the regular expression for a manage_prefix
+// pattern, in which "*" matches any characters including dots.
+func managePrefixPattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(strings.ToLower(strings.TrimSuffix(pattern, ".")), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// withinManagePrefix reports whether a record name relative to the zone
+// matches the manage_prefix pattern, ignoring case.
+func withinManagePrefix(pattern string, relativeName string) bool {
+	return managePrefixPattern(pattern).MatchString(strings.ToLower(strings.TrimSuffix(relativeName, ".")))
+}
+
+// validateManagePrefix checks that the name of the record set matches
+// manage_prefix, if the name is a single label and so surely relative. Other
+// names are checked on apply, once the zone name is known.
+func validateManagePrefix(configData recordSetResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.ManagePrefix.IsNull() || configData.ManagePrefix.IsUnknown() || configData.Name.IsUnknown() {
+		return diags
+	}
+
+	pattern := configData.ManagePrefix.ValueString()
+	if pattern == "" {
+		diags.AddAttributeError(
+			path.Root("manage_prefix"),
+			"Invalid manage_prefix",
+			"The manage_prefix must not be empty, remove it to manage records at any name.",
+		)
+		return diags
+	}
+
+	// A name with dots may be absolute even without the trailing dot
+	name := configData.Name.ValueString()
+	if strings.Contains(name, ".") || withinManagePrefix(pattern, name) {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("name"),
+		"Record set outside manage_prefix",
+		"The name "+name+" doesn't match manage_prefix "+pattern+", so the record set could never be applied. "+
+			"Change the name or manage_prefix.",
+	)
+
+	return diags
+}
@@ -0,0 +1,517 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccRecordSetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example7.test"
+  type = "NATIVE"
+  email = "hostmaster@example7.test"
+}
+resource "hostingde_record_set" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "www"
+  type = "A"
+  values = ["192.0.2.1", "192.0.2.2"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "values.#", "2"),
+					resource.TestCheckTypeSetElemAttr("hostingde_record_set.test", "values.*", "192.0.2.1"),
+					resource.TestCheckTypeSetElemAttr("hostingde_record_set.test", "values.*", "192.0.2.2"),
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "ttl", "3600"),
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "allow_empty", "false"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "hostingde_record_set.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allow_empty"},
+			},
+			// Replace a value and change the TTL
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example7.test"
+  type = "NATIVE"
+  email = "hostmaster@example7.test"
+}
+resource "hostingde_record_set" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "www"
+  type = "A"
+  values = ["192.0.2.1", "192.0.2.3"]
+  ttl = 300
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "values.#", "2"),
+					resource.TestCheckTypeSetElemAttr("hostingde_record_set.test", "values.*", "192.0.2.3"),
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "ttl", "300"),
+				),
+			},
+			// An allowed empty set removes all records and keeps the resource
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example7.test"
+  type = "NATIVE"
+  email = "hostmaster@example7.test"
+}
+resource "hostingde_record_set" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "www"
+  type = "A"
+  values = []
+  allow_empty = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "values.#", "0"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccRecordSetResourceMXOrder(t *testing.T) {
+	zone := `
+resource "hostingde_zone" "test" {
+  name = "example8.test"
+  type = "NATIVE"
+  email = "hostmaster@example8.test"
+}
+`
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + zone + `
+resource "hostingde_record_set" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "@"
+  type = "MX"
+  values = ["20 mx2.example.net", "5 mx1.example.net", "10 mx3.example.net"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "sorted_values.0", "5 mx1.example.net"),
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "sorted_values.1", "10 mx3.example.net"),
+					resource.TestCheckResourceAttr("hostingde_record_set.test", "sorted_values.2", "20 mx2.example.net"),
+				),
+			},
+			// Reordering the configured values changes nothing
+			{
+				Config: providerConfig + zone + `
+resource "hostingde_record_set" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "@"
+  type = "MX"
+  values = ["10 mx3.example.net", "20 mx2.example.net", "5 mx1.example.net"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRecordSetResourcePartialFailure(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+		// The second value is rejected, the first one is added
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Errors = []APIError{{ContextPath: "recordsToAdd[1].content", Text: "Invalid IPv4 address"}}
+			updateResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test"}
+			updateResponse.Response.Records = []DNSRecord{
+				{ID: "10", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+				{ID: "11", Name: "mail.example.test", Type: "A", Content: "192.0.2.9", TTL: 3600},
+			}
+			return updateResponse
+		},
+	})
+
+	state, diags := testCreateResource(t, &recordSetResource{client: client}, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"values": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "192.0.2.1"),
+			tftypes.NewValue(tftypes.String, "192.0.2.300"),
+		}),
+		"sorted_values": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+		"ttl":           tftypes.NewValue(tftypes.Number, 3600),
+		"allow_empty":   tftypes.NewValue(tftypes.Bool, false),
+	})
+	if !diags.HasError() {
+		t.Errorf("got no error for a rejected record")
+	}
+
+	// Only the added record is saved, a re-apply retries the rejected one
+	var values []string
+	state.GetAttribute(context.Background(), path.Root("values"), &values)
+	if fmt.Sprint(values) != "[192.0.2.1]" {
+		t.Errorf("got values %v in state, want only the added 192.0.2.1", values)
+	}
+}
+
+func TestSortedRecordSetValues(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		values     []string
+		want       []string
+	}{
+		// Priorities are compared as numbers, equal priorities by host
+		{recordType: "MX", values: []string{"20 b.example.test", "5 c.example.test", "10 b.example.test", "10 a.example.test"},
+			want: []string{"5 c.example.test", "10 a.example.test", "10 b.example.test", "20 b.example.test"}},
+		{recordType: "URI", values: []string{"10 1 \"https://b.example.test\"", "2 1 \"https://a.example.test\""},
+			want: []string{"2 1 \"https://a.example.test\"", "10 1 \"https://b.example.test\""}},
+		{recordType: "A", values: []string{"192.0.2.2", "192.0.2.10", "192.0.2.1"},
+			want: []string{"192.0.2.1", "192.0.2.10", "192.0.2.2"}},
+	} {
+		values, diags := types.SetValueFrom(context.Background(), types.StringType, tc.values)
+		sorted, sortDiags := sortedRecordSetValues(context.Background(), tc.recordType, values)
+		diags.Append(sortDiags...)
+
+		var got []string
+		diags.Append(sorted.ElementsAs(context.Background(), &got, false)...)
+		if diags.HasError() {
+			t.Fatalf("%s: got errors %v", tc.recordType, diags)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got values %v, want %v", tc.recordType, got, tc.want)
+		}
+	}
+}
+
+func TestRecordSetResourceValidateEmpty(t *testing.T) {
+	for _, tc := range []struct {
+		allowEmpty tftypes.Value
+		wantError  bool
+	}{
+		{allowEmpty: tftypes.NewValue(tftypes.Bool, nil), wantError: true},
+		{allowEmpty: tftypes.NewValue(tftypes.Bool, false), wantError: true},
+		{allowEmpty: tftypes.NewValue(tftypes.Bool, true), wantError: false},
+	} {
+		diags := testValidateResourceConfig(t, NewRecordSetResource(), map[string]tftypes.Value{
+			"zone_id":     tftypes.NewValue(tftypes.String, "1"),
+			"name":        tftypes.NewValue(tftypes.String, "www"),
+			"type":        tftypes.NewValue(tftypes.String, "A"),
+			"values":      tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{}),
+			"allow_empty": tc.allowEmpty,
+		})
+
+		var gotError bool
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary, "Empty record set") {
+				gotError = true
+			}
+		}
+		if gotError != tc.wantError {
+			t.Errorf("allow_empty %v: got error %t, want %t, diagnostics: %v", tc.allowEmpty, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestWithinManagePrefix(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "_acme-challenge.*", name: "_acme-challenge.www", want: true},
+		{pattern: "_acme-challenge.*", name: "_acme-challenge.a.b", want: true},
+		{pattern: "_acme-challenge.*", name: "_ACME-Challenge.www.", want: true},
+		{pattern: "_acme-challenge.*", name: "_acme-challenge", want: false},
+		{pattern: "_acme-challenge.*", name: "www", want: false},
+		{pattern: "_acme-challenge*", name: "_acme-challenge", want: true},
+		{pattern: "*.dev", name: "api.dev", want: true},
+		{pattern: "*.dev", name: "api.prod", want: false},
+		{pattern: "@", name: "@", want: true},
+		// Other characters are matched literally
+		{pattern: "a.b", name: "axb", want: false},
+		{pattern: "[a-z]", name: "a", want: false},
+	} {
+		if got := withinManagePrefix(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("withinManagePrefix(%q, %q) = %t, want %t", tc.pattern, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRecordSetResourceValidateManagePrefix(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		wantError bool
+	}{
+		{name: "_acme-challenge.www"},
+		{name: "www", wantError: true},
+		// May be absolute, checked on apply
+		{name: "www.example.test"},
+	} {
+		diags := testValidateResourceConfig(t, NewRecordSetResource(), map[string]tftypes.Value{
+			"zone_id":       tftypes.NewValue(tftypes.String, "1"),
+			"name":          tftypes.NewValue(tftypes.String, tc.name),
+			"type":          tftypes.NewValue(tftypes.String, "TXT"),
+			"values":        tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "token")}),
+			"manage_prefix": tftypes.NewValue(tftypes.String, "_acme-challenge.*"),
+		})
+		if gotError := len(diags) > 0; gotError != tc.wantError {
+			t.Errorf("name %q: got error %t, want %t, diagnostics: %v", tc.name, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestRecordSetResourceDeleteManagePrefix(t *testing.T) {
+	var deleted []string
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		// The name filter matched a record outside of manage_prefix as well
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "10", Name: "_acme-challenge.www.example.test", Type: "TXT", Content: `"token"`, TTL: 3600},
+				{ID: "11", Name: "www.example.test", Type: "TXT", Content: `"other"`, TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 2
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request: %v", err)
+			}
+			for _, record := range updateRequest.RecordsToDelete {
+				deleted = append(deleted, record.ID)
+			}
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			return updateResponse
+		},
+	})
+
+	diags := testDeleteResource(t, &recordSetResource{client: client}, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "1/_acme-challenge.www/TXT"),
+		"zone_id":       tftypes.NewValue(tftypes.String, "1"),
+		"name":          tftypes.NewValue(tftypes.String, "_acme-challenge.www"),
+		"type":          tftypes.NewValue(tftypes.String, "TXT"),
+		"values":        tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "token")}),
+		"ttl":           tftypes.NewValue(tftypes.Number, 3600),
+		"manage_prefix": tftypes.NewValue(tftypes.String, "_acme-challenge.*"),
+	})
+	if diags.HasError() {
+		t.Fatalf("deleting the record set returned errors: %v", diags)
+	}
+	if fmt.Sprint(deleted) != "[10]" {
+		t.Errorf("deleted records %v, want only 10 within manage_prefix", deleted)
+	}
+}
+
+func TestRecordSetResourceWildcardName(t *testing.T) {
+	var deleted []string
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		// The name filter treats * as a wildcard and matches all names
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "10", Name: "*.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+				{ID: "11", Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 2
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request: %v", err)
+			}
+			for _, record := range updateRequest.RecordsToDelete {
+				deleted = append(deleted, record.ID)
+			}
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			return updateResponse
+		},
+	})
+	values := map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1/*/A"),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "*"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"values":  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "192.0.2.1")}),
+		"ttl":     tftypes.NewValue(tftypes.Number, 3600),
+	}
+
+	state, diags := testReadResource(t, &recordSetResource{client: client}, values)
+	if diags.HasError() {
+		t.Fatalf("reading the record set returned errors: %v", diags)
+	}
+	var got []string
+	state.GetAttribute(context.Background(), path.Root("values"), &got)
+	if fmt.Sprint(got) != "[192.0.2.1]" {
+		t.Errorf("got values %v, want only the record named *", got)
+	}
+
+	diags = testDeleteResource(t, &recordSetResource{client: client}, values)
+	if diags.HasError() {
+		t.Fatalf("deleting the record set returned errors: %v", diags)
+	}
+	if fmt.Sprint(deleted) != "[10]" {
+		t.Errorf("deleted records %v, want only 10 named *", deleted)
+	}
+}
+
+func TestRecordSetResourceStrictWarnings(t *testing.T) {
+	// A warning about the configuration fails before the records are changed,
+	// a request to /recordsUpdate fails the test
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+	client.options.StrictWarnings = true
+
+	_, diags := testCreateResource(t, &recordSetResource{client: client}, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"name":    tftypes.NewValue(tftypes.String, "www.example.test"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"values":  tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "192.0.2.1")}),
+		"ttl":     tftypes.NewValue(tftypes.Number, 3600),
+	})
+	if !diags.HasError() {
+		t.Errorf("got diagnostics %v, want an error before any change", diags)
+	}
+}
+
+func TestRecordSetChanges(t *testing.T) {
+	existing := []DNSRecord{
+		{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: "2", Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 300},
+		{ID: "3", Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: "4", Name: "www.example.test", Type: "A", Content: "192.0.2.4", TTL: 3600},
+	}
+	desired := []DNSRecord{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+	}
+
+	recordReq := recordSetChanges(existing, desired)
+
+	ids := func(records []DNSRecord) string {
+		var ids []string
+		for _, record := range records {
+			ids = append(ids, record.ID+record.Content)
+		}
+		return strings.Join(ids, ",")
+	}
+	// Record 1 is unchanged, 2 gets the new TTL, 3 is a duplicate and 4 isn't wanted
+	if got := ids(recordReq.RecordsToModify); got != "2192.0.2.2" {
+		t.Errorf("got modified records %s, want record 2", got)
+	}
+	if got := ids(recordReq.RecordsToDelete); got != "3,4" {
+		t.Errorf("got deleted records %s, want records 3 and 4", got)
+	}
+	if got := ids(recordReq.RecordsToAdd); got != "192.0.2.3" {
+		t.Errorf("got added records %s, want 192.0.2.3", got)
+	}
+
+	// An empty set deletes all records
+	if got := ids(recordSetChanges(existing, nil).RecordsToDelete); got != "1,2,3,4" {
+		t.Errorf("got deleted records %s for an empty set, want all", got)
+	}
+}
+
+func TestRecordSetValue(t *testing.T) {
+	for _, tc := range []struct {
+		recordType string
+		value      string
+		want       DNSRecord
+		wantError  bool
+	}{
+		{recordType: "A", value: "192.0.2.1", want: DNSRecord{Type: "A", Content: "192.0.2.1"}},
+		{recordType: "MX", value: "10 mail.example.test", want: DNSRecord{Type: "MX", Content: "mail.example.test", Priority: 10}},
+		{recordType: "URI", value: "10 1 \"https://example.test\"", want: DNSRecord{Type: "URI", Content: "10 1 \"https://example.test\""}},
+		{recordType: "MX", value: "mail.example.test", wantError: true},
+	} {
+		record, err := recordFromSetValue(tc.recordType, tc.value)
+		if (err != nil) != tc.wantError {
+			t.Errorf("recordFromSetValue(%s, %q): got error %v, want error %t", tc.recordType, tc.value, err, tc.wantError)
+			continue
+		}
+		if tc.wantError {
+			continue
+		}
+		if record != tc.want {
+			t.Errorf("recordFromSetValue(%s, %q) = %+v, want %+v", tc.recordType, tc.value, record, tc.want)
+		}
+		if got := recordSetValue(record); got != tc.value {
+			t.Errorf("recordSetValue(%+v) = %q, want %q", record, got, tc.value)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package hostingde
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &recordValuesDataSource{}
+	_ datasource.DataSourceWithConfigure = &recordValuesDataSource{}
+)
+
+// NewRecordValuesDataSource is a helper function to simplify the provider implementation.
+func NewRecordValuesDataSource() datasource.DataSource {
+	return &recordValuesDataSource{}
+}
+
+// recordValuesDataSource is the data source implementation.
+type recordValuesDataSource struct {
+	client *Client
+}
+
+// recordValuesDataSourceModel maps the data source schema data.
+type recordValuesDataSourceModel struct {
+	ZoneName types.String `tfsdk:"zone_name"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Values   []string     `tfsdk:"values"`
+}
+
+// Metadata returns the data source type name.
+func (d *recordValuesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_values"
+}
+
+// Schema defines the schema for the data source.
+func (d *recordValuesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Returns the content of the records with a given name and type, as configured in hosting.de.",
+		Attributes: map[string]schema.Attribute{
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the zone.",
+				Required:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the records. Example: mail.example.com.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the records.",
+				Required:    true,
+			},
+			"values": schema.ListAttribute{
+				Description: "Sorted content of the matching records, without the priority. Empty if no record matches.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *recordValuesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state recordValuesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfig, err := d.client.getZoneConfigByName(ctx, state.ZoneName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{
+		SubFilterConnective: "AND",
+		SubFilter: []Filter{
+			{Field: "ZoneConfigId", Value: zoneConfig.ID},
+			{Field: "RecordName", Value: state.Name.ValueString()},
+			{Field: "RecordType", Value: state.Type.ValueString()},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Values = []string{}
+	for _, record := range records {
+		content, _ := splitPriority(record)
+		state.Values = append(state.Values, content)
+	}
+	sort.Strings(state.Values)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *recordValuesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
@@ -0,0 +1,57 @@
+package hostingde
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRecordValuesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example6.test"
+  type = "NATIVE"
+  email = "hostmaster@example6.test"
+}
+resource "hostingde_record" "b" {
+  zone_id = hostingde_zone.test.id
+  name = "www.example6.test"
+  type = "A"
+  content = "192.0.2.2"
+}
+resource "hostingde_record" "a" {
+  zone_id = hostingde_zone.test.id
+  name = "www.example6.test"
+  type = "A"
+  content = "192.0.2.1"
+}
+data "hostingde_record_values" "www" {
+  zone_name = hostingde_zone.test.name
+  name = "www.example6.test"
+  type = "A"
+  depends_on = [hostingde_record.a, hostingde_record.b]
+}
+data "hostingde_record_values" "none" {
+  zone_name = hostingde_zone.test.name
+  name = "www.example6.test"
+  type = "AAAA"
+  depends_on = [hostingde_record.a, hostingde_record.b]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the values are sorted.
+					resource.TestCheckResourceAttr("data.hostingde_record_values.www", "values.#", "2"),
+					resource.TestCheckResourceAttr("data.hostingde_record_values.www", "values.0", "192.0.2.1"),
+					resource.TestCheckResourceAttr("data.hostingde_record_values.www", "values.1", "192.0.2.2"),
+					// Verify an empty list is returned if nothing matches.
+					resource.TestCheckResourceAttr("data.hostingde_record_values.none", "values.#", "0"),
+				),
+			},
+		},
+	})
+}
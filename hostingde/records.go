@@ -1,47 +1,426 @@
 package hostingde
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // https://www.hosting.de/api/?json#list-recordconfigs
-func (d *Client) listRecords(findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
+func (d *Client) listRecords(ctx context.Context, findRequest RecordsFindRequest) (*RecordsFindResponse, error) {
 	uri := d.baseURL + "/recordsFind"
 
-	findResponse := &RecordsFindResponse{}
+	findRequest.Limit = clampLimit(findRequest.Limit)
 
-	rawResp, err := d.doRequest(http.MethodPost, uri, findRequest, findResponse)
+	rawResp, err := d.doRequest(ctx, http.MethodPost, uri, findRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: %s", err, toErrorWithNewlines(uri, rawResp))
+	findResponse, err := decodeEnvelope[RecordsFindResponse](ctx, uri, rawResp)
+	if err != nil {
+		return findResponse, err
 	}
 
-	if findResponse.Status != "success" {
-		return findResponse, errors.New(toErrorWithNewlines(uri, rawResp))
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("records %w: %s", errNotFound, toErrorWithNewlines(uri, rawResp))
 	}
 
 	return findResponse, nil
 }
 
 // https://www.hosting.de/api/?json#updating-records-in-a-zone
-func (c *Client) updateRecords(updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
+func (c *Client) updateRecords(ctx context.Context, updateRequest RecordsUpdateRequest) (*RecordsUpdateResponse, error) {
 	uri := c.baseURL + "/recordsUpdate"
 
-	updateResponse := &RecordsUpdateResponse{}
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	updateResponse, err := decodeEnvelope[RecordsUpdateResponse](ctx, uri, rawResp, "pending")
+	if updateResponse == nil {
+		return nil, err
+	}
+
+	failed := failedRecords(updateRequest, updateResponse.Errors)
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, updateRequest, updateResponse)
 	if err != nil {
+		if len(failed) > 0 {
+			return nil, &RecordsUpdateError{URI: uri, Body: rawResp, Failed: failed}
+		}
 		return nil, err
 	}
 
-	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	// The other records of a partially successful request were applied, so
+	// the response is returned along with the error.
+	if len(failed) > 0 {
+		return updateResponse, &RecordsUpdateError{URI: uri, Body: rawResp, Failed: failed, Partial: true}
 	}
 
 	return updateResponse, nil
 }
+
+// RecordsUpdateError is returned by updateRecords if the API rejected single
+// records of the request.
+type RecordsUpdateError struct {
+	URI    string
+	Body   []byte
+	Failed []FailedRecord
+	// Partial is set if the other records of the request were applied.
+	Partial bool
+}
+
+// FailedRecord is a record rejected by the API, with the reason.
+type FailedRecord struct {
+	// Operation is the list of the request the record was sent in, one of
+	// recordsToAdd, recordsToModify and recordsToDelete.
+	Operation string
+	Record    DNSRecord
+	Reason    string
+}
+
+// failedCount returns the number of records of the operation, one of
+// recordsToAdd, recordsToModify and recordsToDelete, rejected by the API.
+func (e *RecordsUpdateError) failedCount(operation string) int {
+	count := 0
+	for _, f := range e.Failed {
+		if f.Operation == operation {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (e *RecordsUpdateError) Error() string {
+	failed := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		record := f.Record.Type + " record " + f.Record.Name
+		if f.Record.ID != "" {
+			record += " (ID " + f.Record.ID + ")"
+		}
+		failed = append(failed, fmt.Sprintf("%s in %s: %s", record, f.Operation, f.Reason))
+	}
+
+	applied := "no records were changed"
+	if e.Partial {
+		applied = "the other records were applied"
+	}
+
+	return fmt.Sprintf("%d record(s) rejected, %s:\n%s\n%s",
+		len(e.Failed), applied, strings.Join(failed, "\n"), toErrorWithNewlines(e.URI, e.Body))
+}
+
+// recordContextPath matches the context path of an API error pointing at a
+// record of a recordsUpdate request, e.g. recordsToAdd[2].content or
+// /recordsToAdd/2/content.
+var recordContextPath = regexp.MustCompile(`(recordsTo(?:Add|Modify|Delete))[/\[.](\d+)`)
+
+// failedRecords returns the records of the request the API errors refer to.
+// Errors without a record context are left to the generic error handling.
+func failedRecords(updateRequest RecordsUpdateRequest, apiErrors []APIError) []FailedRecord {
+	lists := map[string][]DNSRecord{
+		"recordsToAdd":    updateRequest.RecordsToAdd,
+		"recordsToModify": updateRequest.RecordsToModify,
+		"recordsToDelete": updateRequest.RecordsToDelete,
+	}
+
+	var failed []FailedRecord
+	for _, apiError := range apiErrors {
+		match := recordContextPath.FindStringSubmatch(apiError.ContextPath)
+		if match == nil {
+			continue
+		}
+
+		index, _ := strconv.Atoi(match[2])
+		if index >= len(lists[match[1]]) {
+			continue
+		}
+
+		reason := apiError.Text
+		if reason == "" {
+			reason = apiError.Value
+		}
+
+		failed = append(failed, FailedRecord{
+			Operation: match[1],
+			Record:    lists[match[1]][index],
+			Reason:    reason,
+		})
+	}
+
+	return failed
+}
+
+// countRecords returns the number of records in a zone. It requests a single
+// record and uses the totalEntries field of the response, so the records
+// themselves don't need to be downloaded.
+// https://www.hosting.de/api/?json#list-records
+func (c *Client) countRecords(ctx context.Context, zoneConfigId string) (int, error) {
+	uri := c.baseURL + "/recordsFind"
+
+	findRequest := RecordsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneConfigId",
+			Value: zoneConfigId,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	findResponse, err := decodeEnvelope[RecordsFindResponse](ctx, uri, rawResp)
+	if err != nil {
+		return 0, err
+	}
+
+	return findResponse.Response.TotalEntries, nil
+}
+
+// zoneSerial returns the serial of the zone from its SOA record.
+func (c *Client) zoneSerial(ctx context.Context, zoneConfigId string, zoneName string) (int64, error) {
+	records, err := c.listRecordsByName(ctx, zoneConfigId, zoneName, "SOA")
+	if err != nil {
+		return 0, err
+	}
+
+	if len(records) == 0 {
+		return 0, fmt.Errorf("SOA record of zone %s %w", zoneName, errNotFound)
+	}
+
+	return soaSerial(records[0].Content)
+}
+
+// soaSerial returns the serial of the content of a SOA record, the field
+// after the primary nameserver and the hostmaster mailbox.
+func soaSerial(content string) (int64, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 3 {
+		return 0, fmt.Errorf("invalid SOA record content %q", content)
+	}
+
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid serial in SOA record content %q: %v", content, err)
+	}
+
+	return int64(serial), nil
+}
+
+// RecordFields holds the fields of a record to change with patchRecord.
+// Nil fields keep their current value.
+type RecordFields struct {
+	Name     *string
+	Content  *string
+	TTL      *int
+	Priority *int
+}
+
+// getRecord returns the record with the given ID.
+// https://www.hosting.de/api/?json#list-records
+func (c *Client) getRecord(ctx context.Context, recordId string) (*DNSRecord, error) {
+	// Unlike listRecords, an empty result isn't an error
+	records, err := c.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "RecordId",
+		Value: recordId,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("record %s %w", recordId, errNotFound)
+	}
+
+	return &records[0], nil
+}
+
+// patchRecord changes only the given fields of a record. The API has no
+// partial updates, recordsUpdate replaces the whole record. So the current
+// record is fetched, the changed fields are merged into it, and the full
+// object is sent back, keeping fields managed by hosting.de, like comments
+// or the record template reference, intact.
+// https://www.hosting.de/api/?json#updating-records-in-a-zone
+func (c *Client) patchRecord(ctx context.Context, recordId string, fields RecordFields) (*RecordsUpdateResponse, error) {
+	current, err := c.getRecord(ctx, recordId)
+	if err != nil {
+		return nil, err
+	}
+
+	record := *current
+	if fields.Name != nil {
+		record.Name = *fields.Name
+	}
+	if fields.Content != nil {
+		record.Content = *fields.Content
+	}
+	if fields.TTL != nil {
+		record.TTL = *fields.TTL
+	}
+	if fields.Priority != nil {
+		record.Priority = *fields.Priority
+	}
+
+	return c.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:     &BaseRequest{},
+		ZoneConfigId:    record.ZoneID,
+		RecordsToModify: []DNSRecord{record},
+	})
+}
+
+// listAllRecords returns all records matching the filter. The API caps the
+// page size, so the records are fetched page by page until the total number
+// of entries reported by the API is reached.
+// https://www.hosting.de/api/?json#list-records
+func (c *Client) listAllRecords(ctx context.Context, filter FilterOrChain) ([]DNSRecord, error) {
+	uri := c.baseURL + "/recordsFind"
+
+	records := []DNSRecord{}
+	for page := 1; ; page++ {
+		findRequest := RecordsFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter:      filter,
+			Limit:       maxPageLimit,
+			Page:        page,
+		}
+
+		rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		findResponse, err := decodeEnvelope[RecordsFindResponse](ctx, uri, rawResp)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, findResponse.Response.Data...)
+
+		// Stop on an empty page as well, in case records were deleted in between
+		if len(records) >= findResponse.Response.TotalEntries || len(findResponse.Response.Data) == 0 {
+			return records, nil
+		}
+	}
+}
+
+// listRecordsByName returns the records of a zone with the given fully
+// qualified name and type.
+func (c *Client) listRecordsByName(ctx context.Context, zoneConfigId string, name string, recordType string) ([]DNSRecord, error) {
+	return c.listAllRecords(ctx, FilterOrChain{
+		SubFilterConnective: "AND",
+		SubFilter: []Filter{
+			{Field: "ZoneConfigId", Value: zoneConfigId},
+			{Field: "RecordName", Value: name},
+			{Field: "RecordType", Value: recordType},
+		},
+	})
+}
+
+// findMatchingRecord returns the record of the zone with the same name, type
+// and content as the given record, or nil if there is none.
+func (c *Client) findMatchingRecord(ctx context.Context, record DNSRecord) (*DNSRecord, error) {
+	records, err := c.listRecordsByName(ctx, record.ZoneID, record.Name, record.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	// The filter on the content isn't exact for all record types, compare here
+	for i := range records {
+		if sameRecordContent(record.Type, record.Content, records[i].Content) {
+			return &records[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// listZoneNameservers returns the content of the NS records at the apex of a
+// zone, i.e. the nameservers the zone was set up with.
+func (c *Client) listZoneNameservers(ctx context.Context, zoneConfigId string, zoneName string) ([]string, error) {
+	records, err := c.listAllRecords(ctx, FilterOrChain{
+		SubFilterConnective: "AND",
+		SubFilter: []Filter{
+			{Field: "ZoneConfigId", Value: zoneConfigId},
+			{Field: "RecordType", Value: "NS"},
+			{Field: "RecordName", Value: zoneName},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nameservers := []string{}
+	for _, record := range records {
+		nameservers = append(nameservers, record.Content)
+	}
+
+	return nameservers, nil
+}
+
+// recordsBelowMinTTL returns the records with a TTL below minTTL. Records
+// whose TTL is controlled by hosting.de, like the SOA and apex NS records of
+// the zone, are left out.
+func recordsBelowMinTTL(records []DNSRecord, zoneName string, minTTL int) []DNSRecord {
+	below := []DNSRecord{}
+	for _, record := range records {
+		if isSystemRecord(record, zoneName) || slices.Contains(serverTTLRecordTypes, record.Type) {
+			continue
+		}
+		if record.TTL < minTTL {
+			below = append(below, record)
+		}
+	}
+
+	return below
+}
+
+// raiseRecordTTLs sets the TTL of all records of the zone below minTTL to
+// minTTL in a single recordsUpdate request, and returns the number of
+// records changed. If the API rejected single records, the number of the
+// other, raised records is returned along with the partial
+// RecordsUpdateError.
+// https://www.hosting.de/api/?json#updating-records-in-a-zone
+func (c *Client) raiseRecordTTLs(ctx context.Context, zoneConfigId string, zoneName string, minTTL int) (int, error) {
+	records, err := c.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: zoneConfigId,
+	}})
+	if err != nil {
+		return 0, err
+	}
+
+	// The full records are sent back, so only the TTL changes
+	below := recordsBelowMinTTL(records, zoneName, minTTL)
+	if len(below) == 0 {
+		return 0, nil
+	}
+	for i := range below {
+		below[i].TTL = minTTL
+	}
+
+	_, err = c.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:     &BaseRequest{},
+		ZoneConfigId:    zoneConfigId,
+		RecordsToModify: below,
+	})
+	var updateErr *RecordsUpdateError
+	if errors.As(err, &updateErr) && updateErr.Partial {
+		return len(below) - updateErr.failedCount("recordsToModify"), err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return len(below), nil
+}
@@ -0,0 +1,97 @@
+package hostingde
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// recordsJSONRecord is a record of the records_json attribute of a zone.
+type recordsJSONRecord struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      *int   `json:"ttl"`
+	Priority *int   `json:"priority"`
+}
+
+// parseRecordsJSON parses a JSON array of records into records for the zone.
+// Names are relative to the zone like the name of hostingde_record, priorities
+// are converted like withPriority does. Errors name the index of the record.
+func parseRecordsJSON(zoneName string, value string) ([]DNSRecord, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &elements); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of records: %w", err)
+	}
+
+	records := make([]DNSRecord, 0, len(elements))
+	for i, element := range elements {
+		record, err := parseRecordsJSONRecord(zoneName, element)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseRecordsJSONRecord parses and validates a single record of
+// records_json. Unknown keys are rejected, so typos don't go unnoticed.
+func parseRecordsJSONRecord(zoneName string, element json.RawMessage) (DNSRecord, error) {
+	decoder := json.NewDecoder(bytes.NewReader(element))
+	decoder.DisallowUnknownFields()
+
+	var value recordsJSONRecord
+	if err := decoder.Decode(&value); err != nil {
+		return DNSRecord{}, err
+	}
+
+	switch {
+	case value.Name == "":
+		return DNSRecord{}, errors.New(`name is required, use "@" for the zone apex`)
+	case value.Type == "":
+		return DNSRecord{}, errors.New("type is required")
+	case !slices.Contains(knownRecordTypes, value.Type):
+		return DNSRecord{}, fmt.Errorf("type %s is not supported by hosting.de", value.Type)
+	case value.Type == "SOA":
+		return DNSRecord{}, errors.New("SOA records are managed by hosting.de")
+	case value.Content == "" && value.Type != "NULLMX":
+		return DNSRecord{}, errors.New("content is required")
+	case value.TTL != nil && (*value.TTL < 60 || *value.TTL > 31556926):
+		return DNSRecord{}, fmt.Errorf("ttl must be between 60 and 31556926, got %d", *value.TTL)
+	}
+
+	name := recordFQDN(value.Name, zoneName)
+	if value.Type == "NS" && name == zoneName {
+		return DNSRecord{}, errors.New("NS records at the zone apex are managed by hosting.de")
+	}
+
+	_, hasPriority := priorityRecordTypes[value.Type]
+	switch {
+	case hasPriority && value.Priority == nil:
+		return DNSRecord{}, fmt.Errorf("priority is required for %s records", value.Type)
+	case !hasPriority && value.Priority != nil:
+		return DNSRecord{}, fmt.Errorf("%s records have no priority", value.Type)
+	case value.Priority != nil && (*value.Priority < 0 || *value.Priority > 65535):
+		return DNSRecord{}, fmt.Errorf("priority must be between 0 and 65535, got %d", *value.Priority)
+	}
+
+	record := DNSRecord{
+		Name: name,
+		Type: value.Type,
+		TTL:  defaultRecordTTL,
+	}
+	if value.TTL != nil {
+		record.TTL = *value.TTL
+	}
+	priority := 0
+	if value.Priority != nil {
+		priority = *value.Priority
+	}
+
+	return withPriority(record, normalizeContent(value.Type, value.Content), int64(priority)), nil
+}
@@ -0,0 +1,59 @@
+package hostingde
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRecordsJSON(t *testing.T) {
+	value := `[
+		{"name": "@", "type": "A", "content": "192.0.2.1"},
+		{"name": "www", "type": "CNAME", "content": "example.test", "ttl": 300},
+		{"name": "@", "type": "MX", "content": "mail.example.test", "priority": 10},
+		{"name": "_sip._tcp", "type": "URI", "content": "1 \"sip:sip.example.test\"", "priority": 20},
+		{"name": "sub.example.test", "type": "NS", "content": "ns1.example.net"}
+	]`
+
+	records, err := parseRecordsJSON("example.test", value)
+	if err != nil {
+		t.Fatalf("parseRecordsJSON returned an error: %v", err)
+	}
+
+	want := []DNSRecord{
+		{Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 300},
+		{Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{Name: "_sip._tcp.example.test", Type: "URI", Content: "20 1 \"sip:sip.example.test\"", TTL: 3600},
+		{Name: "sub.example.test", Type: "NS", Content: "ns1.example.net", TTL: 3600},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("parseRecordsJSON() =\n%+v\nwant:\n%+v", records, want)
+	}
+}
+
+func TestParseRecordsJSONErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		value string
+		want  string
+	}{
+		"not an array":      {value: `{"name": "@"}`, want: "expected a JSON array"},
+		"unknown key":       {value: `[{"name": "@", "type": "A", "content": "192.0.2.1", "prio": 1}]`, want: `record 0: json: unknown field "prio"`},
+		"missing name":      {value: `[{"name": "@", "type": "A", "content": "192.0.2.1"}, {"type": "A", "content": "192.0.2.2"}]`, want: "record 1: name is required"},
+		"unknown type":      {value: `[{"name": "@", "type": "SPF", "content": "v=spf1 -all"}]`, want: "record 0: type SPF is not supported"},
+		"missing content":   {value: `[{"name": "www", "type": "A"}]`, want: "record 0: content is required"},
+		"soa":               {value: `[{"name": "@", "type": "SOA", "content": "ns1.example.net"}]`, want: "record 0: SOA records"},
+		"apex ns":           {value: `[{"name": "example.test.", "type": "NS", "content": "ns1.example.net"}]`, want: "record 0: NS records at the zone apex"},
+		"ttl out of range":  {value: `[{"name": "@", "type": "A", "content": "192.0.2.1", "ttl": 30}]`, want: "record 0: ttl must be between"},
+		"missing priority":  {value: `[{"name": "@", "type": "MX", "content": "mail.example.test"}]`, want: "record 0: priority is required for MX records"},
+		"needless priority": {value: `[{"name": "@", "type": "A", "content": "192.0.2.1", "priority": 10}]`, want: "record 0: A records have no priority"},
+		"wrong value type":  {value: `[{"name": "@", "type": "A", "content": "192.0.2.1", "ttl": "1h"}]`, want: "record 0: json: cannot unmarshal string"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseRecordsJSON("example.test", tc.value)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %v, want an error containing %q", err, tc.want)
+			}
+		})
+	}
+}
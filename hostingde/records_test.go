@@ -0,0 +1,368 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListAllRecordsPaginates(t *testing.T) {
+	const totalEntries = 5
+	const pageSize = 2
+
+	var requestedPages []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var findRequest RecordsFindRequest
+		if err := json.NewDecoder(r.Body).Decode(&findRequest); err != nil {
+			t.Fatalf("invalid request body: %v", err)
+		}
+		if findRequest.Limit > maxPageLimit {
+			t.Errorf("requested limit %d exceeds the maximum of %d", findRequest.Limit, maxPageLimit)
+		}
+		requestedPages = append(requestedPages, findRequest.Page)
+
+		// The server returns fewer records than requested
+		findResponse := RecordsFindResponse{}
+		findResponse.Status = "success"
+		findResponse.Response.TotalEntries = totalEntries
+		for i := (findRequest.Page - 1) * pageSize; i < findRequest.Page*pageSize && i < totalEntries; i++ {
+			findResponse.Response.Data = append(findResponse.Response.Data, DNSRecord{ID: fmt.Sprint(i)})
+		}
+
+		if err := json.NewEncoder(w).Encode(findResponse); err != nil {
+			t.Fatalf("could not write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(nil, nil, &server.URL, ClientOptions{})
+
+	records, err := client.listAllRecords(context.Background(), FilterOrChain{Filter: Filter{Field: "ZoneConfigId", Value: "1"}})
+	if err != nil {
+		t.Fatalf("listAllRecords returned an error: %v", err)
+	}
+
+	if len(records) != totalEntries {
+		t.Errorf("got %d records, want %d", len(records), totalEntries)
+	}
+	if len(requestedPages) != 3 {
+		t.Errorf("got %d requests, want 3 (pages %v)", len(requestedPages), requestedPages)
+	}
+}
+
+func TestClampLimit(t *testing.T) {
+	for limit, want := range map[int]int{
+		0:                maxPageLimit,
+		1:                1,
+		maxPageLimit:     maxPageLimit,
+		maxPageLimit + 1: maxPageLimit,
+	} {
+		if got := clampLimit(limit); got != want {
+			t.Errorf("clampLimit(%d) = %d, want %d", limit, got, want)
+		}
+	}
+}
+
+func TestSOASerial(t *testing.T) {
+	for _, tc := range []struct {
+		content string
+		want    int64
+		wantErr bool
+	}{
+		{content: "ns1.hosting.de. hostmaster.hosting.de. 2024010101 86400 7200 3600000 3600", want: 2024010101},
+		{content: "ns1.hosting.de hostmaster.hosting.de 4294967295 86400 7200 3600000 3600", want: 4294967295},
+		{content: "ns1.hosting.de. hostmaster.hosting.de.", wantErr: true},
+		{content: "ns1.hosting.de. hostmaster.hosting.de. serial 86400 7200 3600000 3600", wantErr: true},
+		{content: "ns1.hosting.de. hostmaster.hosting.de. 4294967296 86400 7200 3600000 3600", wantErr: true},
+	} {
+		got, err := soaSerial(tc.content)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("soaSerial(%q) = %d, want error", tc.content, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("soaSerial(%q): %v", tc.content, err)
+		} else if got != tc.want {
+			t.Errorf("soaSerial(%q) = %d, want %d", tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestPatchRecordMergesFields(t *testing.T) {
+	current := DNSRecord{
+		ID:               "record-1",
+		ZoneID:           "zone-1",
+		RecordTemplateID: "template-record-1",
+		Name:             "www.example.test",
+		Type:             "A",
+		Content:          "192.0.2.1",
+		TTL:              3600,
+	}
+
+	var modified []DNSRecord
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, body []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{current}
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			modified = updateRequest.RecordsToModify
+
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Response.Records = updateRequest.RecordsToModify
+			return updateResponse
+		},
+	})
+
+	content := "192.0.2.2"
+	if _, err := client.patchRecord(context.Background(), current.ID, RecordFields{Content: &content}); err != nil {
+		t.Fatalf("patchRecord returned an error: %v", err)
+	}
+
+	want := current
+	want.Content = content
+	if len(modified) != 1 || modified[0] != want {
+		t.Errorf("got modified records %+v, want %+v", modified, want)
+	}
+}
+
+func TestUpdateRecordsFailedRecords(t *testing.T) {
+	updateRequest := RecordsUpdateRequest{
+		BaseRequest: &BaseRequest{},
+		RecordsToAdd: []DNSRecord{
+			{Name: "a.example.test", Type: "A", Content: "192.0.2.1"},
+			{Name: "b.example.test", Type: "A", Content: "not an address"},
+		},
+		RecordsToDelete: []DNSRecord{{ID: "42", Name: "c.example.test", Type: "TXT"}},
+	}
+
+	for _, tc := range []struct {
+		status      string
+		wantPartial bool
+	}{
+		{status: "error"},
+		{status: "success", wantPartial: true},
+	} {
+		client := newTestClient(t, map[string]testHandler{
+			"/recordsUpdate": func(t *testing.T, _ []byte) any {
+				updateResponse := RecordsUpdateResponse{}
+				updateResponse.Status = tc.status
+				updateResponse.Errors = []APIError{
+					{ContextPath: "recordsToAdd[1].content", Text: "Invalid IPv4 address"},
+					{ContextPath: "/recordsToDelete/0", Value: "notFound"},
+				}
+				return updateResponse
+			},
+		})
+
+		updateResponse, err := client.updateRecords(context.Background(), updateRequest)
+
+		var updateErr *RecordsUpdateError
+		if !errors.As(err, &updateErr) {
+			t.Fatalf("status %s: got error %v, want a RecordsUpdateError", tc.status, err)
+		}
+		if updateErr.Partial != tc.wantPartial || (updateResponse != nil) != tc.wantPartial {
+			t.Errorf("status %s: got partial %t and response %v, want partial %t", tc.status, updateErr.Partial, updateResponse, tc.wantPartial)
+		}
+
+		if len(updateErr.Failed) != 2 {
+			t.Fatalf("status %s: got %d failed records, want 2", tc.status, len(updateErr.Failed))
+		}
+		if got := updateErr.Failed[0]; got.Record.Name != "b.example.test" || got.Reason != "Invalid IPv4 address" {
+			t.Errorf("status %s: got failed record %+v, want b.example.test", tc.status, got)
+		}
+		if got := updateErr.Failed[1]; got.Operation != "recordsToDelete" || got.Record.ID != "42" || got.Reason != "notFound" {
+			t.Errorf("status %s: got failed record %+v, want deletion of ID 42", tc.status, got)
+		}
+		if !strings.Contains(err.Error(), "TXT record c.example.test (ID 42) in recordsToDelete: notFound") {
+			t.Errorf("status %s: error %q doesn't name the failed record", tc.status, err)
+		}
+	}
+}
+
+func TestPatchRecordKeepsComments(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{{ID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600, Comments: "managed by terraform"}}
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if got := updateRequest.RecordsToModify[0]; got.Content != "192.0.2.2" || got.Comments != "managed by terraform" {
+				t.Errorf("got modified record %+v, want new content and the comments kept", got)
+			}
+
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			return updateResponse
+		},
+	})
+
+	content := "192.0.2.2"
+	if _, err := client.patchRecord(context.Background(), "1", RecordFields{Content: &content}); err != nil {
+		t.Fatalf("patchRecord returned an error: %v", err)
+	}
+}
+
+func TestRaiseRecordTTLs(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", Name: "example.test", Type: "SOA", TTL: 300},
+				{ID: "2", Name: "example.test", Type: "NS", Content: "ns1.example.net", TTL: 300},
+				{ID: "3", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 300},
+				{ID: "4", Name: "mail.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+				{ID: "5", Name: "alias.example.test", Type: "ALIAS", Content: "target.example.net", TTL: 60},
+				{ID: "6", Name: "sub.example.test", Type: "NS", Content: "ns1.example.org", TTL: 60},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+
+			var modified []string
+			for _, record := range updateRequest.RecordsToModify {
+				modified = append(modified, record.ID)
+				if record.TTL != 1800 {
+					t.Errorf("record %s modified with TTL %d, want 1800", record.ID, record.TTL)
+				}
+			}
+			if strings.Join(modified, ",") != "3,6" {
+				t.Errorf("modified records %v, want 3 and 6", modified)
+			}
+
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			return updateResponse
+		},
+	})
+
+	changed, err := client.raiseRecordTTLs(context.Background(), "1", "example.test", 1800)
+	if err != nil {
+		t.Fatalf("raiseRecordTTLs returned an error: %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("got %d changed records, want 2", changed)
+	}
+}
+
+func TestRaiseRecordTTLsPartialFailure(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", Name: "a.example.test", Type: "A", Content: "192.0.2.1", TTL: 60},
+				{ID: "2", Name: "b.example.test", Type: "A", Content: "192.0.2.2", TTL: 60},
+				{ID: "3", Name: "c.example.test", Type: "A", Content: "192.0.2.3", TTL: 60},
+			}
+			findResponse.Response.TotalEntries = 3
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, _ []byte) any {
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Errors = []APIError{{ContextPath: "recordsToModify[1]", Value: "blocked"}}
+			return updateResponse
+		},
+	})
+
+	changed, err := client.raiseRecordTTLs(context.Background(), "1", "example.test", 1800)
+	var updateErr *RecordsUpdateError
+	if !errors.As(err, &updateErr) || !updateErr.Partial {
+		t.Fatalf("got error %v, want a partial RecordsUpdateError", err)
+	}
+	if changed != 2 {
+		t.Errorf("got %d changed records, want the 2 accepted ones", changed)
+	}
+}
+
+func TestFindMatchingRecord(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordsFind": func(t *testing.T, body []byte) any {
+			var findRequest RecordsFindRequest
+			if err := json.Unmarshal(body, &findRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			if got := len(findRequest.Filter.SubFilter); got != 3 {
+				t.Errorf("got %d sub filters, want zone, name and type", got)
+			}
+
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1"},
+				{ID: "2", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.2"},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})
+
+	record := DNSRecord{ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.2"}
+	existing, err := client.findMatchingRecord(context.Background(), record)
+	if err != nil {
+		t.Fatalf("findMatchingRecord returned an error: %v", err)
+	}
+	if existing == nil || existing.ID != "2" {
+		t.Errorf("got record %+v, want record 2", existing)
+	}
+
+	record.Content = "192.0.2.3"
+	existing, err = client.findMatchingRecord(context.Background(), record)
+	if err != nil {
+		t.Fatalf("findMatchingRecord returned an error: %v", err)
+	}
+	if existing != nil {
+		t.Errorf("got record %+v for content without a match, want none", existing)
+	}
+}
+
+func TestFindMatchingRecordTXT(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		// The API returns the content quoted and split differently
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "1", ZoneID: "1", Name: "example.test", Type: "TXT", Content: `"v=spf1 " "-all"`},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+	})
+
+	record := DNSRecord{ZoneID: "1", Name: "example.test", Type: "TXT", Content: `"v=spf1 -all"`}
+	existing, err := client.findMatchingRecord(context.Background(), record)
+	if err != nil {
+		t.Fatalf("findMatchingRecord returned an error: %v", err)
+	}
+	if existing == nil || existing.ID != "1" {
+		t.Errorf("got record %+v, want record 1", existing)
+	}
+}
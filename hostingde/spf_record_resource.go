@@ -0,0 +1,691 @@
+package hostingde
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxSPFLength is the length of an SPF record above which the DNS response
+// might not fit into a single UDP packet, see RFC 7208 section 3.4.
+const maxSPFLength = 450
+
+// maxSPFLookups is the number of mechanisms causing DNS lookups an SPF record
+// may contain, see RFC 7208 section 4.6.4.
+const maxSPFLookups = 10
+
+// spfAllPolicies maps the all_policy values to the all mechanism of the
+// record. pass is left out on purpose, +all allows every host to send mail.
+var spfAllPolicies = map[string]string{
+	"fail":     "-all",
+	"softfail": "~all",
+	"neutral":  "?all",
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &spfRecordResource{}
+	_ resource.ResourceWithConfigure      = &spfRecordResource{}
+	_ resource.ResourceWithValidateConfig = &spfRecordResource{}
+	_ resource.ResourceWithModifyPlan     = &spfRecordResource{}
+)
+
+// NewSpfRecordResource is a helper function to simplify the provider implementation.
+func NewSpfRecordResource() resource.Resource {
+	return &spfRecordResource{}
+}
+
+// spfRecordResource is the resource implementation.
+type spfRecordResource struct {
+	client *Client
+}
+
+// spfRecordResourceModel maps the SPF record resource schema data.
+type spfRecordResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	ZoneID    types.String `tfsdk:"zone_id"`
+	Include   types.List   `tfsdk:"include"`
+	IP4       types.List   `tfsdk:"ip4"`
+	IP6       types.List   `tfsdk:"ip6"`
+	AllPolicy types.String `tfsdk:"all_policy"`
+	TTL       types.Int64  `tfsdk:"ttl"`
+	Name      types.String `tfsdk:"name"`
+	Content   types.String `tfsdk:"content"`
+}
+
+// spfMechanisms are the structured fields of an SPF record.
+type spfMechanisms struct {
+	Include   []string
+	IP4       []string
+	IP6       []string
+	AllPolicy string
+}
+
+// Metadata returns the resource type name.
+func (r *spfRecordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_spf_record"
+}
+
+// Schema defines the schema for the resource.
+func (r *spfRecordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the SPF record at the apex of a zone, a TXT record assembled from its mechanisms. " +
+			"The mechanisms are joined as \"v=spf1 ip4:... ip6:... include:... -all\" and split into strings of at most 255 characters. " +
+			"Creating the record fails if the zone already has an SPF record at the apex, as receivers treat several SPF records as an error. " +
+			"If the record is changed outside of Terraform, the mechanisms are parsed from its content. " +
+			"Content with other mechanisms, like a or redirect, keeps the configured mechanisms and is only shown in content.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the TXT record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Description: "ID of DNS zone that the record belongs to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include": schema.ListAttribute{
+				Description: "Domains whose SPF records are included, like _spf.example.net. " +
+					"Each include takes a DNS lookup, at most " + strconv.Itoa(maxSPFLookups) + " are allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ip4": schema.ListAttribute{
+				Description: "IPv4 addresses or networks allowed to send mail, like 192.0.2.1 or 192.0.2.0/24.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"ip6": schema.ListAttribute{
+				Description: "IPv6 addresses or networks allowed to send mail, like 2001:db8::1 or 2001:db8::/32.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"all_policy": schema.StringAttribute{
+				Description: "How receivers treat mail from other hosts: fail (-all), softfail (~all) or neutral (?all). " +
+					"pass (+all) is not supported, as it allows every host to send mail for the domain. Defaults to softfail.",
+				Computed: true,
+				Optional: true,
+				Default:  stringdefault.StaticString("softfail"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("fail", "softfail", "neutral"),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the record in seconds. Minimum is 60, maximum is 31556926. Defaults to 3600.",
+				Computed:    true,
+				Optional:    true,
+				Default:     int64default.StaticInt64(defaultRecordTTL),
+				Validators: []validator.Int64{
+					int64validator.Between(60, 31556926),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the TXT record, the name of the zone.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the TXT record, the assembled mechanisms split into quoted strings.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Create a new resource
+func (r *spfRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create SPF record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan
+	var plan spfRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	zoneName, diags := lookupZoneName(ctx, r.client, plan.ZoneID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A second SPF record makes receivers fail the SPF check
+	existing, err := r.client.listRecordsByName(ctx, plan.ZoneID.ValueString(), zoneName, "TXT")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not list the TXT records of "+zoneName+": "+err.Error(),
+		)
+		return
+	}
+	for _, record := range existing {
+		if isSPFRecord(record) {
+			resp.Diagnostics.AddError(
+				"Existing SPF record",
+				"The zone "+zoneName+" already has the SPF record "+record.ID+" with content "+record.Content+". "+
+					"A domain must have exactly one SPF record, delete the existing record or remove it from its resource first.",
+			)
+			return
+		}
+	}
+
+	record := DNSRecord{
+		Name:     zoneName,
+		ZoneID:   plan.ZoneID.ValueString(),
+		Type:     "TXT",
+		Content:  plan.Content.ValueString(),
+		TTL:      int(plan.TTL.ValueInt64()),
+		Comments: r.client.options.ManagedByComment,
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordResp, err := r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: plan.ZoneID.ValueString(),
+		RecordsToAdd: []DNSRecord{record},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not create SPF record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	var returnedRecord *DNSRecord
+	for i, r := range recordResp.Response.Records {
+		if r.Name == record.Name && r.Type == record.Type && txtValue(r.Content) == txtValue(record.Content) {
+			returnedRecord = &recordResp.Response.Records[i]
+		}
+	}
+	if returnedRecord == nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not find the created SPF record of "+record.Name+" in the response of hosting.de",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(returnedRecord.ID)
+	plan.Name = types.StringValue(returnedRecord.Name)
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *spfRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	// Get current state
+	var state spfRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	record, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read SPF record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The API may quote or split the value differently
+	if txtValue(record.Content) != txtValue(state.Content.ValueString()) {
+		state.Content = types.StringValue(record.Content)
+
+		mechanisms, err := parseSPF(txtValue(record.Content))
+		if err != nil {
+			tflog.Warn(ctx, "Could not parse the mechanisms of the SPF record, keeping the configured mechanisms", map[string]any{
+				"hostingde_record_id": record.ID,
+				"error":               err.Error(),
+			})
+		} else {
+			resp.Diagnostics.Append(state.setMechanisms(ctx, mechanisms)...)
+		}
+	}
+
+	state.ZoneID = types.StringValue(record.ZoneID)
+	state.Name = types.StringValue(record.Name)
+	state.TTL = types.Int64Value(int64(record.TTL))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *spfRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update SPF record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from plan and prior state
+	var plan, state spfRecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
+	content := plan.Content.ValueString()
+	ttl := int(plan.TTL.ValueInt64())
+	fields := RecordFields{TTL: &ttl}
+	if !plan.Content.Equal(state.Content) {
+		fields.Content = &content
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if _, err := r.client.patchRecord(ctx, state.ID.ValueString(), fields); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating records",
+			"Could not update SPF record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *spfRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete SPF record")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state
+	var state spfRecordResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getRecord(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Record already deleted", map[string]any{
+			"hostingde_record_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read SPF record ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Strict warnings fail before the record changes
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = r.client.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: state.ZoneID.ValueString(),
+		RecordsToDelete: []DNSRecord{{
+			ID:   state.ID.ValueString(),
+			Name: state.Name.ValueString(),
+			Type: "TXT",
+		}},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Record",
+			"Could not delete SPF record, unexpected error: "+err.Error(),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *spfRecordResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*Client)
+}
+
+// ModifyPlan plans the assembled content of the record. The content of
+// unchanged mechanisms is kept, so a different quoting by the API doesn't
+// show up as a change.
+func (r *spfRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan spfRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mechanisms, known, diags := plan.mechanisms(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+	plan.Content = types.StringValue(chunkTXT(assembleSPF(mechanisms)))
+
+	if !req.State.Raw.IsNull() {
+		var state spfRecordResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if txtValue(plan.Content.ValueString()) == txtValue(state.Content.ValueString()) {
+			plan.Content = state.Content
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// ValidateConfig checks the mechanisms, the number of DNS lookups and the
+// length of the record.
+func (r *spfRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData spfRecordResourceModel
+	diags := req.Config.Get(ctx, &configData)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSPFList(configData.Include, "include", validateSPFDomain)...)
+	resp.Diagnostics.Append(validateSPFList(configData.IP4, "ip4", validateSPFNetwork(netip.Addr.Is4, "IPv4"))...)
+	resp.Diagnostics.Append(validateSPFList(configData.IP6, "ip6", validateSPFNetwork(func(addr netip.Addr) bool {
+		return addr.Is6() && !addr.Is4In6()
+	}, "IPv6"))...)
+
+	if !configData.Include.IsUnknown() && len(configData.Include.Elements()) > maxSPFLookups {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("include"),
+			"Too many SPF lookups",
+			"An SPF record may contain at most "+strconv.Itoa(maxSPFLookups)+" mechanisms causing DNS lookups, "+
+				"receivers fail the check otherwise. Got "+strconv.Itoa(len(configData.Include.Elements()))+" includes.",
+		)
+	}
+
+	// all_policy defaults to softfail, the length is the same for all policies
+	if configData.AllPolicy.IsNull() {
+		configData.AllPolicy = types.StringValue("softfail")
+	}
+	mechanisms, known, diags := configData.mechanisms(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !known {
+		return
+	}
+	if value := assembleSPF(mechanisms); len(value) > maxSPFLength {
+		resp.Diagnostics.AddError(
+			"SPF record too long",
+			"The SPF record has "+strconv.Itoa(len(value))+" characters, at most "+strconv.Itoa(maxSPFLength)+" are allowed "+
+				"so the DNS response fits into a single UDP packet. Combine networks or move senders into an included record.",
+		)
+	}
+}
+
+// mechanisms returns the configured mechanisms, or false if some are
+// unknown.
+func (m spfRecordResourceModel) mechanisms(ctx context.Context) (spfMechanisms, bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var mechanisms spfMechanisms
+
+	for _, value := range []types.List{m.Include, m.IP4, m.IP6} {
+		if value.IsUnknown() {
+			return mechanisms, false, diags
+		}
+		for _, element := range value.Elements() {
+			if element.IsUnknown() {
+				return mechanisms, false, diags
+			}
+		}
+	}
+	if m.AllPolicy.IsUnknown() {
+		return mechanisms, false, diags
+	}
+
+	diags.Append(m.Include.ElementsAs(ctx, &mechanisms.Include, false)...)
+	diags.Append(m.IP4.ElementsAs(ctx, &mechanisms.IP4, false)...)
+	diags.Append(m.IP6.ElementsAs(ctx, &mechanisms.IP6, false)...)
+	mechanisms.AllPolicy = m.AllPolicy.ValueString()
+
+	return mechanisms, !diags.HasError(), diags
+}
+
+// setMechanisms sets the structured fields from parsed mechanisms. Empty
+// lists stay null if they are null, so they don't show up as a change.
+func (m *spfRecordResourceModel) setMechanisms(ctx context.Context, mechanisms spfMechanisms) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, field := range []struct {
+		list   *types.List
+		values []string
+	}{
+		{list: &m.Include, values: mechanisms.Include},
+		{list: &m.IP4, values: mechanisms.IP4},
+		{list: &m.IP6, values: mechanisms.IP6},
+	} {
+		if len(field.values) == 0 && field.list.IsNull() {
+			continue
+		}
+		list, d := types.ListValueFrom(ctx, types.StringType, field.values)
+		diags.Append(d...)
+		*field.list = list
+	}
+	m.AllPolicy = types.StringValue(mechanisms.AllPolicy)
+
+	return diags
+}
+
+// validateSPFList checks the known elements of a list attribute.
+func validateSPFList(list types.List, name string, validate func(string) error) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	for i, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		if err := validate(value.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root(name).AtListIndex(i),
+				"Invalid SPF mechanism",
+				"Invalid "+name+" "+strconv.Quote(value.ValueString())+": "+err.Error(),
+			)
+		}
+	}
+
+	return diags
+}
+
+// validateSPFDomain checks the domain of an include mechanism.
+func validateSPFDomain(domain string) error {
+	if domain == "" || strings.ContainsAny(domain, " \t\"") || !strings.Contains(strings.Trim(domain, "."), ".") {
+		return errors.New("expected a domain name like _spf.example.net")
+	}
+
+	return nil
+}
+
+// validateSPFNetwork returns a check of the address or network of an ip4 or
+// ip6 mechanism.
+func validateSPFNetwork(isFamily func(netip.Addr) bool, family string) func(string) error {
+	return func(value string) error {
+		addr, err := netip.ParseAddr(value)
+		if strings.Contains(value, "/") {
+			var prefix netip.Prefix
+			prefix, err = netip.ParsePrefix(value)
+			addr = prefix.Addr()
+		}
+		if err != nil || !isFamily(addr) {
+			return fmt.Errorf("expected an %s address or network in CIDR notation", family)
+		}
+
+		return nil
+	}
+}
+
+// isSPFRecord returns whether a TXT record is an SPF record, i.e. whether its
+// value starts with the version v=spf1.
+func isSPFRecord(record DNSRecord) bool {
+	value := strings.ToLower(txtValue(record.Content))
+	return value == "v=spf1" || strings.HasPrefix(value, "v=spf1 ")
+}
+
+// assembleSPF joins the mechanisms of an SPF record. The addresses come
+// first, as they don't need DNS lookups, the all mechanism last.
+func assembleSPF(mechanisms spfMechanisms) string {
+	terms := []string{"v=spf1"}
+	for _, ip := range mechanisms.IP4 {
+		terms = append(terms, "ip4:"+ip)
+	}
+	for _, ip := range mechanisms.IP6 {
+		terms = append(terms, "ip6:"+ip)
+	}
+	for _, domain := range mechanisms.Include {
+		terms = append(terms, "include:"+domain)
+	}
+	terms = append(terms, spfAllPolicies[mechanisms.AllPolicy])
+
+	return strings.Join(terms, " ")
+}
+
+// parseSPF splits the value of an SPF record into its mechanisms, the inverse
+// of assembleSPF. Mechanisms that can't be represented by the resource, like
+// a, mx or redirect, are returned as an error.
+func parseSPF(value string) (spfMechanisms, error) {
+	var mechanisms spfMechanisms
+
+	terms := strings.Fields(value)
+	if len(terms) == 0 || !strings.EqualFold(terms[0], "v=spf1") {
+		return mechanisms, errors.New("missing version v=spf1")
+	}
+
+	for i, term := range terms[1:] {
+		if mechanisms.AllPolicy != "" {
+			return mechanisms, fmt.Errorf("unsupported term %q after the all mechanism", term)
+		}
+
+		name, argument, ok := strings.Cut(strings.TrimPrefix(term, "+"), ":")
+		if !ok || argument == "" {
+			name = ""
+		}
+		switch strings.ToLower(name) {
+		case "include":
+			mechanisms.Include = append(mechanisms.Include, argument)
+			continue
+		case "ip4":
+			mechanisms.IP4 = append(mechanisms.IP4, argument)
+			continue
+		case "ip6":
+			mechanisms.IP6 = append(mechanisms.IP6, argument)
+			continue
+		}
+
+		for policy, all := range spfAllPolicies {
+			if strings.EqualFold(term, all) {
+				mechanisms.AllPolicy = policy
+			}
+		}
+		if mechanisms.AllPolicy == "" {
+			return mechanisms, fmt.Errorf("unsupported term %q at position %d", term, i+1)
+		}
+	}
+
+	if mechanisms.AllPolicy == "" {
+		return mechanisms, errors.New("missing all mechanism")
+	}
+
+	return mechanisms, nil
+}
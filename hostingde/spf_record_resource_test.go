@@ -0,0 +1,127 @@
+package hostingde
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAssembleSPF(t *testing.T) {
+	mechanisms := spfMechanisms{
+		Include:   []string{"_spf.example.net", "spf.example.org"},
+		IP4:       []string{"192.0.2.0/24"},
+		IP6:       []string{"2001:db8::/32"},
+		AllPolicy: "fail",
+	}
+
+	value := assembleSPF(mechanisms)
+	if want := "v=spf1 ip4:192.0.2.0/24 ip6:2001:db8::/32 include:_spf.example.net include:spf.example.org -all"; value != want {
+		t.Errorf("assembleSPF() = %q, want %q", value, want)
+	}
+
+	parsed, err := parseSPF(value)
+	if err != nil {
+		t.Fatalf("parseSPF(%q): %v", value, err)
+	}
+	if !reflect.DeepEqual(parsed, mechanisms) {
+		t.Errorf("parseSPF(%q) = %+v, want %+v", value, parsed, mechanisms)
+	}
+}
+
+func TestParseSPF(t *testing.T) {
+	for _, tc := range []struct {
+		value     string
+		want      spfMechanisms
+		wantError bool
+	}{
+		{value: "v=spf1 ~all", want: spfMechanisms{AllPolicy: "softfail"}},
+		{value: "V=SPF1 +include:_spf.example.net ?ALL", want: spfMechanisms{Include: []string{"_spf.example.net"}, AllPolicy: "neutral"}},
+		{value: "v=spf1 ip4:192.0.2.1 ip4:198.51.100.0/24 -all", want: spfMechanisms{IP4: []string{"192.0.2.1", "198.51.100.0/24"}, AllPolicy: "fail"}},
+		{value: "v=spf1 mx -all", wantError: true},
+		{value: "v=spf1 redirect=_spf.example.net", wantError: true},
+		{value: "v=spf1 include:_spf.example.net", wantError: true},
+		{value: "v=spf1 +all", wantError: true},
+		{value: "v=spf1 -all include:_spf.example.net", wantError: true},
+		{value: "v=spf1 -include:_spf.example.net -all", wantError: true},
+		{value: "v=DKIM1; p=MIIB", wantError: true},
+	} {
+		mechanisms, err := parseSPF(tc.value)
+		if (err != nil) != tc.wantError {
+			t.Errorf("parseSPF(%q): got error %v, want error %t", tc.value, err, tc.wantError)
+			continue
+		}
+		if !tc.wantError && !reflect.DeepEqual(mechanisms, tc.want) {
+			t.Errorf("parseSPF(%q) = %+v, want %+v", tc.value, mechanisms, tc.want)
+		}
+	}
+}
+
+func TestIsSPFRecord(t *testing.T) {
+	for content, want := range map[string]bool{
+		`"v=spf1 -all"`:                   true,
+		`"v=spf1 include:" "_spf.x -all"`: true,
+		`v=spf1`:                          true,
+		`"v=spf10 -all"`:                  false,
+		`"google-site-verification=abc"`:  false,
+	} {
+		if got := isSPFRecord(DNSRecord{Type: "TXT", Content: content}); got != want {
+			t.Errorf("isSPFRecord(%s) = %t, want %t", content, got, want)
+		}
+	}
+}
+
+func TestSpfRecordResourceValidateConfig(t *testing.T) {
+	list := func(values ...string) tftypes.Value {
+		elements := []tftypes.Value{}
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, elements)
+	}
+	repeat := func(value string, n int) []string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = value
+		}
+		return values
+	}
+
+	for name, tc := range map[string]struct {
+		values    map[string]tftypes.Value
+		wantError string
+	}{
+		"valid": {values: map[string]tftypes.Value{
+			"include": list("_spf.example.net"),
+			"ip4":     list("192.0.2.1", "198.51.100.0/24"),
+			"ip6":     list("2001:db8::/32"),
+		}},
+		"policy only":      {values: map[string]tftypes.Value{}},
+		"IPv6 in ip4":      {values: map[string]tftypes.Value{"ip4": list("2001:db8::1")}, wantError: "Invalid SPF mechanism"},
+		"IPv4 in ip6":      {values: map[string]tftypes.Value{"ip6": list("::ffff:192.0.2.1")}, wantError: "Invalid SPF mechanism"},
+		"invalid network":  {values: map[string]tftypes.Value{"ip4": list("192.0.2.0/33")}, wantError: "Invalid SPF mechanism"},
+		"invalid include":  {values: map[string]tftypes.Value{"include": list("localhost")}, wantError: "Invalid SPF mechanism"},
+		"too many lookups": {values: map[string]tftypes.Value{"include": list(repeat("_spf.example.net", 11)...)}, wantError: "Too many SPF lookups"},
+		"pass":             {values: map[string]tftypes.Value{"all_policy": tftypes.NewValue(tftypes.String, "pass")}, wantError: "Invalid Attribute Value Match"},
+		// 24 mechanisms of 22 characters exceed 450 characters
+		"too long": {values: map[string]tftypes.Value{"ip4": list(repeat("198.51.100.128/25", 24)...)}, wantError: "SPF record too long"},
+	} {
+		values := map[string]tftypes.Value{"zone_id": tftypes.NewValue(tftypes.String, "1")}
+		for attribute, value := range tc.values {
+			values[attribute] = value
+		}
+
+		diags := testValidateResourceConfig(t, NewSpfRecordResource(), values)
+
+		var gotError string
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				gotError = d.Summary
+			}
+		}
+		if gotError != tc.wantError {
+			t.Errorf("%s: got error %q, want %q, diagnostics: %v", name, gotError, tc.wantError, diags)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// listAllRecordTemplates returns all records of a DNS template, page by
+// page like listAllRecords.
+// https://www.hosting.de/api/?json#listing-record-templates
+func (c *Client) listAllRecordTemplates(ctx context.Context, templateId string) ([]RecordTemplate, error) {
+	uri := c.baseURL + "/recordTemplatesFind"
+
+	recordTemplates := []RecordTemplate{}
+	for page := 1; ; page++ {
+		findRequest := RecordTemplatesFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter: FilterOrChain{Filter: Filter{
+				Field: "TemplateId",
+				Value: templateId,
+			}},
+			Limit: maxPageLimit,
+			Page:  page,
+		}
+
+		rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		findResponse, err := decodeEnvelope[RecordTemplatesFindResponse](ctx, uri, rawResp)
+		if err != nil {
+			return nil, err
+		}
+
+		recordTemplates = append(recordTemplates, findResponse.Response.Data...)
+
+		if len(recordTemplates) >= findResponse.Response.TotalEntries || len(findResponse.Response.Data) == 0 {
+			return recordTemplates, nil
+		}
+	}
+}
+
+// templateRecords returns the records a DNS template defines for the zone,
+// with the placeholders replaced by the zone name and the replacements of
+// the zone. Records with a placeholder the zone has no replacement for are
+// skipped, like hosting.de does when applying the template.
+func templateRecords(zoneConfig ZoneConfig, recordTemplates []RecordTemplate) ([]DNSRecord, error) {
+	var templateValues TemplateValues
+	if err := json.Unmarshal(zoneConfig.TemplateValues, &templateValues); err != nil {
+		return nil, fmt.Errorf("invalid template values of zone %s: %w", zoneConfig.Name, err)
+	}
+
+	var replacements TemplateReplacements
+	if len(templateValues.TemplateReplacements) > 0 {
+		if err := json.Unmarshal(templateValues.TemplateReplacements, &replacements); err != nil {
+			return nil, fmt.Errorf("invalid template replacements of zone %s: %w", zoneConfig.Name, err)
+		}
+	}
+
+	placeholders := map[string]string{
+		"##DOMAIN##":   zoneConfig.Name,
+		"##IPV4##":     replacements.IPv4Replacement,
+		"##IPV6##":     replacements.IPv6Replacement,
+		"##MAILIPV4##": replacements.MailIPv4Replacement,
+		"##MAILIPV6##": replacements.MailIPv6Replacement,
+	}
+
+	records := []DNSRecord{}
+	for _, recordTemplate := range recordTemplates {
+		name, nameOK := replacePlaceholders(recordTemplate.Name, placeholders)
+		content, contentOK := replacePlaceholders(recordTemplate.Content, placeholders)
+		if !nameOK || !contentOK {
+			continue
+		}
+
+		records = append(records, DNSRecord{
+			RecordTemplateID: recordTemplate.ID,
+			Name:             recordFQDN(name, zoneConfig.Name),
+			Type:             recordTemplate.Type,
+			Content:          content,
+			TTL:              recordTemplate.TTL,
+			Priority:         recordTemplate.Priority,
+		})
+	}
+
+	return records, nil
+}
+
+// replacePlaceholders replaces the template placeholders in the value. It
+// returns false if the value contains a placeholder without a replacement.
+func replacePlaceholders(value string, placeholders map[string]string) (string, bool) {
+	for placeholder, replacement := range placeholders {
+		if !strings.Contains(value, placeholder) {
+			continue
+		}
+		if replacement == "" {
+			return "", false
+		}
+		value = strings.ReplaceAll(value, placeholder, replacement)
+	}
+
+	return value, true
+}
+
+// templateRecordChanges returns the records to add and to modify so the zone
+// contains every record of its template. A zone record belongs to a template
+// record if it references it, or, failing that, if it has the same name, type
+// and content. Records that belong to a template record but differ from it are
+// changed back to the template, keeping their comments. Other records of the
+// zone, including records of template records that were removed from the
+// template, are left alone.
+func templateRecordChanges(templateRecords []DNSRecord, zoneRecords []DNSRecord) ([]DNSRecord, []DNSRecord) {
+	toAdd := []DNSRecord{}
+	toModify := []DNSRecord{}
+	for _, templateRecord := range templateRecords {
+		existing := matchTemplateRecord(templateRecord, zoneRecords)
+		if existing == nil {
+			toAdd = append(toAdd, templateRecord)
+			continue
+		}
+
+		if existing.Name == templateRecord.Name && existing.Content == templateRecord.Content &&
+			(templateRecord.TTL == 0 || existing.TTL == templateRecord.TTL) && existing.Priority == templateRecord.Priority {
+			continue
+		}
+
+		modified := *existing
+		modified.Name = templateRecord.Name
+		modified.Content = templateRecord.Content
+		modified.Priority = templateRecord.Priority
+		if templateRecord.TTL != 0 {
+			modified.TTL = templateRecord.TTL
+		}
+		toModify = append(toModify, modified)
+	}
+
+	return toAdd, toModify
+}
+
+// matchTemplateRecord returns the zone record belonging to the template
+// record, or nil if there is none.
+func matchTemplateRecord(templateRecord DNSRecord, zoneRecords []DNSRecord) *DNSRecord {
+	for i, record := range zoneRecords {
+		if record.RecordTemplateID != "" && record.RecordTemplateID == templateRecord.RecordTemplateID {
+			return &zoneRecords[i]
+		}
+	}
+	for i, record := range zoneRecords {
+		if record.Name == templateRecord.Name && record.Type == templateRecord.Type && record.Content == templateRecord.Content {
+			return &zoneRecords[i]
+		}
+	}
+
+	return nil
+}
+
+// reapplyTemplate adds and modifies the records of the zone so it contains
+// every record of its DNS template again, in a single recordsUpdate request.
+// Added records get the given comment. It returns the number of added and
+// modified records. If the API rejected single records, the numbers of the
+// other, applied records are returned along with the partial
+// RecordsUpdateError.
+func (c *Client) reapplyTemplate(ctx context.Context, zoneConfig ZoneConfig, comment string) (int, int, error) {
+	var templateValues TemplateValues
+	if len(zoneConfig.TemplateValues) > 0 {
+		if err := json.Unmarshal(zoneConfig.TemplateValues, &templateValues); err != nil {
+			return 0, 0, fmt.Errorf("invalid template values of zone %s: %w", zoneConfig.Name, err)
+		}
+	}
+	if templateValues.TemplateID == "" {
+		return 0, 0, fmt.Errorf("zone %s is not based on a template", zoneConfig.Name)
+	}
+
+	recordTemplates, err := c.listAllRecordTemplates(ctx, templateValues.TemplateID)
+	if err != nil {
+		return 0, 0, err
+	}
+	wanted, err := templateRecords(zoneConfig, recordTemplates)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	records, err := c.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: zoneConfig.ID,
+	}})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	toAdd, toModify := templateRecordChanges(wanted, records)
+	if len(toAdd) == 0 && len(toModify) == 0 {
+		return 0, 0, nil
+	}
+	for i := range toAdd {
+		toAdd[i].Comments = comment
+	}
+
+	_, err = c.updateRecords(ctx, RecordsUpdateRequest{
+		BaseRequest:     &BaseRequest{},
+		ZoneConfigId:    zoneConfig.ID,
+		RecordsToAdd:    toAdd,
+		RecordsToModify: toModify,
+	})
+	var updateErr *RecordsUpdateError
+	if errors.As(err, &updateErr) && updateErr.Partial {
+		return len(toAdd) - updateErr.failedCount("recordsToAdd"), len(toModify) - updateErr.failedCount("recordsToModify"), err
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return len(toAdd), len(toModify), nil
+}
@@ -0,0 +1,139 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTemplateRecords(t *testing.T) {
+	zoneConfig := ZoneConfig{
+		Name:           "example.test",
+		TemplateValues: json.RawMessage(`{"templateId": "t1", "templateReplacements": {"ipv4Replacement": "192.0.2.1"}}`),
+	}
+	recordTemplates := []RecordTemplate{
+		{ID: "1", Name: "##DOMAIN##", Type: "A", Content: "##IPV4##", TTL: 3600},
+		{ID: "2", Name: "www.##DOMAIN##", Type: "AAAA", Content: "##IPV6##", TTL: 3600},
+		{ID: "3", Name: "##DOMAIN##", Type: "MX", Content: "mail.##DOMAIN##", TTL: 3600, Priority: 10},
+		{ID: "4", Name: "www", Type: "CNAME", Content: "example.net", TTL: 300},
+	}
+
+	got, err := templateRecords(zoneConfig, recordTemplates)
+	if err != nil {
+		t.Fatalf("templateRecords returned an error: %v", err)
+	}
+
+	// The AAAA record is skipped, as the zone has no IPv6 replacement
+	want := []DNSRecord{
+		{RecordTemplateID: "1", Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{RecordTemplateID: "3", Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{RecordTemplateID: "4", Name: "www.example.test", Type: "CNAME", Content: "example.net", TTL: 300},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("templateRecords() =\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestTemplateRecordChanges(t *testing.T) {
+	templateRecords := []DNSRecord{
+		{RecordTemplateID: "1", Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{RecordTemplateID: "2", Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{RecordTemplateID: "3", Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+		{RecordTemplateID: "4", Name: "ftp.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+	}
+	zoneRecords := []DNSRecord{
+		// Changed manually, matched by its template record
+		{ID: "a", RecordTemplateID: "1", Name: "example.test", Type: "A", Content: "192.0.2.99", TTL: 3600, Comments: "manual"},
+		// Unchanged
+		{ID: "b", RecordTemplateID: "2", Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		// Created without the template, matched by name, type and content
+		{ID: "c", Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 60},
+		// Not part of the template
+		{ID: "d", Name: "other.example.test", Type: "A", Content: "192.0.2.2", TTL: 3600},
+	}
+
+	toAdd, toModify := templateRecordChanges(templateRecords, zoneRecords)
+
+	wantAdd := []DNSRecord{
+		{RecordTemplateID: "4", Name: "ftp.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+	}
+	if !reflect.DeepEqual(toAdd, wantAdd) {
+		t.Errorf("records to add =\n%+v\nwant:\n%+v", toAdd, wantAdd)
+	}
+
+	wantModify := []DNSRecord{
+		{ID: "a", RecordTemplateID: "1", Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 3600, Comments: "manual"},
+		{ID: "c", Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+	}
+	if !reflect.DeepEqual(toModify, wantModify) {
+		t.Errorf("records to modify =\n%+v\nwant:\n%+v", toModify, wantModify)
+	}
+}
+
+func TestReapplyTemplate(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/recordTemplatesFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordTemplatesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []RecordTemplate{
+				{ID: "1", Name: "##DOMAIN##", Type: "A", Content: "192.0.2.1", TTL: 3600},
+				{ID: "2", Name: "www.##DOMAIN##", Type: "CNAME", Content: "##DOMAIN##", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "a", RecordTemplateID: "1", Name: "example.test", Type: "A", Content: "192.0.2.99", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = len(findResponse.Response.Data)
+			return findResponse
+		},
+		"/recordsUpdate": func(t *testing.T, body []byte) any {
+			var updateRequest RecordsUpdateRequest
+			if err := json.Unmarshal(body, &updateRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+
+			if len(updateRequest.RecordsToAdd) != 1 || updateRequest.RecordsToAdd[0].Name != "www.example.test" ||
+				updateRequest.RecordsToAdd[0].Comments != "managed by terraform" {
+				t.Errorf("added records %+v, want www.example.test with the comment", updateRequest.RecordsToAdd)
+			}
+			if len(updateRequest.RecordsToModify) != 1 || updateRequest.RecordsToModify[0].Content != "192.0.2.1" {
+				t.Errorf("modified records %+v, want the A record changed back", updateRequest.RecordsToModify)
+			}
+			if len(updateRequest.RecordsToDelete) != 0 {
+				t.Errorf("deleted records %+v, want none", updateRequest.RecordsToDelete)
+			}
+
+			updateResponse := RecordsUpdateResponse{}
+			updateResponse.Status = "success"
+			return updateResponse
+		},
+	})
+
+	zoneConfig := ZoneConfig{
+		ID:             "1",
+		Name:           "example.test",
+		TemplateValues: json.RawMessage(`{"templateId": "t1"}`),
+	}
+	added, modified, err := client.reapplyTemplate(context.Background(), zoneConfig, "managed by terraform")
+	if err != nil {
+		t.Fatalf("reapplyTemplate returned an error: %v", err)
+	}
+	if added != 1 || modified != 1 {
+		t.Errorf("got %d added and %d modified records, want 1 and 1", added, modified)
+	}
+}
+
+func TestReapplyTemplateWithoutTemplate(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{})
+
+	_, _, err := client.reapplyTemplate(context.Background(), ZoneConfig{ID: "1", Name: "example.test"}, "")
+	if err == nil {
+		t.Error("reapplyTemplate returned no error for a zone without a template")
+	}
+}
@@ -0,0 +1,197 @@
+package hostingde
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                     = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigure        = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &zoneDataSource{}
+)
+
+// NewZoneDataSource is a helper function to simplify the provider implementation.
+func NewZoneDataSource() datasource.DataSource {
+	return &zoneDataSource{}
+}
+
+// zoneDataSource is the data source implementation.
+type zoneDataSource struct {
+	client *Client
+}
+
+// zoneDataSourceModel maps the ZoneConfig data source schema data.
+type zoneDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	EMailAddress   types.String `tfsdk:"email"`
+	DNSServerGroup types.String `tfsdk:"dns_server_group"`
+	RecordCount    types.Int64  `tfsdk:"record_count"`
+	RawJSON        types.String `tfsdk:"raw_json"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing DNS zone by ID or name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Numeric identifier of the zone. Exactly one of id or name must be set.",
+				Computed:    true,
+				Optional:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Domain name of the zone. Exactly one of id or name must be set.",
+				Computed:    true,
+				Optional:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "The zone type, one of NATIVE, MASTER, and SLAVE.",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "The hostmaster email address.",
+				Computed:    true,
+			},
+			"dns_server_group": schema.StringAttribute{
+				Description: "ID of the DNS server group serving the zone.",
+				Computed:    true,
+			},
+			"record_count": schema.Int64Attribute{
+				Description: "Number of records in the zone, as reported by the API's total count.",
+				Computed:    true,
+			},
+			"raw_json": schema.StringAttribute{
+				Description: "The zone config as returned by the API, as a JSON string. " +
+					"Use jsondecode to access fields the provider doesn't model yet. " +
+					"The content is defined by the hosting.de API and not covered by the stability guarantees of the provider.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators ensures exactly one lookup attribute is configured.
+func (d *zoneDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zoneDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := Filter{
+		Field: "ZoneConfigId",
+		Value: state.ID.ValueString(),
+	}
+	if !state.Name.IsNull() {
+		filter = Filter{
+			Field: "ZoneName",
+			Value: state.Name.ValueString(),
+		}
+	}
+
+	zoneConfigReq := ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter:      FilterOrChain{Filter: filter},
+		Limit:       1,
+		Page:        1,
+	}
+
+	zoneConfigResp, err := d.client.listZoneConfigs(ctx, zoneConfigReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+filter.Value+": "+err.Error(),
+		)
+		return
+	}
+
+	if len(zoneConfigResp.Response.Data) == 0 {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not find hosting.de DNS zone "+filter.Value,
+		)
+		return
+	}
+
+	zoneConfig := zoneConfigResp.Response.Data[0]
+
+	rawZoneConfigs, err := zoneConfigResp.rawZoneConfigs()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not decode hosting.de DNS zone "+filter.Value+": "+err.Error(),
+		)
+		return
+	}
+
+	var rawJSON bytes.Buffer
+	if err := json.Compact(&rawJSON, rawZoneConfigs[0]); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not decode hosting.de DNS zone "+filter.Value+": "+err.Error(),
+		)
+		return
+	}
+
+	recordCount, err := d.client.countRecords(ctx, zoneConfig.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not count records of hosting.de DNS zone ID "+zoneConfig.ID+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ID = types.StringValue(zoneConfig.ID)
+	state.Name = types.StringValue(zoneConfig.Name)
+	state.Type = types.StringValue(zoneConfig.Type)
+	state.EMailAddress = types.StringValue(zoneConfig.EMailAddress)
+	state.DNSServerGroup = types.StringValue(zoneConfig.DNSServerGroupID)
+	state.RecordCount = types.Int64Value(int64(recordCount))
+	state.RawJSON = types.StringValue(rawJSON.String())
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
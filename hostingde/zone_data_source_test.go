@@ -0,0 +1,70 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZoneDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example3.test"
+  type = "NATIVE"
+  email = "hostmaster@example3.test"
+}
+data "hostingde_zone" "test" {
+  name = hostingde_zone.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the data source found the zone.
+					resource.TestCheckResourceAttrPair("data.hostingde_zone.test", "id", "hostingde_zone.test", "id"),
+					resource.TestCheckResourceAttr("data.hostingde_zone.test", "type", "NATIVE"),
+					// Verify the record count matches the zone resource.
+					resource.TestCheckResourceAttrPair("data.hostingde_zone.test", "record_count", "hostingde_zone.test", "record_count"),
+					// Verify the DNS server group assigned by hosting.de matches the zone resource.
+					resource.TestCheckResourceAttrPair("data.hostingde_zone.test", "dns_server_group", "hostingde_zone.test", "dns_server_group"),
+					// Verify the raw zone config is exposed.
+					resource.TestCheckResourceAttrSet("data.hostingde_zone.test", "raw_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestRawZoneConfigs(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			return json.RawMessage(`{"status":"success","response":{"totalEntries":1,"data":[{"id":"1","name":"example.test","futureField":{"enabled":true}}]}}`)
+		},
+	})
+
+	findResponse, err := client.listZoneConfigs(context.Background(), ZoneConfigsFindRequest{BaseRequest: &BaseRequest{}})
+	if err != nil {
+		t.Fatalf("listZoneConfigs returned an error: %v", err)
+	}
+
+	rawZoneConfigs, err := findResponse.rawZoneConfigs()
+	if err != nil {
+		t.Fatalf("rawZoneConfigs returned an error: %v", err)
+	}
+	if len(rawZoneConfigs) != 1 {
+		t.Fatalf("got %d raw zone configs, want 1", len(rawZoneConfigs))
+	}
+
+	var zoneConfig map[string]any
+	if err := json.Unmarshal(rawZoneConfigs[0], &zoneConfig); err != nil {
+		t.Fatalf("could not decode raw zone config: %v", err)
+	}
+	if _, ok := zoneConfig["futureField"]; !ok {
+		t.Errorf("raw zone config %s is missing the unmodeled field futureField", rawZoneConfigs[0])
+	}
+}
@@ -0,0 +1,439 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneDriftDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneDriftDataSource{}
+)
+
+// NewZoneDriftDataSource is a helper function to simplify the provider implementation.
+func NewZoneDriftDataSource() datasource.DataSource {
+	return &zoneDriftDataSource{}
+}
+
+// zoneDriftDataSource is the data source implementation.
+type zoneDriftDataSource struct {
+	client *Client
+}
+
+// zoneDriftDataSourceModel maps the data source schema data.
+type zoneDriftDataSourceModel struct {
+	ZoneID     types.String             `tfsdk:"zone_id"`
+	Expected   []zoneDriftExpectedModel `tfsdk:"expected"`
+	OwnedBy    types.String             `tfsdk:"owned_by"`
+	Missing    []zoneDriftExpectedModel `tfsdk:"missing"`
+	Extra      []zoneDriftRecordModel   `tfsdk:"extra"`
+	Changed    []zoneDriftChangedModel  `tfsdk:"changed"`
+	DriftCount types.Int64              `tfsdk:"drift_count"`
+	InSync     types.Bool               `tfsdk:"in_sync"`
+}
+
+// zoneDriftExpectedModel maps a record expected in the zone.
+type zoneDriftExpectedModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// zoneDriftRecordModel maps a record of the zone that wasn't expected.
+type zoneDriftRecordModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// zoneDriftChangedModel maps a record of the zone that differs from the
+// expected record.
+type zoneDriftChangedModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Type             types.String `tfsdk:"type"`
+	Content          types.String `tfsdk:"content"`
+	TTL              types.Int64  `tfsdk:"ttl"`
+	Priority         types.Int64  `tfsdk:"priority"`
+	ExpectedContent  types.String `tfsdk:"expected_content"`
+	ExpectedTTL      types.Int64  `tfsdk:"expected_ttl"`
+	ExpectedPriority types.Int64  `tfsdk:"expected_priority"`
+	Fields           []string     `tfsdk:"fields"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneDriftDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_drift"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneDriftDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	recordAttributes := func(description string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Numeric identifier of the record.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the record relative to the zone, \"@\" for the zone apex.",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the record.",
+				Computed:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the record" + description + ", without the priority.",
+				Computed:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the record in seconds" + description + ".",
+				Computed:    true,
+			},
+			"priority": schema.Int64Attribute{
+				Description: "Priority of the record" + description + ". Zero for types without a priority.",
+				Computed:    true,
+			},
+		}
+	}
+
+	changedAttributes := recordAttributes(" in the zone")
+	changedAttributes["expected_content"] = schema.StringAttribute{
+		Description: "Expected content of the record.",
+		Computed:    true,
+	}
+	changedAttributes["expected_ttl"] = schema.Int64Attribute{
+		Description: "Expected TTL of the record. Null if the expected record sets no TTL.",
+		Computed:    true,
+	}
+	changedAttributes["expected_priority"] = schema.Int64Attribute{
+		Description: "Expected priority of the record. Null if the expected record sets no priority.",
+		Computed:    true,
+	}
+	changedAttributes["fields"] = schema.ListAttribute{
+		Description: "Fields that differ, some of content, ttl and priority.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Compares the records of a DNS zone with a set of expected records, for drift monitoring outside of terraform plan. " +
+			"Records are matched by name, type and content, with host names compared in punycode and TXT content by its data, " +
+			"ignoring quoting and splitting into strings. A missing and an extra record of the same name and type are reported as one changed record " +
+			"if they are the only unmatched records of that name and type. The SOA record and the NS records at the apex are never reported as extra.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Numeric identifier of the zone.",
+				Required:    true,
+			},
+			"expected": schema.ListNestedAttribute{
+				Description: "Records expected in the zone, for example the records of the hostingde_record resources of a configuration.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the record relative to the zone, \"@\" for the zone apex. A name ending with the zone name is taken as fully qualified.",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Required:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "Content of the record, without the priority. The placeholder ${zone} is replaced with the name of the zone.",
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "TTL of the record in seconds. The TTL isn't compared if unset.",
+							Optional:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the record. The priority isn't compared if unset.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"owned_by": schema.StringAttribute{
+				Description: "Only report records as extra whose comments contain this managed-by comment, like the managed_by_comment of the provider, " +
+					"so records of other tools in a shared zone are ignored. Records created while no managed_by_comment was set have no marker and are ignored too.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"missing": schema.ListNestedAttribute{
+				Description: "Expected records not found in the zone, in the order of expected.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the record as expected.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Computed:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "Content of the record as expected.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Expected TTL of the record. Null if unset.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Expected priority of the record. Null if unset.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"extra": schema.ListNestedAttribute{
+				Description: "Records of the zone that aren't expected.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordAttributes(""),
+				},
+			},
+			"changed": schema.ListNestedAttribute{
+				Description: "Records of the zone that differ from the expected record in content, TTL or priority.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: changedAttributes,
+				},
+			},
+			"drift_count": schema.Int64Attribute{
+				Description: "Number of missing, extra and changed records.",
+				Computed:    true,
+			},
+			"in_sync": schema.BoolAttribute{
+				Description: "Whether the zone matches the expected records, i.e. drift_count is zero.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneDriftDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zoneDriftDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfig, err := d.client.getZoneConfig(ctx, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: state.ZoneID.ValueString(),
+	}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	warnUnknownRecordTypes(ctx, records)
+
+	state.Missing, state.Extra, state.Changed = zoneDrift(state.Expected, records, zoneConfig.Name, state.OwnedBy)
+	driftCount := len(state.Missing) + len(state.Extra) + len(state.Changed)
+	state.DriftCount = types.Int64Value(int64(driftCount))
+	state.InSync = types.BoolValue(driftCount == 0)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// zoneDriftKey identifies a record by name and type, and its content in the
+// form the records are compared in.
+type zoneDriftKey struct {
+	name       string
+	recordType string
+	content    string
+}
+
+// driftName returns the fully qualified name of a record in the form it is
+// compared in, in lower case punycode.
+func driftName(name string, zoneName string) string {
+	fqdn := recordFQDN(name, zoneName)
+	if ascii, err := asciiHostname(fqdn); err == nil {
+		fqdn = ascii
+	}
+
+	return strings.ToLower(fqdn)
+}
+
+// driftContent returns the content of a record in the form it is compared in.
+// TXT content is compared by its data, other content as normalizeContent
+// sends it to the API.
+func driftContent(recordType string, content string) string {
+	content = strings.TrimSpace(content)
+	if recordType == "TXT" {
+		return txtValue(content)
+	}
+
+	return normalizeContent(recordType, content)
+}
+
+// zoneDrift compares the records of a zone with the expected records. Each
+// record of the zone matches at most one expected record. Records not
+// matched by content are paired as changed if they are the only unmatched
+// records of their name and type on both sides.
+func zoneDrift(expected []zoneDriftExpectedModel, records []DNSRecord, zoneName string, ownedBy types.String) ([]zoneDriftExpectedModel, []zoneDriftRecordModel, []zoneDriftChangedModel) {
+	missing := []zoneDriftExpectedModel{}
+	extra := []zoneDriftRecordModel{}
+	changed := []zoneDriftChangedModel{}
+
+	// Unmatched records of the zone by key, the system records are managed
+	// by hosting.de and never expected
+	unmatched := map[zoneDriftKey][]DNSRecord{}
+	var order []zoneDriftKey
+	for _, record := range records {
+		if isSystemRecord(record, zoneName) {
+			continue
+		}
+		content, _ := splitPriority(record)
+		key := zoneDriftKey{name: driftName(record.Name, zoneName), recordType: record.Type, content: driftContent(record.Type, content)}
+		if _, ok := unmatched[key]; !ok {
+			order = append(order, key)
+		}
+		unmatched[key] = append(unmatched[key], record)
+	}
+
+	var unmatchedExpected []zoneDriftExpectedModel
+	for _, want := range expected {
+		recordType := strings.ToUpper(want.Type.ValueString())
+		content := expandZonePlaceholder(want.Content.ValueString(), zoneName)
+		key := zoneDriftKey{
+			name:       driftName(want.Name.ValueString(), zoneName),
+			recordType: recordType,
+			content:    driftContent(recordType, content),
+		}
+		if len(unmatched[key]) == 0 {
+			unmatchedExpected = append(unmatchedExpected, want)
+			continue
+		}
+
+		record := unmatched[key][0]
+		unmatched[key] = unmatched[key][1:]
+		if change, ok := zoneDriftChange(want, record, zoneName); ok {
+			changed = append(changed, change)
+		}
+	}
+
+	var unmatchedRecords []DNSRecord
+	for _, key := range order {
+		unmatchedRecords = append(unmatchedRecords, unmatched[key]...)
+	}
+
+	// Pair the remaining records of the same name and type, if unambiguous
+	type nameType struct{ name, recordType string }
+	countExpected := map[nameType]int{}
+	for _, want := range unmatchedExpected {
+		countExpected[nameType{driftName(want.Name.ValueString(), zoneName), strings.ToUpper(want.Type.ValueString())}]++
+	}
+	countRecords := map[nameType]int{}
+	for _, record := range unmatchedRecords {
+		countRecords[nameType{driftName(record.Name, zoneName), record.Type}]++
+	}
+	paired := map[nameType]DNSRecord{}
+	for _, record := range unmatchedRecords {
+		key := nameType{driftName(record.Name, zoneName), record.Type}
+		if countExpected[key] == 1 && countRecords[key] == 1 {
+			paired[key] = record
+			continue
+		}
+		if !ownedBy.IsNull() && !hasManagedComment(record, ownedBy.ValueString()) {
+			continue
+		}
+		content, priority := splitPriority(record)
+		extra = append(extra, zoneDriftRecordModel{
+			ID:       types.StringValue(record.ID),
+			Name:     types.StringValue(relativeRecordName(record.Name, zoneName)),
+			Type:     types.StringValue(record.Type),
+			Content:  types.StringValue(content),
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Priority: types.Int64Value(priority),
+		})
+	}
+
+	for _, want := range unmatchedExpected {
+		key := nameType{driftName(want.Name.ValueString(), zoneName), strings.ToUpper(want.Type.ValueString())}
+		record, ok := paired[key]
+		if !ok {
+			missing = append(missing, want)
+			continue
+		}
+		change, _ := zoneDriftChange(want, record, zoneName)
+		changed = append(changed, change)
+	}
+
+	return missing, extra, changed
+}
+
+// zoneDriftChange compares a record of the zone with the expected record.
+// It returns false if they don't differ.
+func zoneDriftChange(want zoneDriftExpectedModel, record DNSRecord, zoneName string) (zoneDriftChangedModel, bool) {
+	content, priority := splitPriority(record)
+
+	var fields []string
+	if driftContent(record.Type, content) != driftContent(record.Type, expandZonePlaceholder(want.Content.ValueString(), zoneName)) {
+		fields = append(fields, "content")
+	}
+	if !want.TTL.IsNull() && want.TTL.ValueInt64() != int64(record.TTL) {
+		fields = append(fields, "ttl")
+	}
+	if !want.Priority.IsNull() && want.Priority.ValueInt64() != priority {
+		fields = append(fields, "priority")
+	}
+
+	return zoneDriftChangedModel{
+		ID:               types.StringValue(record.ID),
+		Name:             types.StringValue(relativeRecordName(record.Name, zoneName)),
+		Type:             types.StringValue(record.Type),
+		Content:          types.StringValue(content),
+		TTL:              types.Int64Value(int64(record.TTL)),
+		Priority:         types.Int64Value(priority),
+		ExpectedContent:  want.Content,
+		ExpectedTTL:      want.TTL,
+		ExpectedPriority: want.Priority,
+		Fields:           fields,
+	}, len(fields) > 0
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneDriftDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
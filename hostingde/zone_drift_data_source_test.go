@@ -0,0 +1,112 @@
+package hostingde
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZoneDriftDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example11.test"
+  type = "NATIVE"
+  email = "hostmaster@example11.test"
+}
+resource "hostingde_record" "www" {
+  zone_id = hostingde_zone.test.id
+  name = "www"
+  type = "A"
+  content = "192.0.2.1"
+  ttl = 300
+}
+data "hostingde_zone_drift" "test" {
+  zone_id = hostingde_zone.test.id
+  expected = [
+    { name = "www", type = "A", content = "192.0.2.2", ttl = 300 },
+    { name = "mail", type = "A", content = "192.0.2.3" },
+  ]
+
+  depends_on = [hostingde_record.www]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the changed content of www is reported.
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "changed.#", "1"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "changed.0.content", "192.0.2.1"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "changed.0.expected_content", "192.0.2.2"),
+					// Verify the missing mail record is reported.
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "missing.#", "1"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "missing.0.name", "mail"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_drift.test", "in_sync", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestZoneDrift(t *testing.T) {
+	expected := []zoneDriftExpectedModel{
+		// In sync, with the content in another form
+		{Name: types.StringValue("@"), Type: types.StringValue("TXT"), Content: types.StringValue(`"v=spf1 " "-all"`)},
+		{Name: types.StringValue("www"), Type: types.StringValue("CNAME"), Content: types.StringValue("WEB.${zone}")},
+		// Changed TTL, a missing TTL isn't compared
+		{Name: types.StringValue("mail"), Type: types.StringValue("MX"), Content: types.StringValue("mx.example.test"), TTL: types.Int64Value(300), Priority: types.Int64Value(10)},
+		{Name: types.StringValue("api"), Type: types.StringValue("A"), Content: types.StringValue("192.0.2.1")},
+		// Changed content, the only A record of the name on both sides
+		{Name: types.StringValue("app.example.test"), Type: types.StringValue("A"), Content: types.StringValue("192.0.2.2"), TTL: types.Int64Null(), Priority: types.Int64Null()},
+		// Missing, as two records of the name remain
+		{Name: types.StringValue("lb"), Type: types.StringValue("A"), Content: types.StringValue("192.0.2.10")},
+	}
+	records := []DNSRecord{
+		{ID: "1", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 2 3 4 5"},
+		{ID: "2", Name: "example.test", Type: "NS", Content: "ns1.example.test"},
+		{ID: "3", Name: "example.test", Type: "TXT", Content: `"v=spf1 -all"`},
+		{ID: "4", Name: "www.example.test", Type: "CNAME", Content: "web.example.test"},
+		{ID: "5", Name: "mail.example.test", Type: "MX", Content: "mx.example.test", TTL: 3600, Priority: 10},
+		{ID: "6", Name: "api.example.test", Type: "A", Content: "192.0.2.1", TTL: 60},
+		{ID: "7", Name: "app.example.test", Type: "A", Content: "192.0.2.3", TTL: 3600},
+		{ID: "8", Name: "lb.example.test", Type: "A", Content: "192.0.2.11", TTL: 3600},
+		{ID: "9", Name: "lb.example.test", Type: "A", Content: "192.0.2.12", TTL: 3600, Comments: "managed by Terraform"},
+	}
+
+	missing, extra, changed := zoneDrift(expected, records, "example.test", types.StringNull())
+
+	if len(missing) != 1 || missing[0].Name.ValueString() != "lb" {
+		t.Errorf("got missing records %v, want lb", missing)
+	}
+	if fmt.Sprint(recordIDs(extra)) != "[8 9]" {
+		t.Errorf("got extra records %v, want 8 and 9", recordIDs(extra))
+	}
+	if len(changed) != 2 {
+		t.Fatalf("got %d changed records, want 2: %v", len(changed), changed)
+	}
+	if got := changed[0]; got.ID.ValueString() != "5" || fmt.Sprint(got.Fields) != "[ttl]" {
+		t.Errorf("got changed record %s with fields %v, want 5 with ttl", got.ID, got.Fields)
+	}
+	if got := changed[1]; got.ID.ValueString() != "7" || fmt.Sprint(got.Fields) != "[content]" || got.Name.ValueString() != "app" {
+		t.Errorf("got changed record %s %s with fields %v, want 7 app with content", got.ID, got.Name, got.Fields)
+	}
+
+	// Records of other tools aren't extra
+	_, extra, _ = zoneDrift(expected, records, "example.test", types.StringValue("managed by Terraform"))
+	if fmt.Sprint(recordIDs(extra)) != "[9]" {
+		t.Errorf("got extra records %v, want only the owned record 9", recordIDs(extra))
+	}
+}
+
+func recordIDs(records []zoneDriftRecordModel) []string {
+	ids := []string{}
+	for _, record := range records {
+		ids = append(ids, record.ID.ValueString())
+	}
+
+	return ids
+}
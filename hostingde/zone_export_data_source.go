@@ -0,0 +1,191 @@
+package hostingde
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneExportDataSource{}
+)
+
+// NewZoneExportDataSource is a helper function to simplify the provider implementation.
+func NewZoneExportDataSource() datasource.DataSource {
+	return &zoneExportDataSource{}
+}
+
+// zoneExportDataSource is the data source implementation.
+type zoneExportDataSource struct {
+	client *Client
+}
+
+// zoneExportDataSourceModel maps the data source schema data.
+type zoneExportDataSourceModel struct {
+	ZoneName types.String `tfsdk:"zone_name"`
+	Zonefile types.String `tfsdk:"zonefile"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports the records of a DNS zone in BIND master file format, for backups or comparisons.",
+		Attributes: map[string]schema.Attribute{
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the zone.",
+				Required:    true,
+			},
+			"zonefile": schema.StringAttribute{
+				Description: "The zone in BIND master file format. Names are absolute, the SOA and apex NS records come first. " +
+					"ALIAS records have no equivalent in the format and are included as comments.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zoneExportDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfig, err := d.client.getZoneConfigByName(ctx, state.ZoneName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: zoneConfig.ID,
+	}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	warnUnknownRecordTypes(ctx, records)
+
+	state.Zonefile = types.StringValue(renderZonefile(zoneConfig.Name, records))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// renderZonefile renders records in BIND master file format. The SOA record
+// comes first, followed by the NS records at the apex and all other records
+// sorted by name and type.
+func renderZonefile(zoneName string, records []DNSRecord) string {
+	records = slices.Clone(records)
+	rank := func(record DNSRecord) int {
+		switch {
+		case record.Type == "SOA":
+			return 0
+		case record.Type == "NS" && record.Name == zoneName:
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Content < b.Content
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", absoluteName(zoneName))
+	for _, record := range records {
+		recordType, rdata := zonefileRecordData(record)
+		line := fmt.Sprintf("%s %d IN %s %s\n", absoluteName(record.Name), record.TTL, recordType, rdata)
+		// The master file format has no equivalent of ALIAS records
+		if record.Type == "ALIAS" {
+			line = "; " + line
+		}
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// zonefileRecordData returns the type and the record data of a record as
+// written in a master file. Target names are made absolute, as the API
+// returns them without the trailing dot.
+func zonefileRecordData(record DNSRecord) (string, string) {
+	switch record.Type {
+	case "CNAME", "NS", "PTR", "ALIAS":
+		return record.Type, absoluteName(record.Content)
+	case "MX":
+		return record.Type, strconv.Itoa(record.Priority) + " " + absoluteName(record.Content)
+	case "NULLMX":
+		return "MX", "0 ."
+	case "SRV":
+		// The content holds weight, port and target
+		fields := strings.Fields(record.Content)
+		if len(fields) == 3 {
+			fields[2] = absoluteName(fields[2])
+		}
+		return record.Type, strconv.Itoa(record.Priority) + " " + strings.Join(fields, " ")
+	case "TXT":
+		if strings.HasPrefix(record.Content, `"`) {
+			return record.Type, record.Content
+		}
+		return record.Type, `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(record.Content) + `"`
+	default:
+		return record.Type, record.Content
+	}
+}
+
+// absoluteName returns a domain name with a trailing dot.
+func absoluteName(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
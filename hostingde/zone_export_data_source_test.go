@@ -0,0 +1,34 @@
+package hostingde
+
+import "testing"
+
+func TestRenderZonefile(t *testing.T) {
+	records := []DNSRecord{
+		{Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+		{Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{Name: "example.test", Type: "NS", Content: "ns2.example.net", TTL: 86400},
+		{Name: "example.test", Type: "TXT", Content: `v=spf1 -all "quoted"`, TTL: 3600},
+		{Name: "_sip._udp.example.test", Type: "SRV", Content: "5 5060 sip.example.test", TTL: 3600, Priority: 20},
+		{Name: "example.test", Type: "SOA", Content: "ns1.example.net. hostmaster.example.test. 2024010101 86400 7200 3600000 3600", TTL: 86400},
+		{Name: "example.test", Type: "NS", Content: "ns1.example.net", TTL: 86400},
+		{Name: "alias.example.test", Type: "ALIAS", Content: "target.example.net", TTL: 3600},
+		{Name: "nomail.example.test", Type: "NULLMX", TTL: 3600},
+		{Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 60},
+	}
+
+	want := `$ORIGIN example.test.
+example.test. 86400 IN SOA ns1.example.net. hostmaster.example.test. 2024010101 86400 7200 3600000 3600
+example.test. 86400 IN NS ns1.example.net.
+example.test. 86400 IN NS ns2.example.net.
+_sip._udp.example.test. 3600 IN SRV 20 5 5060 sip.example.test.
+; alias.example.test. 3600 IN ALIAS target.example.net.
+example.test. 60 IN A 192.0.2.1
+example.test. 3600 IN MX 10 mail.example.test.
+example.test. 3600 IN TXT "v=spf1 -all \"quoted\""
+nomail.example.test. 3600 IN MX 0 .
+www.example.test. 3600 IN CNAME example.test.
+`
+	if got := renderZonefile("example.test", records); got != want {
+		t.Errorf("renderZonefile() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,264 @@
+package hostingde
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneRecordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneRecordsDataSource{}
+)
+
+// NewZoneRecordsDataSource is a helper function to simplify the provider implementation.
+func NewZoneRecordsDataSource() datasource.DataSource {
+	return &zoneRecordsDataSource{}
+}
+
+// zoneRecordsDataSource is the data source implementation.
+type zoneRecordsDataSource struct {
+	client *Client
+}
+
+// zoneRecordsDataSourceModel maps the data source schema data.
+type zoneRecordsDataSourceModel struct {
+	ZoneID          types.String             `tfsdk:"zone_id"`
+	MinTTL          types.Int64              `tfsdk:"min_ttl"`
+	MaxTTL          types.Int64              `tfsdk:"max_ttl"`
+	OwnedBy         types.String             `tfsdk:"owned_by"`
+	ContentContains types.String             `tfsdk:"content_contains"`
+	Records         []zoneRecordsRecordModel `tfsdk:"records"`
+}
+
+// zoneRecordsRecordModel maps a single record of the data source.
+type zoneRecordsRecordModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Content  types.String `tfsdk:"content"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+
+	AddDate        types.String `tfsdk:"add_date"`
+	LastChangeDate types.String `tfsdk:"last_change_date"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_records"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the records of a DNS zone, including records not managed by Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"zone_id": schema.StringAttribute{
+				Description: "Numeric identifier of the zone.",
+				Required:    true,
+			},
+			"min_ttl": schema.Int64Attribute{
+				Description: "Only return records with a TTL of at least this many seconds. " +
+					"The filter is applied after fetching all records of the zone.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_ttl": schema.Int64Attribute{
+				Description: "Only return records with a TTL of at most this many seconds. " +
+					"The filter is applied after fetching all records of the zone.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"owned_by": schema.StringAttribute{
+				Description: "Only return records whose comments contain this managed-by comment, like the managed_by_comment of the provider " +
+					"that created them. Records created while no managed_by_comment was set have no marker and never match. " +
+					"The filter is applied after fetching all records of the zone.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"content_contains": schema.StringAttribute{
+				Description: "Only return records whose content contains this text, for example an IP address of a server to decommission. " +
+					"Matching is a plain substring match that ignores case, without wildcards or regular expressions. " +
+					"The content includes the priority of NAPTR and URI records. " +
+					"The filter is applied after fetching all records of the zone.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "Records of the zone matching the filters. Empty if no record matches.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the record.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the record.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the record.",
+							Computed:    true,
+						},
+						"content": schema.StringAttribute{
+							Description: "Content of the record, without the priority.",
+							Computed:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "TTL of the record in seconds.",
+							Computed:    true,
+						},
+						"priority": schema.Int64Attribute{
+							Description: "Priority of the record. Zero for types without a priority.",
+							Computed:    true,
+						},
+						"read_only": schema.BoolAttribute{
+							Description: "Whether hosting.de manages the record for the zone itself, i.e. the SOA record and the NS records at the apex. " +
+								"These can't be managed with hostingde_record. NS records that aren't read-only delegate a subzone.",
+							Computed: true,
+						},
+						"add_date": schema.StringAttribute{
+							Description: "Time the record was created, as reported by the API. Null if the API doesn't return it.",
+							Computed:    true,
+						},
+						"last_change_date": schema.StringAttribute{
+							Description: "Time of the last change to the record, as reported by the API. Null if the API doesn't return it.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zoneRecordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: state.ZoneID.ValueString(),
+	}})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	warnUnknownRecordTypes(ctx, records)
+
+	// Needed to tell the zone's own NS records from delegations
+	zoneConfig, err := d.client.getZoneConfig(ctx, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone ID "+state.ZoneID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Records = []zoneRecordsRecordModel{}
+	records = filterRecordsByContent(filterRecordsByOwner(filterRecordsByTTL(records, state.MinTTL, state.MaxTTL), state.OwnedBy), state.ContentContains)
+	for _, record := range records {
+		content, priority := splitPriority(record)
+		state.Records = append(state.Records, zoneRecordsRecordModel{
+			ID:       types.StringValue(record.ID),
+			Name:     types.StringValue(record.Name),
+			Type:     types.StringValue(record.Type),
+			Content:  types.StringValue(content),
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Priority: types.Int64Value(priority),
+			ReadOnly: types.BoolValue(isSystemRecord(record, zoneConfig.Name)),
+
+			AddDate:        recordDate(record.AddDate),
+			LastChangeDate: recordDate(record.LastChangeDate),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// filterRecordsByTTL returns the records whose TTL lies within the given
+// bounds. Null bounds don't restrict the TTL.
+func filterRecordsByTTL(records []DNSRecord, minTTL types.Int64, maxTTL types.Int64) []DNSRecord {
+	filtered := []DNSRecord{}
+	for _, record := range records {
+		if !minTTL.IsNull() && int64(record.TTL) < minTTL.ValueInt64() {
+			continue
+		}
+		if !maxTTL.IsNull() && int64(record.TTL) > maxTTL.ValueInt64() {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	return filtered
+}
+
+// filterRecordsByOwner returns the records whose comments contain the
+// managed-by comment. A null comment doesn't restrict the records.
+func filterRecordsByOwner(records []DNSRecord, ownedBy types.String) []DNSRecord {
+	if ownedBy.IsNull() {
+		return records
+	}
+
+	filtered := []DNSRecord{}
+	for _, record := range records {
+		if hasManagedComment(record, ownedBy.ValueString()) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return filtered
+}
+
+// filterRecordsByContent returns the records whose content contains the text,
+// ignoring case. A null text doesn't restrict the records.
+func filterRecordsByContent(records []DNSRecord, contains types.String) []DNSRecord {
+	if contains.IsNull() {
+		return records
+	}
+
+	text := strings.ToLower(contains.ValueString())
+	filtered := []DNSRecord{}
+	for _, record := range records {
+		if strings.Contains(strings.ToLower(record.Content), text) {
+			filtered = append(filtered, record)
+		}
+	}
+
+	return filtered
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
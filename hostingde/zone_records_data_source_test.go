@@ -0,0 +1,138 @@
+package hostingde
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZoneRecordsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example4.test"
+  type = "NATIVE"
+  email = "hostmaster@example4.test"
+}
+resource "hostingde_record" "test" {
+  zone_id = hostingde_zone.test.id
+  name = "low.example4.test"
+  type = "A"
+  content = "192.0.2.1"
+  ttl = 60
+}
+data "hostingde_zone_records" "low_ttl" {
+  zone_id = hostingde_record.test.zone_id
+  max_ttl = 60
+}
+data "hostingde_zone_records" "none" {
+  zone_id = hostingde_record.test.zone_id
+  min_ttl = 31556926
+  max_ttl = 31556926
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify only the low TTL record matches.
+					resource.TestCheckResourceAttr("data.hostingde_zone_records.low_ttl", "records.#", "1"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_records.low_ttl", "records.0.name", "low.example4.test"),
+					// Verify an empty list is returned if nothing matches.
+					resource.TestCheckResourceAttr("data.hostingde_zone_records.none", "records.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterRecordsByTTL(t *testing.T) {
+	records := []DNSRecord{
+		{ID: "1", TTL: 60},
+		{ID: "2", TTL: 300},
+		{ID: "3", TTL: 3600},
+	}
+
+	tests := []struct {
+		name   string
+		minTTL types.Int64
+		maxTTL types.Int64
+		want   []string
+	}{
+		{"no bounds", types.Int64Null(), types.Int64Null(), []string{"1", "2", "3"}},
+		{"min only", types.Int64Value(300), types.Int64Null(), []string{"2", "3"}},
+		{"max only", types.Int64Null(), types.Int64Value(300), []string{"1", "2"}},
+		{"range", types.Int64Value(61), types.Int64Value(3599), []string{"2"}},
+		{"no match", types.Int64Value(7200), types.Int64Null(), []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, record := range filterRecordsByTTL(records, tt.minTTL, tt.maxTTL) {
+				got = append(got, record.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got records %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRecordsByOwner(t *testing.T) {
+	records := []DNSRecord{
+		{ID: "1", Comments: "team-a"},
+		{ID: "2", Comments: "team-b"},
+		{ID: "3", Comments: "team-a, ticket 42"},
+		{ID: "4"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		ownedBy types.String
+		want    []string
+	}{
+		{name: "no filter", ownedBy: types.StringNull(), want: []string{"1", "2", "3", "4"}},
+		{name: "owner", ownedBy: types.StringValue("team-a"), want: []string{"1", "3"}},
+		{name: "no match", ownedBy: types.StringValue("team-c"), want: []string{}},
+	} {
+		got := []string{}
+		for _, record := range filterRecordsByOwner(records, tc.ownedBy) {
+			got = append(got, record.ID)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got records %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFilterRecordsByContent(t *testing.T) {
+	records := []DNSRecord{
+		{ID: "1", Type: "A", Content: "192.0.2.10"},
+		{ID: "2", Type: "A", Content: "192.0.2.1"},
+		{ID: "3", Type: "CNAME", Content: "Old-Server.example.test"},
+		{ID: "4", Type: "TXT", Content: `"v=spf1 ip4:192.0.2.1 -all"`},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		contains types.String
+		want     []string
+	}{
+		{name: "no filter", contains: types.StringNull(), want: []string{"1", "2", "3", "4"}},
+		{name: "substring", contains: types.StringValue("192.0.2.1"), want: []string{"1", "2", "4"}},
+		{name: "ignores case", contains: types.StringValue("old-server"), want: []string{"3"}},
+		{name: "no wildcards", contains: types.StringValue("192.0.2.*"), want: []string{}},
+	} {
+		got := []string{}
+		for _, record := range filterRecordsByContent(records, tc.contains) {
+			got = append(got, record.ID)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got records %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -2,20 +2,109 @@ package hostingde
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/miekg/dns"
 )
 
+// nameserverSetNameValidator validates the name of a nameserver set.
+var nameserverSetNameValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 ._-]*$`),
+	"must start with a letter or digit and may only contain letters, digits, spaces, dots, underscores and hyphens",
+)
+
+// caaIssuerValidator validates the domain name of a certificate authority
+// in a CAA issue property.
+var caaIssuerValidator = stringvalidator.RegexMatches(
+	regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)+$`),
+	"must be the domain name of a certificate authority, like letsencrypt.org",
+)
+
+// ipAddressValidator validates that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if net.ParseIP(req.ConfigValue.ValueString()) == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP address",
+			"The value must be an IPv4 or IPv6 address, got: "+req.ConfigValue.ValueString(),
+		)
+	}
+}
+
+// emailAddressValidator validates that a string is a plain email address,
+// without a display name.
+type emailAddressValidator struct{}
+
+func (v emailAddressValidator) Description(_ context.Context) string {
+	return "value must be an email address like hostmaster@example.com"
+}
+
+func (v emailAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	// The domain needs a dot, hostmaster@localhost is no public address
+	value := req.ConfigValue.ValueString()
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value || !strings.Contains(value[strings.LastIndex(value, "@")+1:], ".") {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid email address",
+			"The value must be an email address like hostmaster@example.com, without a display name, got: "+value,
+		)
+	}
+}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &zoneResource{}
-	_ resource.ResourceWithConfigure   = &zoneResource{}
-	_ resource.ResourceWithImportState = &zoneResource{}
+	_ resource.Resource                   = &zoneResource{}
+	_ resource.ResourceWithConfigure      = &zoneResource{}
+	_ resource.ResourceWithImportState    = &zoneResource{}
+	_ resource.ResourceWithValidateConfig = &zoneResource{}
+	_ resource.ResourceWithModifyPlan     = &zoneResource{}
 )
 
 // NewZoneResource is a helper function to simplify the provider implementation.
@@ -30,12 +119,70 @@ type zoneResource struct {
 
 // zoneResourceModel maps the ZoneConfig resource schema data.
 type zoneResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Type         types.String `tfsdk:"type"`
-	EMailAddress types.String `tfsdk:"email"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	EMailAddress    types.String `tfsdk:"email"`
+	NameserverSet   types.String `tfsdk:"nameserver_set"`
+	DNSServerGroup  types.String `tfsdk:"dns_server_group"`
+	MasterIPs       types.List   `tfsdk:"master_ips"`
+	Zonefile        types.String `tfsdk:"zonefile"`
+	RecordsJSON     types.String `tfsdk:"records_json"`
+	Nameservers     types.List   `tfsdk:"nameservers"`
+	RecordCount     types.Int64  `tfsdk:"record_count"`
+	AppliedTemplate types.String `tfsdk:"applied_template"`
+	ReapplyTemplate types.Bool   `tfsdk:"reapply_template"`
+	Ready           types.Bool   `tfsdk:"ready"`
+
+	EnforceMinTTL      types.Int64 `tfsdk:"enforce_min_ttl"`
+	RecordsBelowMinTTL types.Int64 `tfsdk:"records_below_min_ttl"`
+
+	DNSSEC                 *zoneDNSSECModel `tfsdk:"dnssec"`
+	DNSSECMode             types.String     `tfsdk:"dnssec_mode"`
+	DNSSECKeysTimeout      types.String     `tfsdk:"dnssec_keys_timeout"`
+	ReadyTimeout           types.String     `tfsdk:"ready_timeout"`
+	DeleteRecordsOnDestroy types.Bool       `tfsdk:"delete_records_on_destroy"`
+	DeletionProtection     types.Bool       `tfsdk:"deletion_protection"`
+	DefaultCAAIssuer       types.String     `tfsdk:"default_caa_issuer"`
 }
 
+// zoneDNSSECModel maps the DNSSEC options of a zone.
+type zoneDNSSECModel struct {
+	Algorithm            types.String `tfsdk:"algorithm"`
+	NSECMode             types.String `tfsdk:"nsec_mode"`
+	PublishDSAtRegistrar types.Bool   `tfsdk:"publish_ds_at_registrar"`
+	DSPublishStatus      types.String `tfsdk:"ds_publish_status"`
+
+	Keys     []zoneDNSSECKeyModel `tfsdk:"keys"`
+	DSRecord types.String         `tfsdk:"ds_record"`
+}
+
+// zoneDNSSECKeyModel maps a DNSSEC key supplied for a zone in manual mode.
+type zoneDNSSECKeyModel struct {
+	Flags     types.Int64  `tfsdk:"flags"`
+	Algorithm types.Int64  `tfsdk:"algorithm"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+// dnssecAlgorithms are the DNSSEC signing algorithms supported by hosting.de.
+var dnssecAlgorithms = []string{
+	"RSASHA256",
+	"RSASHA512",
+	"ECDSAP256SHA256",
+	"ECDSAP384SHA384",
+	"ED25519",
+}
+
+// zoneTypes are the zone types supported by hosting.de.
+var zoneTypes = []string{"NATIVE", "MASTER", "SLAVE"}
+
+// defaultDNSSECAlgorithm and defaultNSECMode are used for zones enabling
+// DNSSEC without configuring them, unless the provider sets zone_defaults.
+const (
+	defaultDNSSECAlgorithm = "ECDSAP256SHA256"
+	defaultNSECMode        = "nsec3"
+)
+
 // Metadata returns the resource type name.
 func (r *zoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_zone"
@@ -57,27 +204,266 @@ func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "The zone type. Valid types are NATIVE, MASTER, and SLAVE. Changing this forces re-creation of the zone.",
-				Required:    true,
+				Description: "The zone type. Valid types are NATIVE, MASTER, and SLAVE. Defaults to the type of the provider's zone_defaults, or NATIVE. " +
+					"Changing this forces re-creation of the zone.",
+				Computed: true,
+				Optional: true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"email": schema.StringAttribute{
-				Description: "The hostmaster email address. Only relevant if the type is NATIVE or MASTER. If the field is left empty, the default is hostmaster@name.",
-				Computed:    true,
-				Required:    false,
+				Description: "The hostmaster email address. Only relevant if the type is NATIVE or MASTER. If the field is left empty, the default is hostmaster@name. " +
+					"Changes are applied in place. This is the only contact of a zone in the hosting.de DNS API, " +
+					"technical or abuse contacts belong to the domain registration and can't be managed by this provider.",
+				Computed: true,
+				Required: false,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					emailAddressValidator{},
+				},
+			},
+			"dns_server_group": schema.StringAttribute{
+				Description: "ID of the DNS server group serving the zone, for accounts with dedicated DNS clusters at hosting.de. " +
+					"Defaults to the group hosting.de assigns to new zones of the account, which is read into the state without causing a diff. " +
+					"Changes are applied in place. The groups available to the account are not listed by the API, it rejects an unknown group on apply.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"nameserver_set": schema.StringAttribute{
+				Description: "Name of the nameserver set used for the zone. Defaults to the nameserver_set of the provider's zone_defaults, " +
+					"then the provider's default_nameserver_set, or the account's default nameserver set if none is configured. Changing this forces re-creation of the zone.",
+				Computed: true,
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					nameserverSetNameValidator,
+				},
+			},
+			"master_ips": schema.ListAttribute{
+				Description: "IP addresses of the primary nameserver a SLAVE zone is transferred from, " +
+					"for example a hidden primary. Required for SLAVE zones and not allowed for other types. " +
+					"The hosting.de API stores a single primary, so the list must contain exactly one address.",
+				ElementType: types.StringType,
 				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(ipAddressValidator{}),
+				},
+			},
+			"zonefile": schema.StringAttribute{
+				Description: "Records to create with the zone, in BIND master file format, for example to migrate a zone from another DNS provider. " +
+					"Relative names are relative to the zone name. The SOA record and the NS records at the apex are skipped, " +
+					"as hosting.de manages them. Only used when the zone is created, later changes are not applied to the records. " +
+					"Use file() to read the zonefile from disk.",
+				Optional: true,
+			},
+			"records_json": schema.StringAttribute{
+				Description: "Records to create with the zone, as a JSON array of objects with the keys name, type, content, ttl and priority, " +
+					"for example built with jsonencode(). Names are relative to the zone name, \"@\" refers to the apex. " +
+					"ttl defaults to 3600, priority is required for MX, NAPTR, SRV and URI records and not allowed for other types. " +
+					"Unknown keys, SOA records and NS records at the apex are rejected with the index of the record. " +
+					"The records are created together with the records of zonefile. " +
+					"Only used when the zone is created, later changes are not applied to the records.",
+				Optional: true,
+			},
+			"default_caa_issuer": schema.StringAttribute{
+				Description: "Domain name of the certificate authority allowed to issue certificates for the zone, like letsencrypt.org. " +
+					"If set, a CAA record with the issue property for it is created at the apex when the zone is created, " +
+					"unless the zonefile already contains one. Only used when the zone is created, the record can be changed or " +
+					"removed afterwards, for example with hostingde_record, and is not recreated by later applies.",
+				Optional: true,
+				Validators: []validator.String{
+					caaIssuerValidator,
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Nameservers of the zone, taken from its NS records at the apex.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"record_count": schema.Int64Attribute{
+				Description: "Number of records in the zone, including records not managed by Terraform.",
+				Computed:    true,
+			},
+			"applied_template": schema.StringAttribute{
+				Description: "ID of the DNS template the zone was created from. Null if no template applies.",
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"dnssec": schema.SingleNestedAttribute{
+				Description: "DNSSEC signing of the zone. DNSSEC is enabled if this attribute is set, and disabled otherwise. " +
+					"Changing the algorithm makes hosting.de perform an algorithm rollover of the zone's keys; " +
+					"the DS record at the registrar has to be updated with the new key once the rollover published it.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"algorithm": schema.StringAttribute{
+						Description: "Signing algorithm of the zone keys. Valid algorithms are " + strings.Join(dnssecAlgorithms, ", ") + ". " +
+							"The key sizes are determined by the algorithm. Defaults to the dnssec_algorithm of the provider's zone_defaults, or ECDSAP256SHA256.",
+						Computed: true,
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(dnssecAlgorithms...),
+						},
+					},
+					"nsec_mode": schema.StringAttribute{
+						Description: "Authenticated denial of existence mode, either nsec or nsec3. Defaults to the dnssec_nsec_mode of the provider's zone_defaults, or nsec3.",
+						Computed:    true,
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("nsec", "nsec3"),
+						},
+					},
+					"publish_ds_at_registrar": schema.BoolAttribute{
+						Description: "Whether hosting.de publishes the DS record of the zone at the registry automatically. " +
+							"Requires the domain to be registered with hosting.de. Defaults to false.",
+						Computed: true,
+						Optional: true,
+						Default:  booldefault.StaticBool(false),
+					},
+					"ds_publish_status": schema.StringAttribute{
+						Description: "Status of the key signing key, whose DS record is published at the registry. Null while no key exists yet.",
+						Computed:    true,
+					},
+					"keys": schema.SetNestedAttribute{
+						Description: "DNSKEYs of the zone, required if dnssec_mode is manual and not allowed otherwise. " +
+							"The zone is signed with the private keys outside of hosting.de, so at least the key signing key is needed.",
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"flags": schema.Int64Attribute{
+									Description: "DNSKEY flags, 257 for the key signing key and 256 for zone signing keys.",
+									Required:    true,
+									Validators: []validator.Int64{
+										int64validator.OneOf(256, 257),
+									},
+								},
+								"algorithm": schema.Int64Attribute{
+									Description: "DNSKEY algorithm number of the key, for example 13 for ECDSAP256SHA256.",
+									Required:    true,
+									Validators: []validator.Int64{
+										int64validator.Between(1, 255),
+									},
+								},
+								"public_key": schema.StringAttribute{
+									Description: "Base64 encoded public key, as in the DNSKEY record.",
+									Required:    true,
+								},
+							},
+						},
+					},
+					"ds_record": schema.StringAttribute{
+						Description: "DS record to publish at the registrar, derived from the key signing key with a SHA-256 digest, " +
+							"like \"12345 13 2 ABCD...\". In automatic mode it belongs to the key generated by hosting.de, " +
+							"in manual mode to the supplied key. Null while no key signing key exists yet, " +
+							"creating or updating the zone waits for hosting.de to generate it, see dnssec_keys_timeout.",
+						Computed: true,
+					},
+				},
+			},
+			"dnssec_mode": schema.StringAttribute{
+				Description: "How the DNSSEC keys of the zone are managed, either automatic or manual. Only allowed if dnssec is set, defaults to automatic. " +
+					"In automatic mode hosting.de generates the keys, rolls them over and signs the zone, ds_record shows the DS record to publish. " +
+					"In manual mode the keys are supplied in dnssec.keys and hosting.de serves them without generating or rolling over keys.",
+				Computed: true,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("automatic", "manual"),
+				},
+			},
+			"dnssec_keys_timeout": schema.StringAttribute{
+				Description: "How long creating or updating a zone in automatic DNSSEC mode waits for hosting.de to generate the key signing key, " +
+					"so dnssec.ds_record is known once the apply finished, as a duration like \"10m\". " +
+					"hosting.de generates the keys asynchronously after signing is enabled. The apply fails if no key appeared in time, " +
+					"a zone created by the apply is then saved in state but tainted. \"0s\" disables the wait. Defaults to 10m. " +
+					"Changing only this attribute doesn't update the zone in hosting.de.",
+				Optional: true,
+			},
+			"ready_timeout": schema.StringAttribute{
+				Description: "How long creating or updating the zone waits for hosting.de to provision it, as a duration like \"5m\". " +
+					"The apply fails if the zone isn't active in time, a zone created by the apply is then saved in state but tainted. Defaults to 2m. " +
+					"Changing only this attribute doesn't update the zone in hosting.de.",
+				Optional: true,
+			},
+			"ready": schema.BoolAttribute{
+				Description: "Whether the zone is fully provisioned. Creating and updating a zone waits until the zone is active, " +
+					"so this is always true once the apply finished. Reference it to order resources after the zone is ready.",
+				Computed: true,
+			},
+			"enforce_min_ttl": schema.Int64Attribute{
+				Description: "Minimum TTL in seconds for the records of the zone, including records not managed by Terraform. " +
+					"Every apply raises the TTL of all records below it in a single batch request and reports how many records were changed. " +
+					"The SOA and apex NS records and ALIAS records are left alone, as hosting.de controls their TTL. " +
+					"hostingde_record resources with a lower ttl are changed back on their next apply, so raise their ttl as well.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(60, 31556926),
+				},
+			},
+			"records_below_min_ttl": schema.Int64Attribute{
+				Description: "Number of records with a TTL below enforce_min_ttl as of the last refresh. " +
+					"The next apply raises their TTL if it is not zero. Null if enforce_min_ttl is not set.",
+				Computed: true,
+			},
+			"reapply_template": schema.BoolAttribute{
+				Description: "Changing this value, from false to true or back, re-applies the DNS template referenced by applied_template " +
+					"to the zone on the next apply. Records of the template missing from the zone are added, and records created from " +
+					"the template whose name, content, TTL or priority were changed are changed back, so the template wins over manual " +
+					"changes. Records are matched to the template by the template record they were created from, or else by name, type " +
+					"and content. Other records are never deleted, including records of template records removed from the template. " +
+					"Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"delete_records_on_destroy": schema.BoolAttribute{
+				Description: "Whether destroying the zone also deletes records that are not managed by Terraform. " +
+					"If false, destroying the zone fails as long as records other than the SOA and apex NS records remain, " +
+					"which protects shared zones against accidental data loss. Defaults to true.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether destroying the zone, including replacing it, fails with an error. " +
+					"Set it to false and apply before destroying the zone. The protection is enforced by the provider, " +
+					"not by the hosting.de API, so the zone can still be deleted in the hosting.de web interface or with other tools. " +
+					"Defaults to false.",
+				Computed: true,
+				Optional: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
 // Create a new resource
 func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "create zone")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan zoneResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -86,8 +472,19 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
 	name := plan.Name.ValueString()
 	ztype := plan.Type.ValueString()
+	if ztype == "" {
+		ztype = r.zoneDefaults().Type
+	}
 	if ztype == "" {
 		ztype = "NATIVE"
 	}
@@ -98,13 +495,105 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		BaseRequest:             &BaseRequest{},
 		UseDefaultNameserverSet: true,
 		ZoneConfig: ZoneConfig{
-			Name:         name,
-			Type:         ztype,
-			EMailAddress: email,
+			Name:             name,
+			Type:             ztype,
+			EMailAddress:     email,
+			DNSServerGroupID: plan.DNSServerGroup.ValueString(),
 		},
 		Records: []DNSRecord{},
 	}
-	zone, err := r.client.createZone(zoneReq)
+	// The SOA defaults only apply on creation, so changing them causes no drift
+	if soaValues := r.zoneDefaults().SOAValues; soaValues != nil {
+		values := *soaValues
+		zoneReq.ZoneConfig.SOAValues = &values
+	}
+
+	if !plan.Zonefile.IsNull() {
+		records, err := parseZonefile(name, plan.Zonefile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zonefile"),
+				"Invalid zonefile",
+				"Could not parse the zonefile: "+err.Error(),
+			)
+			return
+		}
+		for i := range records {
+			records[i].Comments = r.client.options.ManagedByComment
+		}
+		zoneReq.Records = records
+	}
+	if !plan.RecordsJSON.IsNull() {
+		records, err := parseRecordsJSON(name, plan.RecordsJSON.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records_json"),
+				"Invalid records_json",
+				"Could not parse records_json: "+err.Error(),
+			)
+			return
+		}
+		for i := range records {
+			records[i].Comments = r.client.options.ManagedByComment
+		}
+		zoneReq.Records = append(zoneReq.Records, records...)
+	}
+	if !plan.DefaultCAAIssuer.IsNull() {
+		zoneReq.Records = addDefaultCAARecord(zoneReq.Records, name, plan.DefaultCAAIssuer.ValueString(), r.client.options.ManagedByComment)
+	}
+	zoneReq.ZoneConfig.DNSSecMode, zoneReq.DNSSecOptions = dnsSecOptions(plan.DNSSEC, plan.DNSSECMode.ValueString())
+	plan.DNSSECMode = types.StringNull()
+	if plan.DNSSEC != nil {
+		plan.DNSSECMode = types.StringValue(zoneReq.ZoneConfig.DNSSecMode)
+	}
+	zoneReq.ZoneConfig.MasterIP, diags = masterIP(ctx, plan.MasterIPs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resource configuration takes precedence over the provider defaults
+	nameserverSetName := plan.NameserverSet.ValueString()
+	if nameserverSetName == "" {
+		nameserverSetName = r.zoneDefaults().NameserverSet
+	}
+	if nameserverSetName == "" {
+		nameserverSetName = r.client.options.DefaultNameserverSet
+	}
+
+	plan.NameserverSet = types.StringNull()
+	if nameserverSetName != "" {
+		nameserverSet, err := r.client.getNameserverSetByName(ctx, nameserverSetName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("nameserver_set"),
+				"Error Reading hosting.de nameserver set",
+				"Could not read hosting.de nameserver set "+nameserverSetName+": "+err.Error(),
+			)
+			return
+		}
+
+		zoneReq.UseDefaultNameserverSet = false
+		zoneReq.NameserverSetId = nameserverSet.ID
+		plan.NameserverSet = types.StringValue(nameserverSetName)
+	}
+
+	// Strict warnings fail before the zone is created
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.createZone(ctx, zoneReq)
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		resp.Diagnostics.AddError(
+			"hosting.de zone limit reached",
+			"Could not create zone "+name+". "+quotaMessage(ctx, quotaErr, "zones", r.client.countZones),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating zone",
@@ -117,17 +606,59 @@ func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	plan.ID = types.StringValue(zone.Response.ZoneConfig.ID)
 	plan.Name = types.StringValue(zone.Response.ZoneConfig.Name)
 	plan.Type = types.StringValue(zone.Response.ZoneConfig.Type)
+	plan.AppliedTemplate = appliedTemplate(zone.Response.ZoneConfig)
+	plan.DNSServerGroup = types.StringValue(zone.Response.ZoneConfig.DNSServerGroupID)
+	plan.Ready = types.BoolValue(false)
 
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	// The zone exists from here on, so it's kept if a later step fails
+	resp.Diagnostics.Append(setPartialZoneState(ctx, &resp.State, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The zone is provisioned asynchronously
+	readyConfig, err := r.client.waitForZoneActive(ctx, plan.ID.ValueString(), zoneReadyTimeout(plan.ReadyTimeout))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ready_timeout"),
+			"Error waiting for hosting.de DNS zone",
+			"Zone "+plan.Name.ValueString()+" did not become ready: "+err.Error(),
+		)
+		return
+	}
+	plan.Ready = types.BoolValue(readyConfig.Status == "active")
+
+	// A timeout is reported once the state is saved, as the zone exists
+	keysDiags := r.waitForDNSSECKeys(ctx, plan)
+	if plan.DNSSEC != nil {
+		dnssec, diags := r.readDNSSEC(ctx, plan.ID.ValueString(), plan.Name.ValueString(), plan.DNSSECMode.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.DNSSEC.DSPublishStatus = dnssec.DSPublishStatus
+		plan.DNSSEC.DSRecord = dnssec.DSRecord
+	}
+
+	// Raising the TTLs may fail for single records, the zone is saved first
+	minTTLDiags := r.enforceMinTTL(ctx, &plan)
+	resp.Diagnostics.Append(r.readRecordInfo(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(minTTLDiags...)
+	resp.Diagnostics.Append(keysDiags...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
 	// Get current state
 	var state zoneResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -147,7 +678,11 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Get refreshed zone value from hosting.de
-	zone, err := r.client.listZones(zoneReq)
+	zone, err := r.client.listZones(ctx, zoneReq)
+	if errors.Is(err, errNotFound) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
@@ -161,6 +696,42 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.Name = types.StringValue(zone.Response.Data[0].ZoneConfig.Name)
 	state.Type = types.StringValue(zone.Response.Data[0].ZoneConfig.Type)
 	state.EMailAddress = types.StringValue(zone.Response.Data[0].ZoneConfig.EMailAddress)
+	state.DNSServerGroup = types.StringValue(zone.Response.Data[0].ZoneConfig.DNSServerGroupID)
+	state.AppliedTemplate = appliedTemplate(zone.Response.Data[0].ZoneConfig)
+	state.Ready = types.BoolValue(zone.Response.Data[0].ZoneConfig.Status == "active")
+	state.MasterIPs, diags = masterIPs(ctx, zone.Response.Data[0].ZoneConfig)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DNSSEC = nil
+	state.DNSSECMode = types.StringNull()
+	if zoneConfig := zone.Response.Data[0].ZoneConfig; zoneConfig.DNSSecMode != "" && zoneConfig.DNSSecMode != "off" {
+		state.DNSSECMode = types.StringValue(zoneConfig.DNSSecMode)
+		state.DNSSEC, diags = r.readDNSSEC(ctx, zoneConfig.ID, zoneConfig.Name, zoneConfig.DNSSecMode)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Not stored in the API, use the default for imported zones
+	if state.DeleteRecordsOnDestroy.IsNull() {
+		state.DeleteRecordsOnDestroy = types.BoolValue(true)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+	if state.ReapplyTemplate.IsNull() {
+		state.ReapplyTemplate = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(r.readRecordInfo(ctx, &state)...)
+	resp.Diagnostics.Append(r.readRecordsBelowMinTTL(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -172,6 +743,14 @@ func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "update zone")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from plan
 	var plan zoneResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -180,6 +759,30 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// Only used by the provider, the zone itself is left as it is
+	onlyTimeout, err := onlyTimeoutsChanged(req.Config.Raw, req.Plan.Raw, req.State.Raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating zone", "Could not compare the plan with the state: "+err.Error())
+		return
+	}
+	if onlyTimeout {
+		state, diags := zoneStateWithTimeouts(ctx, req.Plan, req.State)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+
+	if err := r.client.waitForJitter(ctx); err != nil {
+		resp.Diagnostics.AddError(
+			"Operation cancelled",
+			"The operation was cancelled while waiting for operation_jitter: "+err.Error(),
+		)
+		return
+	}
+
 	zoneFindReq := ZonesFindRequest{
 		BaseRequest: &BaseRequest{},
 		Filter: FilterOrChain{Filter: Filter{
@@ -191,7 +794,7 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	// Get refreshed zone value from hosting.de
-	zoneFindResp, err := r.client.listZones(zoneFindReq)
+	zoneFindResp, err := r.client.listZones(ctx, zoneFindReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading hosting.de DNS zone",
@@ -200,18 +803,45 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	// The nameserver set is only known if it was set on creation
+	if plan.NameserverSet.IsUnknown() {
+		plan.NameserverSet = types.StringNull()
+	}
+
 	zoneConfig := zoneFindResp.Response.Data[0].ZoneConfig
 	zoneConfig.ID = plan.ID.ValueString()
 	zoneConfig.Name = plan.Name.ValueString()
 	zoneConfig.Type = plan.Type.ValueString()
 	zoneConfig.EMailAddress = plan.EMailAddress.ValueString()
+	// Unset, the group of the zone is left as it is
+	if !plan.DNSServerGroup.IsUnknown() {
+		zoneConfig.DNSServerGroupID = plan.DNSServerGroup.ValueString()
+	}
+	zoneConfig.MasterIP, diags = masterIP(ctx, plan.MasterIPs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Generate API request body from plan
 	zoneReq := ZoneUpdateRequest{
 		BaseRequest: &BaseRequest{},
 		ZoneConfig:  zoneConfig,
 	}
-	zone, err := r.client.updateZone(zoneReq)
+	zoneReq.ZoneConfig.DNSSecMode, zoneReq.DNSSecOptions = dnsSecOptions(plan.DNSSEC, plan.DNSSECMode.ValueString())
+	plan.DNSSECMode = types.StringNull()
+	if plan.DNSSEC != nil {
+		plan.DNSSECMode = types.StringValue(zoneReq.ZoneConfig.DNSSecMode)
+	}
+
+	// Strict warnings fail before the zone is changed
+	warnings.appendTo(&resp.Diagnostics)
+	r.client.strictWarnings(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, err := r.client.updateZone(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating zone",
@@ -224,16 +854,96 @@ func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	plan.ID = types.StringValue(zone.Response.ZoneConfig.ID)
 	plan.Name = types.StringValue(zone.Response.ZoneConfig.Name)
 	plan.Type = types.StringValue(zone.Response.ZoneConfig.Type)
+	plan.AppliedTemplate = appliedTemplate(zone.Response.ZoneConfig)
+	plan.DNSServerGroup = types.StringValue(zone.Response.ZoneConfig.DNSServerGroupID)
+	plan.Ready = types.BoolValue(false)
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+	var state zoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The zone is saved with the values of the update, so they aren't applied
+	// again if a later step fails. Template and record details come from the
+	// previous state until they are read again.
+	updated := plan
+	updated.ReapplyTemplate = state.ReapplyTemplate
+	updated.Nameservers = state.Nameservers
+	updated.RecordCount = state.RecordCount
+	updated.RecordsBelowMinTTL = state.RecordsBelowMinTTL
+	resp.Diagnostics.Append(setPartialZoneState(ctx, &resp.State, updated)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The zone is provisioned asynchronously
+	readyConfig, err := r.client.waitForZoneActive(ctx, plan.ID.ValueString(), zoneReadyTimeout(plan.ReadyTimeout))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ready_timeout"),
+			"Error waiting for hosting.de DNS zone",
+			"Zone "+plan.Name.ValueString()+" did not become ready: "+err.Error(),
+		)
+		return
+	}
+	plan.Ready = types.BoolValue(readyConfig.Status == "active")
+
+	// A timeout is reported once the state is saved, as the zone exists
+	keysDiags := r.waitForDNSSECKeys(ctx, plan)
+	if plan.DNSSEC != nil {
+		dnssec, diags := r.readDNSSEC(ctx, plan.ID.ValueString(), plan.Name.ValueString(), plan.DNSSECMode.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.DNSSEC.DSPublishStatus = dnssec.DSPublishStatus
+		plan.DNSSEC.DSRecord = dnssec.DSRecord
+	}
+
+	if !state.ReapplyTemplate.Equal(plan.ReapplyTemplate) {
+		// The zone is saved first, keeping the previous reapply_template, so
+		// a failed template is retried by the next apply
+		updated = plan
+		updated.ReapplyTemplate = state.ReapplyTemplate
+		updated.Nameservers = state.Nameservers
+		updated.RecordCount = state.RecordCount
+		updated.RecordsBelowMinTTL = state.RecordsBelowMinTTL
+		resp.Diagnostics.Append(resp.State.Set(ctx, updated)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.reapplyTemplate(ctx, zone.Response.ZoneConfig)...)
+		if resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(keysDiags...)
+			return
+		}
+	}
+
+	// Raising the TTLs may fail for single records, the zone is saved first
+	minTTLDiags := r.enforceMinTTL(ctx, &plan)
+	resp.Diagnostics.Append(r.readRecordInfo(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(minTTLDiags...)
+	resp.Diagnostics.Append(keysDiags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(checkReadOnly(r.client, "delete zone")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Retrieve values from state
 	var state zoneResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -242,13 +952,72 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Zone is protected against deletion",
+			"Could not delete zone "+state.Name.ValueString()+", because deletion_protection is enabled. "+
+				"Set deletion_protection to false and apply the change before destroying or replacing the zone.",
+		)
+		return
+	}
+
+	// Deleted outside of Terraform, the desired end state is reached
+	_, err := r.client.getZoneConfig(ctx, state.ID.ValueString())
+	if errors.Is(err, errNotFound) {
+		tflog.Info(ctx, "Zone already deleted", map[string]any{
+			"hostingde_zone_config_id": state.ID.ValueString(),
+		})
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if !state.DeleteRecordsOnDestroy.ValueBool() {
+		records, err := r.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+			Field: "ZoneConfigId",
+			Value: state.ID.ValueString(),
+		}})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone records",
+				"Could not read records of hosting.de DNS zone ID "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		warnUnknownRecordTypes(ctx, records)
+
+		var remaining []string
+		for _, record := range records {
+			if !isSystemRecord(record, state.Name.ValueString()) {
+				remaining = append(remaining, record.Name+" "+record.Type+" "+record.Content)
+			}
+		}
+
+		if len(remaining) > 0 {
+			resp.Diagnostics.AddError(
+				"Zone still contains records",
+				"Could not delete zone "+state.Name.ValueString()+", because delete_records_on_destroy is false and the zone "+
+					"still contains records not managed by Terraform:\n"+strings.Join(remaining, "\n")+"\n"+
+					"Delete the records or set delete_records_on_destroy to true.",
+			)
+			return
+		}
+	}
+
 	zoneReq := ZoneDeleteRequest{
 		BaseRequest:  &BaseRequest{},
 		ZoneConfigId: state.ID.ValueString(),
 	}
 
 	// Delete existing zone
-	_, err := r.client.deleteZone(zoneReq)
+	_, err = r.client.deleteZone(ctx, zoneReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting hosting.de Zone",
@@ -258,7 +1027,7 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	// Purge restorable zone
-	_, purgeErr := r.client.purgeZone(zoneReq)
+	_, purgeErr := r.client.purgeZone(ctx, zoneReq)
 	if purgeErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Purging hosting.de Zone",
@@ -268,6 +1037,766 @@ func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// readRecordInfo populates the computed attributes derived from the records
+// of the zone.
+func (r *zoneResource) readRecordInfo(ctx context.Context, model *zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	recordCount, err := r.client.countRecords(ctx, model.ID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not count records of hosting.de DNS zone ID "+model.ID.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+	model.RecordCount = types.Int64Value(int64(recordCount))
+
+	nameservers, err := r.client.listZoneNameservers(ctx, model.ID.ValueString(), model.Name.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read nameservers of hosting.de DNS zone ID "+model.ID.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+
+	nameserversValue, listDiags := types.ListValueFrom(ctx, types.StringType, nameservers)
+	diags.Append(listDiags...)
+	model.Nameservers = nameserversValue
+
+	return diags
+}
+
+// reapplyTemplate re-applies the DNS template of the zone after
+// reapply_template changed, reporting the changed records as a warning.
+func (r *zoneResource) reapplyTemplate(ctx context.Context, zoneConfig ZoneConfig) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if appliedTemplate(zoneConfig).IsNull() {
+		diags.AddAttributeWarning(
+			path.Root("reapply_template"),
+			"Zone has no template",
+			"Zone "+zoneConfig.Name+" is not based on a DNS template, so there is nothing to re-apply.",
+		)
+		return diags
+	}
+
+	added, modified, err := r.client.reapplyTemplate(ctx, zoneConfig, r.client.options.ManagedByComment)
+	var updateErr *RecordsUpdateError
+	if errors.As(err, &updateErr) && updateErr.Partial {
+		diags.AddAttributeError(
+			path.Root("reapply_template"),
+			"Error updating records",
+			fmt.Sprintf("Added %d and modified %d record(s) of zone %s to match its DNS template, but could not apply the others: %s",
+				added, modified, zoneConfig.Name, err.Error()),
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("reapply_template"),
+			"Error updating records",
+			"Could not re-apply the template of hosting.de DNS zone ID "+zoneConfig.ID+": "+err.Error(),
+		)
+		return diags
+	}
+
+	if added > 0 || modified > 0 {
+		diags.AddAttributeWarning(
+			path.Root("reapply_template"),
+			"Re-applied DNS template",
+			fmt.Sprintf("Added %d and modified %d record(s) of zone %s to match its DNS template.",
+				added, modified, zoneConfig.Name),
+		)
+	}
+
+	return diags
+}
+
+// enforceMinTTL raises the TTL of the records below enforce_min_ttl.
+func (r *zoneResource) enforceMinTTL(ctx context.Context, model *zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.RecordsBelowMinTTL = types.Int64Null()
+	if model.EnforceMinTTL.IsNull() {
+		return diags
+	}
+
+	changed, err := r.client.raiseRecordTTLs(ctx, model.ID.ValueString(), model.Name.ValueString(), int(model.EnforceMinTTL.ValueInt64()))
+	// The rejected records stay below the minimum, so the next plan retries
+	// them
+	var updateErr *RecordsUpdateError
+	if errors.As(err, &updateErr) && updateErr.Partial {
+		model.RecordsBelowMinTTL = types.Int64Value(int64(len(updateErr.Failed)))
+		diags.AddAttributeError(
+			path.Root("enforce_min_ttl"),
+			"Error updating records",
+			fmt.Sprintf("Raised the TTL of %d record(s) of zone %s to the minimum of %d seconds, but could not raise the others: %s",
+				changed, model.Name.ValueString(), model.EnforceMinTTL.ValueInt64(), err.Error()),
+		)
+		return diags
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("enforce_min_ttl"),
+			"Error updating records",
+			"Could not raise the TTL of the records of hosting.de DNS zone ID "+model.ID.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+	model.RecordsBelowMinTTL = types.Int64Value(0)
+
+	if changed > 0 {
+		diags.AddAttributeWarning(
+			path.Root("enforce_min_ttl"),
+			"Raised record TTLs",
+			fmt.Sprintf("Raised the TTL of %d record(s) of zone %s to the minimum of %d seconds.",
+				changed, model.Name.ValueString(), model.EnforceMinTTL.ValueInt64()),
+		)
+	}
+
+	return diags
+}
+
+// readRecordsBelowMinTTL counts the records below enforce_min_ttl, so a
+// record with a too low TTL makes the next plan update the zone.
+func (r *zoneResource) readRecordsBelowMinTTL(ctx context.Context, model *zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.RecordsBelowMinTTL = types.Int64Null()
+	if model.EnforceMinTTL.IsNull() {
+		return diags
+	}
+
+	records, err := r.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: model.ID.ValueString(),
+	}})
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone records",
+			"Could not read records of hosting.de DNS zone ID "+model.ID.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+
+	below := recordsBelowMinTTL(records, model.Name.ValueString(), int(model.EnforceMinTTL.ValueInt64()))
+	model.RecordsBelowMinTTL = types.Int64Value(int64(len(below)))
+
+	return diags
+}
+
+// ModifyPlan plans records_below_min_ttl as zero while enforce_min_ttl is
+// set, so records below the minimum found on refresh trigger an update, the
+// defaults of type and the DNSSEC options, and the default of dnssec_mode.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the zone is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Waiting differently for the zone changes nothing in hosting.de, the
+	// zone keeps its state and Update skips the API
+	if !req.State.Raw.IsNull() {
+		onlyTimeout, err := onlyTimeoutsChanged(req.Config.Raw, req.Plan.Raw, req.State.Raw)
+		if err != nil {
+			resp.Diagnostics.AddError("Error planning zone", "Could not compare the plan with the state: "+err.Error())
+			return
+		}
+		if onlyTimeout {
+			state, diags := zoneStateWithTimeouts(ctx, req.Plan, req.State)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, state)...)
+			return
+		}
+	}
+
+	defaults := r.zoneDefaults()
+	resp.Diagnostics.Append(planZoneType(ctx, req, resp, defaults.Type)...)
+	resp.Diagnostics.Append(planDNSSECDefault(ctx, req, resp, "algorithm", defaults.DNSSECAlgorithm, defaultDNSSECAlgorithm)...)
+	resp.Diagnostics.Append(planDNSSECDefault(ctx, req, resp, "nsec_mode", defaults.DNSSECNSECMode, defaultNSECMode)...)
+	resp.Diagnostics.Append(planDNSSECMode(ctx, req, resp)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var enforceMinTTL types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("enforce_min_ttl"), &enforceMinTTL)...)
+	if resp.Diagnostics.HasError() || enforceMinTTL.IsUnknown() {
+		return
+	}
+
+	recordsBelowMinTTL := types.Int64Null()
+	if !enforceMinTTL.IsNull() {
+		recordsBelowMinTTL = types.Int64Value(0)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records_below_min_ttl"), recordsBelowMinTTL)...)
+}
+
+// zoneDefaults returns the zone defaults of the provider, which are empty
+// before the provider is configured.
+func (r *zoneResource) zoneDefaults() ZoneDefaults {
+	if r.client == nil {
+		return ZoneDefaults{}
+	}
+
+	return r.client.options.ZoneDefaults
+}
+
+// planZoneType plans the type of new zones that don't configure it from the
+// provider default, falling back to NATIVE. Existing zones keep their type,
+// so changing the default causes no drift. As ValidateConfig can't know the
+// type of those zones, master_ips is checked against the planned type here.
+func planZoneType(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, defaultType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var configType types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("type"), &configType)...)
+	if diags.HasError() || !configType.IsNull() {
+		return diags
+	}
+
+	if req.State.Raw.IsNull() {
+		if defaultType == "" {
+			defaultType = "NATIVE"
+		}
+		diags.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), types.StringValue(defaultType))...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	var zoneType types.String
+	var masterIPs types.List
+	diags.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("master_ips"), &masterIPs)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(validateMasterIPs(zoneType, masterIPs)...)
+
+	return diags
+}
+
+// planDNSSECDefault plans a DNSSEC option that isn't configured. Zones that
+// are already signed keep the value of their state, so changing the default
+// causes no drift. Zones enabling DNSSEC get the provider default, or the
+// fallback if the provider sets none.
+func planDNSSECDefault(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, name string, defaultValue string, fallback string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	attrPath := path.Root("dnssec").AtName(name)
+
+	var dnssec types.Object
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("dnssec"), &dnssec)...)
+	if diags.HasError() || dnssec.IsNull() || dnssec.IsUnknown() {
+		return diags
+	}
+
+	var configValue types.String
+	diags.Append(req.Config.GetAttribute(ctx, attrPath, &configValue)...)
+	if diags.HasError() || !configValue.IsNull() {
+		return diags
+	}
+
+	stateValue := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		diags.Append(req.State.GetAttribute(ctx, attrPath, &stateValue)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	value := stateValue
+	if value.IsNull() {
+		if defaultValue == "" {
+			defaultValue = fallback
+		}
+		value = types.StringValue(defaultValue)
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, attrPath, value)...)
+
+	return diags
+}
+
+// planDNSSECMode plans dnssec_mode as automatic for signed zones that don't
+// configure it, and as null for unsigned zones.
+func planDNSSECMode(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var configMode types.String
+	diags.Append(req.Config.GetAttribute(ctx, path.Root("dnssec_mode"), &configMode)...)
+	if diags.HasError() || !configMode.IsNull() {
+		return diags
+	}
+
+	var dnssec types.Object
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("dnssec"), &dnssec)...)
+	if diags.HasError() || dnssec.IsUnknown() {
+		return diags
+	}
+
+	mode := types.StringNull()
+	if !dnssec.IsNull() {
+		mode = types.StringValue("automatic")
+	}
+	diags.Append(resp.Plan.SetAttribute(ctx, path.Root("dnssec_mode"), mode)...)
+
+	return diags
+}
+
+// masterIP returns the primary nameserver of a SLAVE zone for the API from
+// the master_ips attribute, or an empty string if it is not set.
+func masterIP(ctx context.Context, list types.List) (string, diag.Diagnostics) {
+	var ips []string
+	diags := list.ElementsAs(ctx, &ips, false)
+	if diags.HasError() || len(ips) == 0 {
+		return "", diags
+	}
+
+	return ips[0], diags
+}
+
+// masterIPs returns the master_ips attribute for the primary nameserver of a
+// zone config, or null if the zone has none.
+func masterIPs(ctx context.Context, zoneConfig ZoneConfig) (types.List, diag.Diagnostics) {
+	if zoneConfig.MasterIP == "" {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, []string{zoneConfig.MasterIP})
+}
+
+// ValidateConfig checks that the zonefile and records_json parse, that the DNSSEC keys match
+// the dnssec_mode and that master_ips is set exactly for SLAVE zones.
+func (r *zoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var configData zoneResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report parse errors with their line number before the zone is created
+	if !configData.Zonefile.IsNull() && !configData.Zonefile.IsUnknown() && !configData.Name.IsUnknown() {
+		if _, err := parseZonefile(configData.Name.ValueString(), configData.Zonefile.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zonefile"),
+				"Invalid zonefile",
+				"Could not parse the zonefile: "+err.Error(),
+			)
+		}
+	}
+	if !configData.RecordsJSON.IsNull() && !configData.RecordsJSON.IsUnknown() && !configData.Name.IsUnknown() {
+		if _, err := parseRecordsJSON(configData.Name.ValueString(), configData.RecordsJSON.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records_json"),
+				"Invalid records_json",
+				"Could not parse records_json: "+err.Error(),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateDNSSECMode(configData)...)
+	resp.Diagnostics.Append(validateDNSSECKeysTimeout(configData.DNSSECKeysTimeout)...)
+	resp.Diagnostics.Append(validateReadyTimeout(configData.ReadyTimeout)...)
+
+	// The type of zones that don't configure it is planned in ModifyPlan,
+	// which checks master_ips then
+	if configData.Type.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(validateMasterIPs(configData.Type, configData.MasterIPs)...)
+}
+
+// validateMasterIPs checks that master_ips is set exactly for SLAVE zones.
+func validateMasterIPs(zoneType types.String, masterIPs types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if zoneType.IsUnknown() || masterIPs.IsUnknown() {
+		return diags
+	}
+
+	if zoneType.ValueString() != "SLAVE" {
+		if !masterIPs.IsNull() {
+			diags.AddAttributeError(
+				path.Root("master_ips"),
+				"Unexpected combination of attributes",
+				"master_ips is only relevant for zones of type SLAVE. Please remove master_ips from the resource or change its type.",
+			)
+		}
+		return diags
+	}
+
+	if len(masterIPs.Elements()) != 1 {
+		diags.AddAttributeError(
+			path.Root("master_ips"),
+			"Invalid number of master IPs",
+			"Zones of type SLAVE need the IP address of their primary nameserver in master_ips. "+
+				"The hosting.de API stores a single primary per zone, so master_ips must contain exactly one address.",
+		)
+	}
+
+	return diags
+}
+
+// validateDNSSECMode checks that dnssec_mode is only set for signed zones
+// and that keys are supplied exactly in manual mode.
+func validateDNSSECMode(configData zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if configData.DNSSECMode.IsUnknown() {
+		return diags
+	}
+
+	if configData.DNSSEC == nil {
+		if !configData.DNSSECMode.IsNull() {
+			diags.AddAttributeError(
+				path.Root("dnssec_mode"),
+				"Unexpected combination of attributes",
+				"dnssec_mode is only relevant if DNSSEC is enabled. Please add the dnssec attribute or remove dnssec_mode.",
+			)
+		}
+		return diags
+	}
+
+	if configData.DNSSECMode.ValueString() != "manual" {
+		if configData.DNSSEC.Keys != nil {
+			diags.AddAttributeError(
+				path.Root("dnssec").AtName("keys"),
+				"Unexpected combination of attributes",
+				"DNSSEC keys can only be supplied if dnssec_mode is manual, in automatic mode hosting.de generates the keys.",
+			)
+		}
+		return diags
+	}
+
+	for _, key := range configData.DNSSEC.Keys {
+		if key.Flags.ValueInt64() == 257 || key.Flags.IsUnknown() {
+			return diags
+		}
+	}
+	diags.AddAttributeError(
+		path.Root("dnssec").AtName("keys"),
+		"Missing key signing key",
+		"In manual mode the keys of the zone have to be supplied in dnssec.keys, including the key signing key with flags 257.",
+	)
+
+	return diags
+}
+
+// appliedTemplate returns the ID of the template referenced by the zone
+// config, or null if the zone is not based on a template.
+func appliedTemplate(zoneConfig ZoneConfig) types.String {
+	if len(zoneConfig.TemplateValues) == 0 {
+		return types.StringNull()
+	}
+
+	var templateValues *TemplateValues
+	if err := json.Unmarshal(zoneConfig.TemplateValues, &templateValues); err != nil || templateValues == nil || templateValues.TemplateID == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(templateValues.TemplateID)
+}
+
+// dnsSecOptions returns the DNSSEC mode and options for the API from the
+// dnssec and dnssec_mode attributes. A null dnssec attribute disables DNSSEC.
+func dnsSecOptions(model *zoneDNSSECModel, mode string) (string, *DNSSecOptions) {
+	if model == nil {
+		return "off", nil
+	}
+	if mode == "" {
+		mode = "automatic"
+	}
+
+	options := &DNSSecOptions{
+		Algorithms: []string{model.Algorithm.ValueString()},
+		NSECMode:   model.NSECMode.ValueString(),
+		PublishKSK: model.PublishDSAtRegistrar.ValueBool(),
+	}
+
+	// Keys generated in automatic mode are not sent back
+	if mode == "manual" {
+		for _, key := range model.Keys {
+			options.Keys = append(options.Keys, DNSSecKey{KeyData: KeyData{
+				Flags:     int(key.Flags.ValueInt64()),
+				Protocol:  3,
+				Algorithm: int(key.Algorithm.ValueInt64()),
+				PublicKey: key.PublicKey.ValueString(),
+			}})
+		}
+	}
+
+	return mode, options
+}
+
+// dsRecord returns the content of the DS record with a SHA-256 digest for a
+// key signing key of the zone, or an empty string if the key is invalid.
+func dsRecord(zoneName string, keyData KeyData) string {
+	dnskey := &dns.DNSKEY{
+		Hdr:   dns.RR_Header{Name: dns.Fqdn(zoneName), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags: uint16(keyData.Flags),
+		// The protocol of DNSSEC keys is always 3, see RFC 4034 section 2.1.2
+		Protocol:  3,
+		Algorithm: uint8(keyData.Algorithm),
+		PublicKey: keyData.PublicKey,
+	}
+
+	ds := dnskey.ToDS(dns.SHA256)
+	if ds == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest))
+}
+
+// waitForDNSSECKeys waits until hosting.de generated the key signing key of a
+// zone in automatic DNSSEC mode, so ds_record can be derived from it. Errors
+// are reported on dnssec_keys_timeout.
+func (r *zoneResource) waitForDNSSECKeys(ctx context.Context, model zoneResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	timeout := dnssecKeysTimeout(model.DNSSECKeysTimeout)
+	if model.DNSSEC == nil || model.DNSSECMode.ValueString() != "automatic" || timeout == 0 {
+		return diags
+	}
+
+	if _, err := r.client.waitForDNSSECKeys(ctx, model.ID.ValueString(), timeout); err != nil {
+		diags.AddAttributeError(
+			path.Root("dnssec_keys_timeout"),
+			"Timeout waiting for DNSSEC keys",
+			"DNSSEC was enabled for zone "+model.Name.ValueString()+", but its key signing key could not be read: "+err.Error()+". "+
+				"The DS record is set by a later refresh once hosting.de generated the key.",
+		)
+	}
+
+	return diags
+}
+
+// dnssecKeysTimeout returns the configured time to wait for DNSSEC keys, or
+// the default if it isn't configured.
+func dnssecKeysTimeout(value types.String) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultDNSSECKeysTimeout
+	}
+
+	// Validated in ValidateConfig
+	timeout, _ := time.ParseDuration(value.ValueString())
+	return timeout
+}
+
+// zoneTimeoutAttributes are the attributes only used by the provider to wait
+// for the zone, they are not sent to hosting.de.
+var zoneTimeoutAttributes = []string{"dnssec_keys_timeout", "ready_timeout"}
+
+// onlyTimeoutsChanged reports whether the plan only changes the attributes
+// of zoneTimeoutAttributes. Values unknown in the plan but not configured
+// are computed by the provider and don't count as a change.
+func onlyTimeoutsChanged(config tftypes.Value, plan tftypes.Value, state tftypes.Value) (bool, error) {
+	diffs, err := plan.Diff(state)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, diff := range diffs {
+		if slices.ContainsFunc(zoneTimeoutAttributes, func(name string) bool {
+			return diff.Path.Equal(tftypes.NewAttributePath().WithAttributeName(name))
+		}) {
+			changed = true
+			continue
+		}
+		// Objects and collections are reported as changed along with the
+		// changes of their elements
+		if diff.Value1 != nil && diff.Value2 != nil && !diff.Value1.Type().Is(tftypes.String) &&
+			!diff.Value1.Type().Is(tftypes.Number) && !diff.Value1.Type().Is(tftypes.Bool) &&
+			diff.Value1.IsKnown() && !diff.Value1.IsNull() && !diff.Value2.IsNull() {
+			continue
+		}
+		if diff.Value1 != nil && !diff.Value1.IsKnown() {
+			configValue, _, err := tftypes.WalkAttributePath(config, diff.Path)
+			if value, ok := configValue.(tftypes.Value); err != nil || (ok && value.IsNull()) {
+				continue
+			}
+		}
+		return false, nil
+	}
+
+	return changed, nil
+}
+
+// zoneStateWithTimeouts returns the state of the zone with the planned
+// dnssec_keys_timeout and ready_timeout.
+func zoneStateWithTimeouts(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (zoneResourceModel, diag.Diagnostics) {
+	var model zoneResourceModel
+	diags := state.Get(ctx, &model)
+	diags.Append(plan.GetAttribute(ctx, path.Root("dnssec_keys_timeout"), &model.DNSSECKeysTimeout)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("ready_timeout"), &model.ReadyTimeout)...)
+
+	return model, diags
+}
+
+// validateDNSSECKeysTimeout checks that the time to wait for DNSSEC keys is a
+// duration that isn't negative.
+func validateDNSSECKeysTimeout(value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	if timeout, err := time.ParseDuration(value.ValueString()); err != nil || timeout < 0 {
+		diags.AddAttributeError(
+			path.Root("dnssec_keys_timeout"),
+			"Invalid DNSSEC keys timeout",
+			"The dnssec_keys_timeout value must be a duration like \"30s\" or \"10m\", or \"0s\" to not wait, got: "+value.ValueString(),
+		)
+	}
+
+	return diags
+}
+
+// zoneReadyTimeout returns the configured time to wait for a zone to become
+// active, or the default if it isn't configured.
+func zoneReadyTimeout(value types.String) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultZoneReadyTimeout
+	}
+
+	// Validated in ValidateConfig
+	timeout, _ := time.ParseDuration(value.ValueString())
+	return timeout
+}
+
+// validateReadyTimeout checks that the time to wait for a zone to become
+// active is a positive duration.
+func validateReadyTimeout(value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	if timeout, err := time.ParseDuration(value.ValueString()); err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("ready_timeout"),
+			"Invalid ready timeout",
+			"The ready_timeout value must be a positive duration like \"30s\" or \"5m\", got: "+value.ValueString(),
+		)
+	}
+
+	return diags
+}
+
+// setPartialZoneState saves the zone in the state once it was written to
+// hosting.de, before waiting for it and reading its computed attributes. If
+// one of these steps fails, Terraform keeps the zone and taints it instead of
+// losing track of it. The state can't hold unknown values, attributes that
+// aren't known yet are saved as null.
+func setPartialZoneState(ctx context.Context, state *tfsdk.State, model zoneResourceModel) diag.Diagnostics {
+	diags := state.Set(ctx, model)
+	if diags.HasError() {
+		return diags
+	}
+
+	raw, err := tftypes.Transform(state.Raw, func(_ *tftypes.AttributePath, value tftypes.Value) (tftypes.Value, error) {
+		if !value.IsKnown() {
+			return tftypes.NewValue(value.Type(), nil), nil
+		}
+		return value, nil
+	})
+	if err != nil {
+		diags.AddError("Error saving zone", "Could not save the state of the zone: "+err.Error())
+		return diags
+	}
+	state.Raw = raw
+
+	return diags
+}
+
+// readDNSSEC returns the DNSSEC options of the zone from the API. The keys
+// are only read in manual mode, where they are part of the configuration.
+func (r *zoneResource) readDNSSEC(ctx context.Context, zoneConfigId string, zoneName string, mode string) (*zoneDNSSECModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	dnsSecResp, err := r.client.getDNSSecOptions(ctx, DNSSecOptionsGetRequest{
+		BaseRequest:  &BaseRequest{},
+		ZoneConfigId: zoneConfigId,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error Reading hosting.de DNS zone DNSSEC options",
+			"Could not read DNSSEC options of hosting.de DNS zone ID "+zoneConfigId+": "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	dnssec := &zoneDNSSECModel{
+		Algorithm:            types.StringNull(),
+		NSECMode:             types.StringValue(dnsSecResp.Response.NSECMode),
+		PublishDSAtRegistrar: types.BoolValue(dnsSecResp.Response.PublishKSK),
+		DSPublishStatus:      types.StringNull(),
+		DSRecord:             types.StringNull(),
+	}
+	if len(dnsSecResp.Response.Algorithms) > 0 {
+		dnssec.Algorithm = types.StringValue(dnsSecResp.Response.Algorithms[0])
+	}
+
+	// The DS record is derived from the key signing key, flagged with 257
+	for _, key := range dnsSecResp.Response.Keys {
+		if key.KeyData.Flags == 257 {
+			dnssec.DSPublishStatus = types.StringValue(key.Status)
+			if ds := dsRecord(zoneName, key.KeyData); ds != "" {
+				dnssec.DSRecord = types.StringValue(ds)
+			}
+		}
+
+		if mode == "manual" {
+			dnssec.Keys = append(dnssec.Keys, zoneDNSSECKeyModel{
+				Flags:     types.Int64Value(int64(key.KeyData.Flags)),
+				Algorithm: types.Int64Value(int64(key.KeyData.Algorithm)),
+				PublicKey: types.StringValue(key.KeyData.PublicKey),
+			})
+		}
+	}
+
+	return dnssec, diags
+}
+
+// addDefaultCAARecord adds a CAA record allowing the issuer to issue
+// certificates at the apex of the zone to the records, unless they already
+// contain a CAA issue record there.
+func addDefaultCAARecord(records []DNSRecord, zoneName string, issuer string, comments string) []DNSRecord {
+	for _, record := range records {
+		if fields := strings.Fields(record.Content); record.Type == "CAA" && record.Name == zoneName && len(fields) > 1 && fields[1] == "issue" {
+			return records
+		}
+	}
+
+	return append(records, DNSRecord{
+		Name:     zoneName,
+		Type:     "CAA",
+		Content:  `0 issue "` + issuer + `"`,
+		Comments: comments,
+	})
+}
+
+// isSystemRecord reports whether a record is created and managed by hosting.de
+// for the zone itself, i.e. the SOA record and the NS records at the apex.
+func isSystemRecord(record DNSRecord, zoneName string) bool {
+	switch record.Type {
+	case "SOA":
+		return true
+	case "NS":
+		return record.Name == zoneName
+	}
+
+	return false
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *zoneResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -1,9 +1,20 @@
 package hostingde
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func TestAccZoneResource(t *testing.T) {
@@ -26,6 +37,8 @@ resource "hostingde_zone" "test" {
 					resource.TestCheckResourceAttr("hostingde_zone.test", "type", "NATIVE"),
 					// Verify email attribute.
 					resource.TestCheckResourceAttr("hostingde_zone.test", "email", "hostmaster@example.test"),
+					// Verify the zone is ready once create returns.
+					resource.TestCheckResourceAttr("hostingde_zone.test", "ready", "true"),
 					// Verify dynamic values have any value set in the state.
 					resource.TestCheckResourceAttrSet("hostingde_zone.test", "id"),
 				),
@@ -54,3 +67,806 @@ resource "hostingde_zone" "test" {
 		},
 	})
 }
+
+func TestAccZoneResourceSlave(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "slave.example.test"
+  type = "SLAVE"
+  master_ips = ["192.0.2.53"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_zone.test", "master_ips.#", "1"),
+					resource.TestCheckResourceAttr("hostingde_zone.test", "master_ips.0", "192.0.2.53"),
+				),
+			},
+			// Changing the primary updates the zone in place
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "slave.example.test"
+  type = "SLAVE"
+  master_ips = ["2001:db8::53"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hostingde_zone.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("hostingde_zone.test", "master_ips.0", "2001:db8::53"),
+				),
+			},
+		},
+	})
+}
+
+func TestZoneResourceValidateMasterIPs(t *testing.T) {
+	masterIPs := func(ips ...string) tftypes.Value {
+		values := []tftypes.Value{}
+		for _, ip := range ips {
+			values = append(values, tftypes.NewValue(tftypes.String, ip))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	for _, tc := range []struct {
+		name      string
+		zoneType  string
+		masterIPs tftypes.Value
+		wantError bool
+	}{
+		{name: "one master", zoneType: "SLAVE", masterIPs: masterIPs("192.0.2.53")},
+		// The API stores a single primary per zone
+		{name: "two masters", zoneType: "SLAVE", masterIPs: masterIPs("192.0.2.53", "2001:db8::53"), wantError: true},
+		{name: "invalid IP", zoneType: "SLAVE", masterIPs: masterIPs("ns1.example.test"), wantError: true},
+		{name: "missing master", zoneType: "SLAVE", masterIPs: tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil), wantError: true},
+		{name: "master of native zone", zoneType: "NATIVE", masterIPs: masterIPs("192.0.2.53"), wantError: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := testValidateResourceConfig(t, NewZoneResource(), map[string]tftypes.Value{
+				"name":       tftypes.NewValue(tftypes.String, "example.test"),
+				"type":       tftypes.NewValue(tftypes.String, tc.zoneType),
+				"master_ips": tc.masterIPs,
+			})
+
+			var gotError bool
+			for _, d := range diags {
+				if d.Severity == tfprotov6.DiagnosticSeverityError && d.Attribute != nil &&
+					d.Attribute.Steps()[0] == tftypes.AttributeName("master_ips") {
+					gotError = true
+				}
+			}
+			if gotError != tc.wantError {
+				t.Errorf("got error for master_ips %t, want %t, diagnostics: %v", gotError, tc.wantError, diags)
+			}
+		})
+	}
+}
+
+func TestZoneResourceValidateEmail(t *testing.T) {
+	for _, tc := range []struct {
+		email     string
+		wantError bool
+	}{
+		{email: "hostmaster@example.test"},
+		{email: "dns.admin@example.test"},
+		{email: "Hostmaster <hostmaster@example.test>", wantError: true},
+		{email: "hostmaster", wantError: true},
+		{email: "hostmaster@localhost", wantError: true},
+	} {
+		diags := testValidateResourceConfig(t, NewZoneResource(), map[string]tftypes.Value{
+			"name":  tftypes.NewValue(tftypes.String, "example.test"),
+			"type":  tftypes.NewValue(tftypes.String, "NATIVE"),
+			"email": tftypes.NewValue(tftypes.String, tc.email),
+		})
+
+		var gotError bool
+		for _, d := range diags {
+			if d.Severity == tfprotov6.DiagnosticSeverityError && d.Attribute != nil &&
+				d.Attribute.Steps()[0] == tftypes.AttributeName("email") {
+				gotError = true
+			}
+		}
+		if gotError != tc.wantError {
+			t.Errorf("email %q: got error %t, want %t, diagnostics: %v", tc.email, gotError, tc.wantError, diags)
+		}
+	}
+}
+
+func TestZoneResourceDeleteAbsent(t *testing.T) {
+	// Only the lookup is answered, a delete request fails the test
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	diags := testDeleteResource(t, &zoneResource{client: client}, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "1"),
+		"name": tftypes.NewValue(tftypes.String, "example.test"),
+	})
+	if diags.HasError() {
+		t.Errorf("deleting an absent zone returned errors: %v", diags)
+	}
+}
+
+func TestClientCreateZoneWithDefaults(t *testing.T) {
+	var createRequest ZoneCreateRequest
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneCreate": func(t *testing.T, body []byte) any {
+			if err := json.Unmarshal(body, &createRequest); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			createResponse := ZoneCreateResponse{}
+			createResponse.Status = "success"
+			createResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test"}
+			return createResponse
+		},
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test", Status: "active"}}
+			return findResponse
+		},
+	})
+	client.options.ZoneDefaults = ZoneDefaults{Type: "MASTER", SOAValues: &SOAValues{TTL: 7200}}
+
+	zoneConfig, err := client.createZoneWithDefaults(context.Background(), "example.test")
+	if err != nil {
+		t.Fatalf("could not create zone: %v", err)
+	}
+	if zoneConfig.ID != "1" {
+		t.Errorf("got zone ID %q, want 1", zoneConfig.ID)
+	}
+	if createRequest.ZoneConfig.Type != "MASTER" || createRequest.ZoneConfig.SOAValues == nil || createRequest.ZoneConfig.SOAValues.TTL != 7200 {
+		t.Errorf("got zone config %+v, want the zone defaults of the provider", createRequest.ZoneConfig)
+	}
+	if !createRequest.UseDefaultNameserverSet {
+		t.Errorf("zone was not created with the default nameserver set")
+	}
+}
+
+func TestAddDefaultCAARecord(t *testing.T) {
+	records := addDefaultCAARecord([]DNSRecord{
+		{Name: "www.example.test", Type: "A", Content: "192.0.2.1"},
+	}, "example.test", "letsencrypt.org", "managed by Terraform")
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want the CAA record added", len(records))
+	}
+	want := DNSRecord{Name: "example.test", Type: "CAA", Content: `0 issue "letsencrypt.org"`, Comments: "managed by Terraform"}
+	if records[1] != want {
+		t.Errorf("got record %+v, want %+v", records[1], want)
+	}
+
+	// An issue record from the zonefile takes precedence
+	zonefileRecords, err := parseZonefile("example.test", `@ 3600 IN CAA 0 issue "sectigo.com"`)
+	if err != nil {
+		t.Fatalf("could not parse zonefile: %v", err)
+	}
+	records = addDefaultCAARecord(zonefileRecords, "example.test", "letsencrypt.org", "")
+	if len(records) != 1 || records[0].Content != `0 issue "sectigo.com"` {
+		t.Errorf("got records %+v, want only the CAA record of the zonefile", records)
+	}
+}
+
+func TestZoneResourceDeletionProtection(t *testing.T) {
+	// No requests are answered, the protection has to fail before the lookup
+	client := newTestClient(t, map[string]testHandler{})
+
+	diags := testDeleteResource(t, &zoneResource{client: client}, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "1"),
+		"name":                tftypes.NewValue(tftypes.String, "example.test"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if !diags.HasError() {
+		t.Errorf("deleting a protected zone returned no error")
+	}
+}
+
+func TestDSRecord(t *testing.T) {
+	// Example key of RFC 4034 section 5.4 with the digest of RFC 4509 section 2.3
+	keyData := KeyData{
+		Flags:     256,
+		Protocol:  3,
+		Algorithm: 5,
+		PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw==",
+	}
+	want := "60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"
+	if got := dsRecord("dskey.example.com", keyData); got != want {
+		t.Errorf("got DS record %q, want %q", got, want)
+	}
+
+	keyData.PublicKey = "not base64"
+	if got := dsRecord("dskey.example.com", keyData); got != "" {
+		t.Errorf("got DS record %q for an invalid key, want none", got)
+	}
+}
+
+func TestDNSSecOptionsMode(t *testing.T) {
+	model := &zoneDNSSECModel{
+		Algorithm: types.StringValue("ECDSAP256SHA256"),
+		NSECMode:  types.StringValue("nsec3"),
+		Keys: []zoneDNSSECKeyModel{{
+			Flags:     types.Int64Value(257),
+			Algorithm: types.Int64Value(13),
+			PublicKey: types.StringValue("a2V5"),
+		}},
+	}
+
+	if mode, options := dnsSecOptions(nil, "manual"); mode != "off" || options != nil {
+		t.Errorf("got mode %q and options %v without dnssec, want off", mode, options)
+	}
+
+	// The keys are generated by hosting.de in automatic mode
+	mode, options := dnsSecOptions(model, "")
+	if mode != "automatic" || len(options.Keys) != 0 {
+		t.Errorf("got mode %q with %d keys by default, want automatic without keys", mode, len(options.Keys))
+	}
+
+	mode, options = dnsSecOptions(model, "manual")
+	want := KeyData{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "a2V5"}
+	if mode != "manual" || len(options.Keys) != 1 || options.Keys[0].KeyData != want {
+		t.Errorf("got mode %q with keys %v, want manual with key %v", mode, options.Keys, want)
+	}
+}
+
+func TestValidateDNSSECMode(t *testing.T) {
+	zsk := zoneDNSSECKeyModel{Flags: types.Int64Value(256), Algorithm: types.Int64Value(13), PublicKey: types.StringValue("a2V5")}
+	ksk := zoneDNSSECKeyModel{Flags: types.Int64Value(257), Algorithm: types.Int64Value(13), PublicKey: types.StringValue("a2V5")}
+
+	for _, tc := range []struct {
+		name      string
+		dnssec    *zoneDNSSECModel
+		mode      types.String
+		wantError bool
+	}{
+		{name: "unsigned", mode: types.StringNull()},
+		{name: "mode without dnssec", mode: types.StringValue("automatic"), wantError: true},
+		{name: "automatic", dnssec: &zoneDNSSECModel{}, mode: types.StringNull()},
+		{name: "keys in automatic mode", dnssec: &zoneDNSSECModel{Keys: []zoneDNSSECKeyModel{ksk}}, mode: types.StringValue("automatic"), wantError: true},
+		{name: "manual", dnssec: &zoneDNSSECModel{Keys: []zoneDNSSECKeyModel{zsk, ksk}}, mode: types.StringValue("manual")},
+		{name: "manual without keys", dnssec: &zoneDNSSECModel{}, mode: types.StringValue("manual"), wantError: true},
+		{name: "manual without key signing key", dnssec: &zoneDNSSECModel{Keys: []zoneDNSSECKeyModel{zsk}}, mode: types.StringValue("manual"), wantError: true},
+	} {
+		diags := validateDNSSECMode(zoneResourceModel{DNSSEC: tc.dnssec, DNSSECMode: tc.mode})
+		if diags.HasError() != tc.wantError {
+			t.Errorf("%s: got error %t, want %t, diagnostics: %v", tc.name, diags.HasError(), tc.wantError, diags)
+		}
+	}
+}
+
+func TestWaitForDNSSECKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		keys    string
+		delay   time.Duration
+		wantErr bool
+	}{
+		{name: "key signing key", keys: `[{"keyData": {"flags": 256}}, {"keyData": {"flags": 257}}]`},
+		{name: "zone signing key only", keys: `[{"keyData": {"flags": 256}}]`, wantErr: true},
+		{name: "no keys", keys: `[]`, wantErr: true},
+		// The timeout expires during the request
+		{name: "slow request", keys: `[]`, delay: 100 * time.Millisecond, wantErr: true},
+	} {
+		client := newTestClient(t, map[string]testHandler{
+			"/zoneDnsSecOptionsGet": func(t *testing.T, _ []byte) any {
+				time.Sleep(tc.delay)
+				return json.RawMessage(`{"status": "success", "response": {"keys": ` + tc.keys + `}}`)
+			},
+		})
+
+		// The timeout expires before the next poll
+		_, err := client.waitForDNSSECKeys(context.Background(), "1", 10*time.Millisecond)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tc.name, err, tc.wantErr)
+		}
+		if err != nil && !strings.Contains(err.Error(), "no key signing key after the dnssec_keys_timeout of 10ms") {
+			t.Errorf("%s: got error %q, want it to mention the missing key and the timeout", tc.name, err)
+		}
+	}
+}
+
+func TestDNSSECKeysTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		value     types.String
+		want      time.Duration
+		wantError bool
+	}{
+		{value: types.StringNull(), want: defaultDNSSECKeysTimeout},
+		{value: types.StringValue("2m"), want: 2 * time.Minute},
+		{value: types.StringValue("0s"), want: 0},
+		{value: types.StringValue("-1m"), wantError: true},
+		{value: types.StringValue("soon"), wantError: true},
+	} {
+		diags := validateDNSSECKeysTimeout(tc.value)
+		if diags.HasError() != tc.wantError {
+			t.Errorf("%s: got error %t, want %t", tc.value, diags.HasError(), tc.wantError)
+		}
+		if got := dnssecKeysTimeout(tc.value); !tc.wantError && got != tc.want {
+			t.Errorf("%s: got timeout %s, want %s", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestZoneResourceModifyPlanDefaults(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := fwresource.SchemaResponse{}
+	NewZoneResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	dnssecType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["dnssec"].(tftypes.Object)
+	dnssec := func(algorithm, nsecMode tftypes.Value) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range dnssecType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["algorithm"] = algorithm
+		attributes["nsec_mode"] = nsecMode
+		return tftypes.NewValue(dnssecType, attributes)
+	}
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	null := tftypes.NewValue(tftypes.String, nil)
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	for _, tc := range []struct {
+		name          string
+		defaults      ZoneDefaults
+		config        map[string]tftypes.Value
+		plan          map[string]tftypes.Value
+		state         map[string]tftypes.Value
+		wantType      string
+		wantAlgorithm string
+		wantNSECMode  string
+	}{
+		{
+			name:     "new zone",
+			config:   map[string]tftypes.Value{"name": str("example.test")},
+			plan:     map[string]tftypes.Value{"name": str("example.test"), "type": unknown},
+			wantType: "NATIVE",
+		},
+		{
+			name:     "new zone with default type",
+			defaults: ZoneDefaults{Type: "MASTER"},
+			config:   map[string]tftypes.Value{"name": str("example.test")},
+			plan:     map[string]tftypes.Value{"name": str("example.test"), "type": unknown},
+			wantType: "MASTER",
+		},
+		{
+			name:     "configured type",
+			defaults: ZoneDefaults{Type: "MASTER"},
+			config:   map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE")},
+			plan:     map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE")},
+			wantType: "NATIVE",
+		},
+		{
+			// Changing the default must not replace existing zones
+			name:     "existing zone",
+			defaults: ZoneDefaults{Type: "MASTER"},
+			config:   map[string]tftypes.Value{"name": str("example.test")},
+			plan:     map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE")},
+			state:    map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE")},
+			wantType: "NATIVE",
+		},
+		{
+			name:          "new signed zone",
+			config:        map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(null, null)},
+			plan:          map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(unknown, unknown)},
+			wantType:      "NATIVE",
+			wantAlgorithm: "ECDSAP256SHA256",
+			wantNSECMode:  "nsec3",
+		},
+		{
+			name:          "new signed zone with default algorithm",
+			defaults:      ZoneDefaults{DNSSECAlgorithm: "ED25519", DNSSECNSECMode: "nsec"},
+			config:        map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(null, null)},
+			plan:          map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(unknown, unknown)},
+			wantType:      "NATIVE",
+			wantAlgorithm: "ED25519",
+			wantNSECMode:  "nsec",
+		},
+		{
+			name:          "configured algorithm",
+			defaults:      ZoneDefaults{DNSSECAlgorithm: "ED25519"},
+			config:        map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(str("RSASHA256"), null)},
+			plan:          map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(str("RSASHA256"), unknown)},
+			wantType:      "NATIVE",
+			wantAlgorithm: "RSASHA256",
+			wantNSECMode:  "nsec3",
+		},
+		{
+			// Changing the default must not roll the keys of signed zones over
+			name:          "existing signed zone",
+			defaults:      ZoneDefaults{DNSSECAlgorithm: "ED25519"},
+			config:        map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(null, null)},
+			plan:          map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(unknown, unknown)},
+			state:         map[string]tftypes.Value{"name": str("example.test"), "type": str("NATIVE"), "dnssec": dnssec(str("RSASHA256"), str("nsec3"))},
+			wantType:      "NATIVE",
+			wantAlgorithm: "RSASHA256",
+			wantNSECMode:  "nsec3",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &zoneResource{client: &Client{options: ClientOptions{ZoneDefaults: tc.defaults}}}
+			plan, diags := testModifyPlan(t, r, tc.config, tc.plan, tc.state)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var zoneType, algorithm, nsecMode types.String
+			diags.Append(plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+			diags.Append(plan.GetAttribute(ctx, path.Root("dnssec").AtName("algorithm"), &algorithm)...)
+			diags.Append(plan.GetAttribute(ctx, path.Root("dnssec").AtName("nsec_mode"), &nsecMode)...)
+			if diags.HasError() {
+				t.Fatalf("could not read plan: %v", diags)
+			}
+			if zoneType.ValueString() != tc.wantType {
+				t.Errorf("got type %s, want %s", zoneType, tc.wantType)
+			}
+			if algorithm.ValueString() != tc.wantAlgorithm || nsecMode.ValueString() != tc.wantNSECMode {
+				t.Errorf("got algorithm %s and nsec_mode %s, want %q and %q", algorithm, nsecMode, tc.wantAlgorithm, tc.wantNSECMode)
+			}
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanMasterIPs(t *testing.T) {
+	masterIPs := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "192.0.2.53")})
+
+	for zoneType, wantError := range map[string]bool{
+		"SLAVE":  false,
+		"NATIVE": true,
+	} {
+		r := &zoneResource{client: &Client{options: ClientOptions{ZoneDefaults: ZoneDefaults{Type: zoneType}}}}
+		values := map[string]tftypes.Value{
+			"name":       tftypes.NewValue(tftypes.String, "example.test"),
+			"master_ips": masterIPs,
+		}
+
+		// The type of the zone is only known once it is planned
+		if diags := testValidateResourceConfig(t, NewZoneResource(), values); len(diags) != 0 {
+			t.Errorf("%s: unexpected diagnostics validating the config: %v", zoneType, diags)
+		}
+
+		plan := map[string]tftypes.Value{
+			"name":       values["name"],
+			"type":       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"master_ips": masterIPs,
+		}
+		_, diags := testModifyPlan(t, r, values, plan, nil)
+		if diags.HasError() != wantError {
+			t.Errorf("%s: got error %t, want %t, diagnostics: %v", zoneType, diags.HasError(), wantError, diags)
+		}
+	}
+}
+
+func TestZoneResourceReadAbsent(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zonesFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	state, diags := testReadResource(t, &zoneResource{client: client}, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "1"),
+		"name": tftypes.NewValue(tftypes.String, "example.test"),
+	})
+	if diags.HasError() {
+		t.Errorf("reading an absent zone returned errors: %v", diags)
+	}
+	if !state.Raw.IsNull() {
+		t.Errorf("an absent zone wasn't removed from the state")
+	}
+}
+
+func TestZoneResourceCreateNotReady(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneCreate": func(t *testing.T, _ []byte) any {
+			createResponse := ZoneCreateResponse{}
+			createResponse.Status = "success"
+			createResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test", Type: "NATIVE", Status: "pending"}
+			return createResponse
+		},
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test", Status: "pending"}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+	})
+
+	state, diags := testCreateResource(t, &zoneResource{client: client}, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":          tftypes.NewValue(tftypes.String, "example.test"),
+		"type":          tftypes.NewValue(tftypes.String, "NATIVE"),
+		"ready":         tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		"record_count":  tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+		"ready_timeout": tftypes.NewValue(tftypes.String, "10ms"),
+	})
+	if !diags.HasError() {
+		t.Fatalf("a zone that didn't become ready returned no error")
+	}
+	if !strings.Contains(fmt.Sprint(diags), "ready_timeout of 10ms") {
+		t.Errorf("got diagnostics %v, want them to mention the ready_timeout", diags)
+	}
+
+	// The created zone is kept in the state, without unknown values
+	var id types.String
+	state.GetAttribute(context.Background(), path.Root("id"), &id)
+	if id.ValueString() != "1" {
+		t.Errorf("got ID %s in the state, want the created zone", id)
+	}
+	if !state.Raw.IsFullyKnown() {
+		t.Errorf("state has unknown values: %v", state.Raw)
+	}
+}
+
+func TestZoneResourceCreateDNSSEC(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		options    string
+		wantError  bool
+		wantStatus string
+	}{
+		{
+			name:       "options read",
+			options:    `{"status": "success", "response": {"publishKsk": true, "keys": [{"keyData": {"flags": 257, "protocol": 3, "algorithm": 13, "publicKey": "a2V5"}, "status": "published"}]}}`,
+			wantStatus: "published",
+		},
+		{
+			name:      "options not read",
+			options:   `{"status": "error", "errors": [{"text": "internal error"}]}`,
+			wantError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var createRequest ZoneCreateRequest
+			client := newTestClient(t, map[string]testHandler{
+				"/zoneCreate": func(t *testing.T, body []byte) any {
+					if err := json.Unmarshal(body, &createRequest); err != nil {
+						t.Fatalf("invalid request body: %v", err)
+					}
+					createResponse := ZoneCreateResponse{}
+					createResponse.Status = "success"
+					createResponse.Response.ZoneConfig = ZoneConfig{ID: "1", Name: "example.test", Type: "NATIVE", Status: "active"}
+					return createResponse
+				},
+				"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+					findResponse := ZoneConfigsFindResponse{}
+					findResponse.Status = "success"
+					findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test", Status: "active"}}
+					findResponse.Response.TotalEntries = 1
+					return findResponse
+				},
+				"/zoneDnsSecOptionsGet": func(t *testing.T, _ []byte) any {
+					return json.RawMessage(tc.options)
+				},
+				"/recordsFind": func(t *testing.T, _ []byte) any {
+					findResponse := RecordsFindResponse{}
+					findResponse.Status = "success"
+					return findResponse
+				},
+			})
+
+			r := &zoneResource{client: client}
+			schemaResp := fwresource.SchemaResponse{}
+			r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+			dnssecType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object).AttributeTypes["dnssec"].(tftypes.Object)
+			dnssec := map[string]tftypes.Value{}
+			for name, attributeType := range dnssecType.AttributeTypes {
+				dnssec[name] = tftypes.NewValue(attributeType, nil)
+			}
+			dnssec["algorithm"] = tftypes.NewValue(tftypes.String, "ECDSAP256SHA256")
+			dnssec["nsec_mode"] = tftypes.NewValue(tftypes.String, "nsec3")
+			dnssec["publish_ds_at_registrar"] = tftypes.NewValue(tftypes.Bool, true)
+			dnssec["ds_publish_status"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			dnssec["ds_record"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+			state, diags := testCreateResource(t, r, map[string]tftypes.Value{
+				"id":                  tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"name":                tftypes.NewValue(tftypes.String, "example.test"),
+				"type":                tftypes.NewValue(tftypes.String, "NATIVE"),
+				"dnssec":              tftypes.NewValue(dnssecType, dnssec),
+				"dnssec_mode":         tftypes.NewValue(tftypes.String, "automatic"),
+				"dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "0s"),
+			})
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("got diagnostics %v, want error %t", diags, tc.wantError)
+			}
+			if createRequest.DNSSecOptions == nil || !createRequest.DNSSecOptions.PublishKSK {
+				t.Errorf("got DNSSEC options %+v, want publishKsk to be sent", createRequest.DNSSecOptions)
+			}
+
+			// The created zone is kept in the state even if reading its
+			// DNSSEC options failed
+			var id types.String
+			state.GetAttribute(context.Background(), path.Root("id"), &id)
+			if id.ValueString() != "1" {
+				t.Errorf("got ID %s in the state, want the created zone", id)
+			}
+			var status types.String
+			state.GetAttribute(context.Background(), path.Root("dnssec").AtName("ds_publish_status"), &status)
+			if status.ValueString() != tc.wantStatus {
+				t.Errorf("got ds_publish_status %s, want %q", status, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestZoneResourceUpdateReapplyTemplateFailure(t *testing.T) {
+	zoneConfig := ZoneConfig{
+		ID:             "1",
+		Name:           "example.test",
+		Type:           "NATIVE",
+		EMailAddress:   "hostmaster@example.test",
+		Status:         "active",
+		TemplateValues: json.RawMessage(`{"templateId": "t1", "tieToTemplate": false}`),
+	}
+	updated := false
+	client := newTestClient(t, map[string]testHandler{
+		"/zonesFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZonesFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []Zone{{ZoneConfig: zoneConfig}}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/zoneUpdate": func(t *testing.T, _ []byte) any {
+			updated = true
+			updateResponse := ZoneUpdateResponse{}
+			updateResponse.Status = "success"
+			updateResponse.Response.ZoneConfig = zoneConfig
+			updateResponse.Response.ZoneConfig.EMailAddress = "admin@example.test"
+			return updateResponse
+		},
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []ZoneConfig{zoneConfig}
+			findResponse.Response.TotalEntries = 1
+			return findResponse
+		},
+		"/recordTemplatesFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordTemplatesFindResponse{}
+			findResponse.Status = "error"
+			findResponse.Errors = []APIError{{Text: "Template not found"}}
+			return findResponse
+		},
+	})
+
+	values := func(email string, reapply bool) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":               tftypes.NewValue(tftypes.String, "1"),
+			"name":             tftypes.NewValue(tftypes.String, "example.test"),
+			"type":             tftypes.NewValue(tftypes.String, "NATIVE"),
+			"email":            tftypes.NewValue(tftypes.String, email),
+			"reapply_template": tftypes.NewValue(tftypes.Bool, reapply),
+			"record_count":     tftypes.NewValue(tftypes.Number, 3),
+		}
+	}
+	state, diags := testUpdateResource(t, &zoneResource{client: client}, values("admin@example.test", true), values("admin@example.test", true), values("hostmaster@example.test", false))
+	if !updated {
+		t.Fatalf("zone was not updated")
+	}
+	if !diags.HasError() {
+		t.Errorf("a failed template returned no error")
+	}
+
+	// The updated zone is saved, the template is retried by the next apply
+	var email types.String
+	state.GetAttribute(context.Background(), path.Root("email"), &email)
+	if email.ValueString() != "admin@example.test" {
+		t.Errorf("got email %s in the state, want the updated zone", email)
+	}
+	var reapply types.Bool
+	state.GetAttribute(context.Background(), path.Root("reapply_template"), &reapply)
+	if reapply.ValueBool() {
+		t.Errorf("reapply_template was saved although the template was not re-applied")
+	}
+}
+
+func TestZoneResourceUpdateDNSSECKeysTimeout(t *testing.T) {
+	// Any request fails the test
+	client := newTestClient(t, map[string]testHandler{})
+
+	values := func(timeout string, recordCount any) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":                  tftypes.NewValue(tftypes.String, "1"),
+			"name":                tftypes.NewValue(tftypes.String, "example.test"),
+			"type":                tftypes.NewValue(tftypes.String, "NATIVE"),
+			"email":               tftypes.NewValue(tftypes.String, "hostmaster@example.test"),
+			"dnssec_keys_timeout": tftypes.NewValue(tftypes.String, timeout),
+			"record_count":        tftypes.NewValue(tftypes.Number, recordCount),
+		}
+	}
+	config := values("20m", nil)
+	delete(config, "id")
+	state, diags := testUpdateResource(t, &zoneResource{client: client}, config, values("20m", tftypes.UnknownValue), values("10m", 3))
+	if diags.HasError() {
+		t.Fatalf("updating dnssec_keys_timeout returned errors: %v", diags)
+	}
+
+	var timeout types.String
+	var recordCount types.Int64
+	state.GetAttribute(context.Background(), path.Root("dnssec_keys_timeout"), &timeout)
+	state.GetAttribute(context.Background(), path.Root("record_count"), &recordCount)
+	if timeout.ValueString() != "20m" || recordCount.ValueInt64() != 3 {
+		t.Errorf("got dnssec_keys_timeout %s and record_count %s in the state, want 20m and 3", timeout, recordCount)
+	}
+}
+
+func TestOnlyTimeoutsChanged(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := fwresource.SchemaResponse{}
+	NewZoneResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	value := func(values map[string]tftypes.Value) tftypes.Value {
+		attributes := map[string]tftypes.Value{}
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+			if value, ok := values[name]; ok {
+				attributes[name] = value
+			}
+		}
+		return tftypes.NewValue(objectType, attributes)
+	}
+	state := value(map[string]tftypes.Value{
+		"email":               tftypes.NewValue(tftypes.String, "hostmaster@example.test"),
+		"dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "10m"),
+		"record_count":        tftypes.NewValue(tftypes.Number, 3),
+	})
+
+	for _, tc := range []struct {
+		name   string
+		config map[string]tftypes.Value
+		plan   map[string]tftypes.Value
+		want   bool
+	}{
+		{
+			name:   "timeout",
+			config: map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m")},
+			plan:   map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m"), "record_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+			want:   true,
+		},
+		{
+			name:   "timeout and email",
+			config: map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "admin@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m")},
+			plan:   map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "admin@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m"), "record_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		},
+		{
+			name:   "timeout and unknown email",
+			config: map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, tftypes.UnknownValue), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m")},
+			plan:   map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, tftypes.UnknownValue), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "20m"), "record_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+		},
+		{
+			name:   "ready timeout",
+			config: map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "10m"), "ready_timeout": tftypes.NewValue(tftypes.String, "5m")},
+			plan:   map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "10m"), "ready_timeout": tftypes.NewValue(tftypes.String, "5m"), "record_count": tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)},
+			want:   true,
+		},
+		{
+			name:   "nothing",
+			config: map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "10m")},
+			plan:   map[string]tftypes.Value{"email": tftypes.NewValue(tftypes.String, "hostmaster@example.test"), "dnssec_keys_timeout": tftypes.NewValue(tftypes.String, "10m"), "record_count": tftypes.NewValue(tftypes.Number, 3)},
+		},
+	} {
+		got, err := onlyTimeoutsChanged(value(tc.config), value(tc.plan), state)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
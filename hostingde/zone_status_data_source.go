@@ -0,0 +1,206 @@
+package hostingde
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zoneStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneStatusDataSource{}
+)
+
+// NewZoneStatusDataSource is a helper function to simplify the provider implementation.
+func NewZoneStatusDataSource() datasource.DataSource {
+	return &zoneStatusDataSource{}
+}
+
+// zoneStatusDataSource is the data source implementation.
+type zoneStatusDataSource struct {
+	client *Client
+}
+
+// zoneStatusDataSourceModel maps the data source schema data.
+type zoneStatusDataSourceModel struct {
+	ZoneName          types.String               `tfsdk:"zone_name"`
+	DNSSECKeysTimeout types.String               `tfsdk:"dnssec_keys_timeout"`
+	ID                types.String               `tfsdk:"id"`
+	Status            types.String               `tfsdk:"status"`
+	Active            types.Bool                 `tfsdk:"active"`
+	LastChangeDate    types.String               `tfsdk:"last_change_date"`
+	DNSSECMode        types.String               `tfsdk:"dnssec_mode"`
+	DNSSECKeys        []zoneStatusDNSSECKeyModel `tfsdk:"dnssec_keys"`
+	DSRecord          types.String               `tfsdk:"ds_record"`
+}
+
+// zoneStatusDNSSECKeyModel maps a single DNSSEC key of the data source.
+type zoneStatusDNSSECKeyModel struct {
+	KeyTag    types.Int64  `tfsdk:"key_tag"`
+	Flags     types.Int64  `tfsdk:"flags"`
+	Algorithm types.Int64  `tfsdk:"algorithm"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// Metadata returns the data source type name.
+func (d *zoneStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *zoneStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the status of a DNS zone and its DNSSEC keys as seen by hosting.de, for monitoring. " +
+			"The API doesn't run health checks on zones and DNSSEC keys have no expiry date, so neither is available.",
+		Attributes: map[string]schema.Attribute{
+			"zone_name": schema.StringAttribute{
+				Description: "Domain name of the zone. Reading fails if the zone doesn't exist.",
+				Required:    true,
+			},
+			"dnssec_keys_timeout": schema.StringAttribute{
+				Description: "If set, reading a zone in automatic DNSSEC mode waits up to this duration, like \"10m\", " +
+					"for hosting.de to generate the key signing key, so ds_record is known right after signing was enabled. " +
+					"Reading fails if no key appeared in time. By default the keys are read without waiting.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Numeric identifier of the zone.",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the zone config in hosting.de, for example active, or blocked while a change is processed.",
+				Computed:    true,
+			},
+			"active": schema.BoolAttribute{
+				Description: "Whether the zone status is active. Any other status needs attention if it persists.",
+				Computed:    true,
+			},
+			"last_change_date": schema.StringAttribute{
+				Description: "Time of the last change to the zone config, as reported by the API.",
+				Computed:    true,
+			},
+			"dnssec_mode": schema.StringAttribute{
+				Description: "DNSSEC mode of the zone, off if the zone isn't signed.",
+				Computed:    true,
+			},
+			"ds_record": schema.StringAttribute{
+				Description: "DS record to publish at the registrar, derived from the key signing key with a SHA-256 digest, " +
+					"like \"12345 13 2 ABCD...\". Null while the zone has no key signing key.",
+				Computed: true,
+			},
+			"dnssec_keys": schema.ListNestedAttribute{
+				Description: "DNSSEC keys of the zone. Empty if the zone isn't signed.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							Description: "Key tag of the key.",
+							Computed:    true,
+						},
+						"flags": schema.Int64Attribute{
+							Description: "DNSKEY flags, 257 for the key signing key and 256 for zone signing keys.",
+							Computed:    true,
+						},
+						"algorithm": schema.Int64Attribute{
+							Description: "DNSKEY algorithm number of the key.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the key in hosting.de. For the key signing key, this is the publishing status of the DS record at the registrar.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zoneStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zoneStatusDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfig, err := d.client.getZoneConfigByName(ctx, state.ZoneName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zone",
+			"Could not read hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	dnsSecMode := zoneConfig.DNSSecMode
+	if dnsSecMode == "" {
+		dnsSecMode = "off"
+	}
+
+	state.ID = types.StringValue(zoneConfig.ID)
+	state.Status = types.StringValue(zoneConfig.Status)
+	state.Active = types.BoolValue(zoneConfig.Status == "active")
+	state.LastChangeDate = types.StringValue(zoneConfig.LastChangeDate)
+	state.DNSSECMode = types.StringValue(dnsSecMode)
+
+	resp.Diagnostics.Append(validateDNSSECKeysTimeout(state.DNSSECKeysTimeout)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DNSSECKeys = []zoneStatusDNSSECKeyModel{}
+	state.DSRecord = types.StringNull()
+	if dnsSecMode != "off" {
+		var dnsSecResp *DNSSecOptionsGetResponse
+		if dnsSecMode == "automatic" && !state.DNSSECKeysTimeout.IsNull() {
+			dnsSecResp, err = d.client.waitForDNSSECKeys(ctx, zoneConfig.ID, dnssecKeysTimeout(state.DNSSECKeysTimeout))
+		} else {
+			dnsSecResp, err = d.client.getDNSSecOptions(ctx, DNSSecOptionsGetRequest{
+				BaseRequest:  &BaseRequest{},
+				ZoneConfigId: zoneConfig.ID,
+			})
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone DNSSEC options",
+				"Could not read DNSSEC options of hosting.de DNS zone "+state.ZoneName.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+
+		for _, key := range dnsSecResp.Response.Keys {
+			state.DNSSECKeys = append(state.DNSSECKeys, zoneStatusDNSSECKeyModel{
+				KeyTag:    types.Int64Value(int64(key.KeyTag)),
+				Flags:     types.Int64Value(int64(key.KeyData.Flags)),
+				Algorithm: types.Int64Value(int64(key.KeyData.Algorithm)),
+				Status:    types.StringValue(key.Status),
+			})
+			if ds := dsRecord(zoneConfig.Name, key.KeyData); key.KeyData.Flags == 257 && ds != "" {
+				state.DSRecord = types.StringValue(ds)
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zoneStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
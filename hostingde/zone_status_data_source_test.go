@@ -0,0 +1,45 @@
+package hostingde
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZoneStatusDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example3.test"
+  type = "NATIVE"
+  email = "hostmaster@example3.test"
+}
+data "hostingde_zone_status" "test" {
+  zone_name = hostingde_zone.test.name
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.hostingde_zone_status.test", "id", "hostingde_zone.test", "id"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_status.test", "status", "active"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_status.test", "active", "true"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_status.test", "dnssec_mode", "off"),
+					resource.TestCheckResourceAttr("data.hostingde_zone_status.test", "dnssec_keys.#", "0"),
+				),
+			},
+			// Missing zone testing
+			{
+				Config: providerConfig + `
+data "hostingde_zone_status" "test" {
+  zone_name = "missing.example3.test"
+}
+`,
+				ExpectError: regexp.MustCompile(`zone missing.example3.test not found`),
+			},
+		},
+	})
+}
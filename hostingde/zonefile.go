@@ -0,0 +1,107 @@
+package hostingde
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// parseZonefile parses a zonefile in BIND master file format into records
+// for the zone. The SOA record and the NS records at the apex are skipped, as
+// hosting.de manages them for the zone. Errors contain the line number.
+func parseZonefile(zoneName string, zonefile string) ([]DNSRecord, error) {
+	origin := dns.Fqdn(zoneName)
+	parser := dns.NewZoneParser(strings.NewReader(zonefile), origin, "")
+
+	records := []DNSRecord{}
+	n := 0
+	for rr, ok := parser.Next(); ok; rr, ok = parser.Next() {
+		n++
+		header := rr.Header()
+		if !dns.IsSubDomain(origin, header.Name) {
+			return nil, fmt.Errorf("line %d: record %s is not part of zone %s", zonefileRecordLine(zonefile, origin, n), header.Name, zoneName)
+		}
+
+		if header.Rrtype == dns.TypeSOA || (header.Rrtype == dns.TypeNS && header.Name == origin) {
+			continue
+		}
+
+		record := recordFromRR(rr)
+		if !slices.Contains(knownRecordTypes, record.Type) {
+			return nil, fmt.Errorf("line %d: record %s has type %s, which is not supported by hosting.de", zonefileRecordLine(zonefile, origin, n), header.Name, record.Type)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := parser.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// zonefileRecordLine returns the line on which the n-th record of the
+// zonefile starts, counting from 1. The zone parser doesn't report the line of
+// a record, so growing prefixes of the zonefile are parsed until one contains
+// the record. Prefixes ending within a record spanning several lines fail to
+// parse.
+func zonefileRecordLine(zonefile string, origin string, n int) int {
+	lines := strings.SplitAfter(zonefile, "\n")
+	start := 1
+	for i := range lines {
+		parser := dns.NewZoneParser(strings.NewReader(strings.Join(lines[:i+1], "")), origin, "")
+		count := 0
+		for _, ok := parser.Next(); ok; _, ok = parser.Next() {
+			count++
+		}
+		if parser.Err() != nil {
+			continue
+		}
+		if count >= n {
+			return start
+		}
+		start = i + 2
+	}
+
+	return start
+}
+
+// recordFromRR converts a resource record to a record in the representation
+// of the API, with target names relative to the root and the priority of MX
+// and SRV records in the priority field.
+func recordFromRR(rr dns.RR) DNSRecord {
+	header := rr.Header()
+	record := DNSRecord{
+		Name: strings.TrimSuffix(header.Name, "."),
+		Type: dns.TypeToString[header.Rrtype],
+		TTL:  int(header.Ttl),
+	}
+
+	switch rr := rr.(type) {
+	case *dns.CNAME:
+		record.Content = strings.TrimSuffix(rr.Target, ".")
+	case *dns.NS:
+		record.Content = strings.TrimSuffix(rr.Ns, ".")
+	case *dns.PTR:
+		record.Content = strings.TrimSuffix(rr.Ptr, ".")
+	case *dns.MX:
+		// hosting.de has a separate type for null MX records
+		if rr.Mx == "." {
+			record.Type = "NULLMX"
+			break
+		}
+		record.Content = strings.TrimSuffix(rr.Mx, ".")
+		record.Priority = int(rr.Preference)
+	case *dns.SRV:
+		record.Content = strconv.Itoa(int(rr.Weight)) + " " + strconv.Itoa(int(rr.Port)) + " " + strings.TrimSuffix(rr.Target, ".")
+		record.Priority = int(rr.Priority)
+	default:
+		record.Content = strings.TrimSpace(strings.TrimPrefix(rr.String(), header.String()))
+	}
+
+	return record
+}
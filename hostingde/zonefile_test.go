@@ -0,0 +1,59 @@
+package hostingde
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseZonefile(t *testing.T) {
+	zonefile := `$TTL 3600
+@       86400 IN SOA ns1.example.net. hostmaster.example.test. 2024010101 86400 7200 3600000 3600
+@       86400 IN NS  ns1.example.net.
+@             IN A   192.0.2.1
+www           IN CNAME @
+@             IN MX  10 mail
+_sip._udp 300 IN SRV 20 5 5060 sip.example.test.
+@             IN TXT "v=spf1 -all"
+sub           IN NS  ns1.example.net.
+`
+
+	records, err := parseZonefile("example.test", zonefile)
+	if err != nil {
+		t.Fatalf("parseZonefile returned an error: %v", err)
+	}
+
+	want := []DNSRecord{
+		{Name: "example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www.example.test", Type: "CNAME", Content: "example.test", TTL: 3600},
+		{Name: "example.test", Type: "MX", Content: "mail.example.test", TTL: 3600, Priority: 10},
+		{Name: "_sip._udp.example.test", Type: "SRV", Content: "5 5060 sip.example.test", TTL: 300, Priority: 20},
+		{Name: "example.test", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 3600},
+		// Delegations below the apex are kept
+		{Name: "sub.example.test", Type: "NS", Content: "ns1.example.net", TTL: 3600},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("parseZonefile() =\n%+v\nwant:\n%+v", records, want)
+	}
+}
+
+func TestParseZonefileErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		zonefile string
+		want     string
+	}{
+		"syntax error":   {zonefile: "www IN A 192.0.2.1\nmail IN A not-an-ip\n", want: "line: 2"},
+		"foreign record": {zonefile: "www IN A 192.0.2.1\n\nwww.example.net. IN A 192.0.2.1\n", want: "line 3: record www.example.net. is not part of zone"},
+		"unsupported type": {
+			zonefile: "$TTL 300\n; comment\nwww IN A 192.0.2.1\nhost IN HINFO (\n  \"PC\"\n  \"Linux\"\n)\n",
+			want:     "line 4: record host.example.test. has type HINFO",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseZonefile("example.test", tc.zonefile)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %v, want an error containing %q", err, tc.want)
+			}
+		})
+	}
+}
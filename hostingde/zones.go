@@ -1,101 +1,380 @@
 package hostingde
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
+// defaultZoneReadyTimeout is how long to wait for a zone to become active
+// after it was created or updated, if not configured otherwise.
+const defaultZoneReadyTimeout = 2 * time.Minute
+
+// zoneReadyInterval is the delay between checks of the zone status.
+const zoneReadyInterval = 2 * time.Second
+
+// defaultDNSSECKeysTimeout is how long to wait for hosting.de to generate the
+// key signing key of a zone in automatic DNSSEC mode, if not configured
+// otherwise.
+const defaultDNSSECKeysTimeout = 10 * time.Minute
+
+// dnssecKeysInterval is the delay between checks of the DNSSEC keys.
+const dnssecKeysInterval = 10 * time.Second
+
 // https://www.hosting.de/api/?json#listing-zones
-func (c *Client) listZones(findRequest ZonesFindRequest) (*ZonesFindResponse, error) {
+func (c *Client) listZones(ctx context.Context, findRequest ZonesFindRequest) (*ZonesFindResponse, error) {
 	uri := c.baseURL + "/zonesFind"
 
-	findResponse := &ZonesFindResponse{}
+	findRequest.Limit = clampLimit(findRequest.Limit)
 
-	rawResp, err := c.doRequest(http.MethodPost, uri, findRequest, findResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
 	if err != nil {
 		return nil, err
 	}
 
+	findResponse, err := decodeEnvelope[ZonesFindResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		return findResponse, err
+	}
+
 	if len(findResponse.Response.Data) == 0 {
-		return nil, fmt.Errorf("%v: uri: %s %s", err, uri, rawResp)
+		return nil, fmt.Errorf("zone %w: %s", errNotFound, toErrorWithNewlines(uri, rawResp))
+	}
+
+	return findResponse, nil
+}
+
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) listZoneConfigs(ctx context.Context, findRequest ZoneConfigsFindRequest) (*ZoneConfigsFindResponse, error) {
+	uri := c.baseURL + "/zoneConfigsFind"
+
+	findRequest.Limit = clampLimit(findRequest.Limit)
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, findRequest)
+	if err != nil {
+		return nil, err
 	}
 
-	if findResponse.Status != "success" && findResponse.Status != "pending" {
-		return findResponse, errors.New(toErrorWithNewlines(uri, rawResp))
+	findResponse, err := decodeEnvelope[ZoneConfigsFindResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		return findResponse, err
 	}
 
+	findResponse.raw = rawResp
+
 	return findResponse, nil
 }
 
+// rawZoneConfigs returns the zone configs of the response as the JSON objects
+// sent by the API, including the fields the provider doesn't model.
+func (r *ZoneConfigsFindResponse) rawZoneConfigs() ([]json.RawMessage, error) {
+	var rawResponse struct {
+		Response struct {
+			Data []json.RawMessage `json:"data"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal(r.raw, &rawResponse); err != nil {
+		return nil, err
+	}
+
+	return rawResponse.Response.Data, nil
+}
+
+// getZoneConfig returns the ZoneConfig with the given ID.
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) getZoneConfig(ctx context.Context, zoneConfigId string) (*ZoneConfig, error) {
+	findRequest := ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneConfigId",
+			Value: zoneConfigId,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	findResponse, err := c.listZoneConfigs(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("zone config %s %w", zoneConfigId, errNotFound)
+	}
+
+	return &findResponse.Response.Data[0], nil
+}
+
+// getZoneConfigByName returns the ZoneConfig of the zone with the given name.
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) getZoneConfigByName(ctx context.Context, zoneName string) (*ZoneConfig, error) {
+	findRequest := ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Filter: FilterOrChain{Filter: Filter{
+			Field: "ZoneName",
+			Value: zoneName,
+		}},
+		Limit: 1,
+		Page:  1,
+	}
+
+	findResponse, err := c.listZoneConfigs(ctx, findRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(findResponse.Response.Data) == 0 {
+		return nil, fmt.Errorf("zone %s %w", zoneName, errNotFound)
+	}
+
+	return &findResponse.Response.Data[0], nil
+}
+
+// listAllZoneConfigs returns all zone configs matching the filter, fetching
+// them page by page like listAllRecords.
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) listAllZoneConfigs(ctx context.Context, filter FilterOrChain) ([]ZoneConfig, error) {
+	zoneConfigs := []ZoneConfig{}
+	for page := 1; ; page++ {
+		findRequest := ZoneConfigsFindRequest{
+			BaseRequest: &BaseRequest{},
+			Filter:      filter,
+			Limit:       maxPageLimit,
+			Page:        page,
+		}
+
+		findResponse, err := c.listZoneConfigs(ctx, findRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		zoneConfigs = append(zoneConfigs, findResponse.Response.Data...)
+
+		if len(zoneConfigs) >= findResponse.Response.TotalEntries || len(findResponse.Response.Data) == 0 {
+			return zoneConfigs, nil
+		}
+	}
+}
+
+// waitForZoneActive polls the zone config until its status is active, as the
+// API provisions zones asynchronously.
+func (c *Client) waitForZoneActive(ctx context.Context, zoneConfigId string, timeout time.Duration) (*ZoneConfig, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		zoneConfig, err := c.getZoneConfig(ctx, zoneConfigId)
+		if err != nil {
+			return nil, err
+		}
+
+		if zoneConfig.Status == "active" {
+			return zoneConfig, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("zone %s is still %s after the ready_timeout of %s", zoneConfigId, zoneConfig.Status, timeout)
+		case <-time.After(zoneReadyInterval):
+		}
+	}
+}
+
+// waitForDNSSECKeys polls the DNSSEC options of the zone until they contain a
+// key signing key, as hosting.de generates the keys asynchronously once
+// signing is enabled. The DS record derived from the key doesn't change until
+// the next key rollover.
+func (c *Client) waitForDNSSECKeys(ctx context.Context, zoneConfigId string, timeout time.Duration) (*DNSSecOptionsGetResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		dnsSecResp, err := c.getDNSSecOptions(ctx, DNSSecOptionsGetRequest{
+			BaseRequest:  &BaseRequest{},
+			ZoneConfigId: zoneConfigId,
+		})
+		// The timeout may expire during a request as well
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("zone %s has no key signing key after the dnssec_keys_timeout of %s: %w", zoneConfigId, timeout, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hasKeySigningKey(dnsSecResp.Response.Keys) {
+			return dnsSecResp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("zone %s has no key signing key after the dnssec_keys_timeout of %s", zoneConfigId, timeout)
+		case <-time.After(dnssecKeysInterval):
+		}
+	}
+}
+
+// hasKeySigningKey reports whether the keys contain a key signing key,
+// flagged with 257.
+func hasKeySigningKey(keys []DNSSecKey) bool {
+	for _, key := range keys {
+		if key.KeyData.Flags == 257 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countZones returns the number of zones of the account. It requests a
+// single zone and uses the totalEntries field of the response.
+// https://www.hosting.de/api/?json#list-zoneconfigs
+func (c *Client) countZones(ctx context.Context) (int, error) {
+	findResponse, err := c.listZoneConfigs(ctx, ZoneConfigsFindRequest{
+		BaseRequest: &BaseRequest{},
+		Limit:       1,
+		Page:        1,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return findResponse.Response.TotalEntries, nil
+}
+
 // https://www.hosting.de/api/?json#creating-new-zones
-func (c *Client) createZone(createRequest ZoneCreateRequest) (*ZoneCreateResponse, error) {
+func (c *Client) createZone(ctx context.Context, createRequest ZoneCreateRequest) (*ZoneCreateResponse, error) {
 	uri := c.baseURL + "/zoneCreate"
 
-	createResponse := &ZoneCreateResponse{}
-
-	rawResp, err := c.doRequest(http.MethodPost, uri, createRequest, createResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, createRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	if createResponse.Status != "success" && createResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	createResponse, err := decodeEnvelope[ZoneCreateResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		if createResponse != nil {
+			if quotaErr := quotaExceeded(uri, rawResp, createResponse.Errors); quotaErr != nil {
+				return nil, quotaErr
+			}
+		}
+		return nil, err
 	}
 
 	return createResponse, nil
 }
 
+// createZoneWithDefaults creates a zone with the provider's zone defaults,
+// like hostingde_zone does for a zone configuring nothing but its name, and
+// waits until it is active.
+func (c *Client) createZoneWithDefaults(ctx context.Context, name string) (*ZoneConfig, error) {
+	defaults := c.options.ZoneDefaults
+
+	createRequest := ZoneCreateRequest{
+		BaseRequest:             &BaseRequest{},
+		UseDefaultNameserverSet: true,
+		ZoneConfig: ZoneConfig{
+			Name:       name,
+			Type:       defaults.Type,
+			DNSSecMode: "off",
+		},
+		Records: []DNSRecord{},
+	}
+	if createRequest.ZoneConfig.Type == "" {
+		createRequest.ZoneConfig.Type = "NATIVE"
+	}
+	if defaults.SOAValues != nil {
+		values := *defaults.SOAValues
+		createRequest.ZoneConfig.SOAValues = &values
+	}
+
+	nameserverSetName := defaults.NameserverSet
+	if nameserverSetName == "" {
+		nameserverSetName = c.options.DefaultNameserverSet
+	}
+	if nameserverSetName != "" {
+		nameserverSet, err := c.getNameserverSetByName(ctx, nameserverSetName)
+		if err != nil {
+			return nil, err
+		}
+		createRequest.UseDefaultNameserverSet = false
+		createRequest.NameserverSetId = nameserverSet.ID
+	}
+
+	zone, err := c.createZone(ctx, createRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.waitForZoneActive(ctx, zone.Response.ZoneConfig.ID, defaultZoneReadyTimeout)
+}
+
 // https://www.hosting.de/api/?json#updating-zones
-func (c *Client) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
+func (c *Client) updateZone(ctx context.Context, updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
 	uri := c.baseURL + "/zoneUpdate"
 
-	updateResponse := &ZoneUpdateResponse{}
-
-	rawResp, err := c.doRequest(http.MethodPost, uri, updateRequest, updateResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, updateRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	if updateResponse.Status != "success" && updateResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	updateResponse, err := decodeEnvelope[ZoneUpdateResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		return nil, err
 	}
 
 	return updateResponse, nil
 }
 
 // https://www.hosting.de/api/?json#deleting-zones
-func (c *Client) deleteZone(deleteRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
+func (c *Client) deleteZone(ctx context.Context, deleteRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
 	uri := c.baseURL + "/zoneDelete"
 
-	deleteResponse := &ZoneDeleteResponse{}
-
-	rawResp, err := c.doRequest(http.MethodPost, uri, deleteRequest, deleteResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, deleteRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	if deleteResponse.Status != "success" && deleteResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	deleteResponse, err := decodeEnvelope[ZoneDeleteResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		return nil, err
 	}
 
 	return deleteResponse, nil
 }
 
 // https://www.hosting.de/api/?json#purging-zones
-func (c *Client) purgeZone(purgeRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
+func (c *Client) purgeZone(ctx context.Context, purgeRequest ZoneDeleteRequest) (*ZoneDeleteResponse, error) {
 	uri := c.baseURL + "/zonePurgeRestorable"
 
-	purgeResponse := &ZoneDeleteResponse{}
-
-	rawResp, err := c.doRequest(http.MethodPost, uri, purgeRequest, purgeResponse)
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, purgeRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	if purgeResponse.Status != "success" && purgeResponse.Status != "pending" {
-		return nil, errors.New(toErrorWithNewlines(uri, rawResp))
+	purgeResponse, err := decodeEnvelope[ZoneDeleteResponse](ctx, uri, rawResp, "pending")
+	if err != nil {
+		return nil, err
 	}
 
 	return purgeResponse, nil
 }
+
+// https://www.hosting.de/api/?json#retrieving-dnssec-options
+func (c *Client) getDNSSecOptions(ctx context.Context, getRequest DNSSecOptionsGetRequest) (*DNSSecOptionsGetResponse, error) {
+	uri := c.baseURL + "/zoneDnsSecOptionsGet"
+
+	rawResp, err := c.doRequest(ctx, http.MethodPost, uri, getRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	getResponse, err := decodeEnvelope[DNSSecOptionsGetResponse](ctx, uri, rawResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return getResponse, nil
+}
@@ -0,0 +1,289 @@
+package hostingde
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &zonesDataSource{}
+	_ datasource.DataSourceWithConfigure      = &zonesDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &zonesDataSource{}
+)
+
+// NewZonesDataSource is a helper function to simplify the provider implementation.
+func NewZonesDataSource() datasource.DataSource {
+	return &zonesDataSource{}
+}
+
+// zonesDataSource is the data source implementation.
+type zonesDataSource struct {
+	client *Client
+}
+
+// zonesDataSourceModel maps the data source schema data.
+type zonesDataSourceModel struct {
+	Tag           types.String         `tfsdk:"tag"`
+	CreatedAfter  types.String         `tfsdk:"created_after"`
+	CreatedBefore types.String         `tfsdk:"created_before"`
+	OwnedBy       types.String         `tfsdk:"owned_by"`
+	Zones         []zonesDataZoneModel `tfsdk:"zones"`
+}
+
+// zonesDataZoneModel maps a single zone of the data source.
+type zonesDataZoneModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	EMailAddress   types.String `tfsdk:"email"`
+	DNSServerGroup types.String `tfsdk:"dns_server_group"`
+	AddDate        types.String `tfsdk:"add_date"`
+}
+
+// Metadata returns the data source type name.
+func (d *zonesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+// Schema defines the schema for the data source.
+func (d *zonesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the DNS zones of the account.",
+		Attributes: map[string]schema.Attribute{
+			"tag": schema.StringAttribute{
+				Description: "Reserved for filtering zones by tag. The hosting.de DNS API has no tags on zones, " +
+					"so setting this attribute is an error rather than silently returning all zones.",
+				Optional: true,
+			},
+			"created_after": schema.StringAttribute{
+				Description: "Only return zones created at or after this time, as an RFC 3339 timestamp like \"2024-01-01T00:00:00Z\". " +
+					"The filter is applied by the provider after fetching all zones, as the API can't filter by creation date. " +
+					"Zones without a creation date are left out.",
+				Optional: true,
+			},
+			"created_before": schema.StringAttribute{
+				Description: "Only return zones created before this time, as an RFC 3339 timestamp. " +
+					"The filter is applied by the provider after fetching all zones, as the API can't filter by creation date. " +
+					"Zones without a creation date are left out.",
+				Optional: true,
+			},
+			"owned_by": schema.StringAttribute{
+				Description: "Only return zones containing at least one record whose comments contain this managed-by comment, " +
+					"like the managed_by_comment of the provider that created the records. Records created while no managed_by_comment was set " +
+					"have no marker and never match. Reads every record of the account, which takes several API requests for large accounts.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"zones": schema.ListNestedAttribute{
+				Description: "Zones of the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the zone.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Domain name of the zone.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "The zone type, one of NATIVE, MASTER, and SLAVE.",
+							Computed:    true,
+						},
+						"email": schema.StringAttribute{
+							Description: "The hostmaster email address.",
+							Computed:    true,
+						},
+						"dns_server_group": schema.StringAttribute{
+							Description: "ID of the DNS server group serving the zone.",
+							Computed:    true,
+						},
+						"add_date": schema.StringAttribute{
+							Description: "Time the zone was created, as reported by the API. Null if the API doesn't return it.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects filters the API doesn't support and checks the
+// format of the creation date filters.
+func (d *zonesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var configData zonesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !configData.Tag.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			"Unsupported attribute",
+			"The hosting.de DNS API does not support tags on zones, so zones cannot be filtered by tag. "+
+				"Please remove tag from the data source.",
+		)
+	}
+
+	_, _, diags := parseCreatedRange(configData)
+	resp.Diagnostics.Append(diags...)
+}
+
+// parseCreatedRange returns the bounds of the created_after and
+// created_before filters, zero if they are not set.
+func parseCreatedRange(model zonesDataSourceModel) (time.Time, time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var bounds [2]time.Time
+
+	for i, bound := range []struct {
+		name  string
+		value types.String
+	}{
+		{name: "created_after", value: model.CreatedAfter},
+		{name: "created_before", value: model.CreatedBefore},
+	} {
+		if bound.value.IsNull() || bound.value.IsUnknown() {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, bound.value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(bound.name),
+				"Invalid timestamp",
+				"The "+bound.name+" value must be an RFC 3339 timestamp like \"2024-01-01T00:00:00Z\", got: "+bound.value.ValueString(),
+			)
+			continue
+		}
+		bounds[i] = t
+	}
+
+	return bounds[0], bounds[1], diags
+}
+
+// filterZoneConfigsByAddDate returns the zone configs created in the given
+// range. Zero bounds don't restrict the range, zone configs without a
+// creation date are left out if any bound is set.
+func filterZoneConfigsByAddDate(zoneConfigs []ZoneConfig, after time.Time, before time.Time) []ZoneConfig {
+	if after.IsZero() && before.IsZero() {
+		return zoneConfigs
+	}
+
+	filtered := []ZoneConfig{}
+	for _, zoneConfig := range zoneConfigs {
+		added, err := time.Parse(time.RFC3339, zoneConfig.AddDate)
+		if err != nil {
+			continue
+		}
+		if !after.IsZero() && added.Before(after) {
+			continue
+		}
+		if !before.IsZero() && !added.Before(before) {
+			continue
+		}
+		filtered = append(filtered, zoneConfig)
+	}
+
+	return filtered
+}
+
+// filterZoneConfigsByOwner returns the zone configs with at least one record
+// whose comments contain the managed-by comment.
+func filterZoneConfigsByOwner(zoneConfigs []ZoneConfig, records []DNSRecord, ownedBy string) []ZoneConfig {
+	owned := map[string]bool{}
+	for _, record := range records {
+		if hasManagedComment(record, ownedBy) {
+			owned[record.ZoneID] = true
+		}
+	}
+
+	filtered := []ZoneConfig{}
+	for _, zoneConfig := range zoneConfigs {
+		if owned[zoneConfig.ID] {
+			filtered = append(filtered, zoneConfig)
+		}
+	}
+
+	return filtered
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zonesDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	after, before, diags := parseCreatedRange(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneConfigs, err := d.client.listAllZoneConfigs(ctx, FilterOrChain{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading hosting.de DNS zones",
+			"Could not list hosting.de DNS zones: "+err.Error(),
+		)
+		return
+	}
+
+	zoneConfigs = filterZoneConfigsByAddDate(zoneConfigs, after, before)
+
+	if !state.OwnedBy.IsNull() {
+		records, err := d.client.listAllRecords(ctx, FilterOrChain{})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zone records",
+				"Could not list records of the hosting.de DNS zones: "+err.Error(),
+			)
+			return
+		}
+		zoneConfigs = filterZoneConfigsByOwner(zoneConfigs, records, state.OwnedBy.ValueString())
+	}
+
+	state.Zones = []zonesDataZoneModel{}
+	for _, zoneConfig := range zoneConfigs {
+		state.Zones = append(state.Zones, zonesDataZoneModel{
+			ID:             types.StringValue(zoneConfig.ID),
+			Name:           types.StringValue(zoneConfig.Name),
+			Type:           types.StringValue(zoneConfig.Type),
+			EMailAddress:   types.StringValue(zoneConfig.EMailAddress),
+			DNSServerGroup: types.StringValue(zoneConfig.DNSServerGroupID),
+			AddDate:        recordDate(zoneConfig.AddDate),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zonesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
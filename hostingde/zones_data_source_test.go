@@ -0,0 +1,130 @@
+package hostingde
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccZonesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Filtering by tag is rejected
+			{
+				Config: providerConfig + `
+data "hostingde_zones" "test" {
+  tag = "production"
+}
+`,
+				ExpectError: regexp.MustCompile("does not support tags on zones"),
+			},
+			// Creation dates must be RFC 3339 timestamps
+			{
+				Config: providerConfig + `
+data "hostingde_zones" "test" {
+  created_after = "2024-01-01"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid timestamp"),
+			},
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "hostingde_zone" "test" {
+  name = "example5.test"
+  type = "NATIVE"
+  email = "hostmaster@example5.test"
+}
+data "hostingde_zones" "test" {
+  depends_on = [hostingde_zone.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify the created zone is listed.
+					resource.TestCheckTypeSetElemNestedAttrs("data.hostingde_zones.test", "zones.*", map[string]string{
+						"name": "example5.test",
+						"type": "NATIVE",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestListAllZoneConfigsEmptyAccount(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, _ []byte) any {
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			return findResponse
+		},
+	})
+
+	zoneConfigs, err := client.listAllZoneConfigs(context.Background(), FilterOrChain{})
+	if err != nil {
+		t.Fatalf("listAllZoneConfigs returned an error: %v", err)
+	}
+	if len(zoneConfigs) != 0 {
+		t.Errorf("got %d zone configs, want none", len(zoneConfigs))
+	}
+}
+
+func TestFilterZoneConfigsByAddDate(t *testing.T) {
+	zoneConfigs := []ZoneConfig{
+		{ID: "1", AddDate: "2023-06-01T10:00:00Z"},
+		{ID: "2", AddDate: "2024-01-01T00:00:00Z"},
+		{ID: "3", AddDate: "2024-06-01T10:00:00+02:00"},
+		{ID: "4"},
+	}
+	date := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("could not parse %s: %v", value, err)
+		}
+		return parsed
+	}
+
+	for _, tc := range []struct {
+		name   string
+		after  time.Time
+		before time.Time
+		want   []string
+	}{
+		{name: "no bounds", want: []string{"1", "2", "3", "4"}},
+		// The lower bound is inclusive, the upper bound exclusive
+		{name: "after", after: date("2024-01-01T00:00:00Z"), want: []string{"2", "3"}},
+		{name: "before", before: date("2024-01-01T00:00:00Z"), want: []string{"1"}},
+		{name: "range", after: date("2023-01-01T00:00:00Z"), before: date("2024-06-01T08:00:00Z"), want: []string{"1", "2"}},
+	} {
+		got := []string{}
+		for _, zoneConfig := range filterZoneConfigsByAddDate(zoneConfigs, tc.after, tc.before) {
+			got = append(got, zoneConfig.ID)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s: got zones %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFilterZoneConfigsByOwner(t *testing.T) {
+	zoneConfigs := []ZoneConfig{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	records := []DNSRecord{
+		{ZoneID: "1", Comments: "team-a"},
+		{ZoneID: "1", Comments: "team-b"},
+		{ZoneID: "2", Comments: "team-b"},
+		{ZoneID: "3"},
+	}
+
+	got := []string{}
+	for _, zoneConfig := range filterZoneConfigsByOwner(zoneConfigs, records, "team-b") {
+		got = append(got, zoneConfig.ID)
+	}
+	if want := []string{"1", "2"}; !slices.Equal(got, want) {
+		t.Errorf("got zones %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,295 @@
+package hostingde
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultZonesRecordsConcurrency is the number of zones whose records are
+// read at the same time if max_concurrent_requests of the provider is
+// unlimited.
+const defaultZonesRecordsConcurrency = 4
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &zonesRecordsDataSource{}
+	_ datasource.DataSourceWithConfigure = &zonesRecordsDataSource{}
+)
+
+// NewZonesRecordsDataSource is a helper function to simplify the provider implementation.
+func NewZonesRecordsDataSource() datasource.DataSource {
+	return &zonesRecordsDataSource{}
+}
+
+// zonesRecordsDataSource is the data source implementation.
+type zonesRecordsDataSource struct {
+	client *Client
+}
+
+// zonesRecordsDataSourceModel maps the data source schema data.
+type zonesRecordsDataSourceModel struct {
+	ZoneNames  []string                         `tfsdk:"zone_names"`
+	NameFilter types.String                     `tfsdk:"name_filter"`
+	Zones      map[string]zonesRecordsZoneModel `tfsdk:"zones"`
+}
+
+// zonesRecordsZoneModel maps a single zone of the data source.
+type zonesRecordsZoneModel struct {
+	ID      types.String             `tfsdk:"id"`
+	Error   types.String             `tfsdk:"error"`
+	Records []zoneRecordsRecordModel `tfsdk:"records"`
+}
+
+// Metadata returns the data source type name.
+func (d *zonesRecordsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones_records"
+}
+
+// Schema defines the schema for the data source.
+func (d *zonesRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the records of several DNS zones, including records not managed by Terraform. " +
+			"The zones are read in parallel, as many at a time as the provider's max_concurrent_requests allows, or 4 if it is unlimited. " +
+			"A zone that can't be read causes a warning and has its error set, the other zones are still returned.",
+		Attributes: map[string]schema.Attribute{
+			"zone_names": schema.ListAttribute{
+				Description: "Domain names of the zones to read. Exactly one of zone_names or name_filter must be set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ExactlyOneOf(path.MatchRoot("name_filter")),
+				},
+			},
+			"name_filter": schema.StringAttribute{
+				Description: "Read all zones whose name matches this filter of the hosting.de API, where * matches any text, like \"*.example.com\". " +
+					"Failing to list the zones fails the read. Exactly one of zone_names or name_filter must be set.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"zones": schema.MapNestedAttribute{
+				Description: "Zones by domain name, as given in zone_names or as returned by the API for name_filter.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Numeric identifier of the zone. Null if the zone wasn't found.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "Why the zone or its records couldn't be read, like a zone that doesn't exist. Null if the zone was read.",
+							Computed:    true,
+						},
+						"records": schema.ListNestedAttribute{
+							Description: "Records of the zone. Empty if the zone couldn't be read.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Description: "Numeric identifier of the record.",
+										Computed:    true,
+									},
+									"name": schema.StringAttribute{
+										Description: "Name of the record.",
+										Computed:    true,
+									},
+									"type": schema.StringAttribute{
+										Description: "Type of the record.",
+										Computed:    true,
+									},
+									"content": schema.StringAttribute{
+										Description: "Content of the record, without the priority.",
+										Computed:    true,
+									},
+									"ttl": schema.Int64Attribute{
+										Description: "TTL of the record in seconds.",
+										Computed:    true,
+									},
+									"priority": schema.Int64Attribute{
+										Description: "Priority of the record. Zero for types without a priority.",
+										Computed:    true,
+									},
+									"read_only": schema.BoolAttribute{
+										Description: "Whether hosting.de manages the record for the zone itself, i.e. the SOA record and the NS records at the apex.",
+										Computed:    true,
+									},
+									"add_date": schema.StringAttribute{
+										Description: "Time the record was created, as reported by the API. Null if the API doesn't return it.",
+										Computed:    true,
+									},
+									"last_change_date": schema.StringAttribute{
+										Description: "Time of the last change to the record, as reported by the API. Null if the API doesn't return it.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *zonesRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, warnings := withClientWarnings(ctx)
+	defer d.client.strictWarnings(&resp.Diagnostics)
+	defer warnings.appendTo(&resp.Diagnostics)
+
+	var state zonesRecordsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Zones listed by the filter are known, named zones are looked up along
+	// with their records
+	var zoneConfigs []ZoneConfig
+	if !state.NameFilter.IsNull() {
+		var err error
+		zoneConfigs, err = d.client.listAllZoneConfigs(ctx, FilterOrChain{Filter: Filter{
+			Field: "ZoneName",
+			Value: state.NameFilter.ValueString(),
+		}})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading hosting.de DNS zones",
+				"Could not list hosting.de DNS zones matching "+state.NameFilter.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	} else {
+		for _, name := range state.ZoneNames {
+			zoneConfigs = append(zoneConfigs, ZoneConfig{Name: name})
+		}
+	}
+
+	state.Zones = d.readZonesRecords(ctx, zoneConfigs)
+
+	names := make([]string, 0, len(state.Zones))
+	for name := range state.Zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if zone := state.Zones[name]; !zone.Error.IsNull() {
+			resp.Diagnostics.AddWarning(
+				"Error Reading hosting.de DNS zone records",
+				"Could not read records of hosting.de DNS zone "+name+", its error attribute is set: "+zone.Error.ValueString(),
+			)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readZonesRecords reads the records of the zones in parallel, bounded by
+// the request limit of the client. Zones without an ID are looked up by
+// name first. Errors are recorded per zone.
+func (d *zonesRecordsDataSource) readZonesRecords(ctx context.Context, zoneConfigs []ZoneConfig) map[string]zonesRecordsZoneModel {
+	concurrency := d.client.options.MaxConcurrentRequests
+	if concurrency <= 0 {
+		concurrency = defaultZonesRecordsConcurrency
+	}
+
+	var mu sync.Mutex
+	zones := make(map[string]zonesRecordsZoneModel, len(zoneConfigs))
+	var group errgroup.Group
+	group.SetLimit(concurrency)
+	for _, zoneConfig := range zoneConfigs {
+		zoneConfig := zoneConfig
+		group.Go(func() error {
+			zone := d.readZoneRecords(ctx, zoneConfig)
+
+			mu.Lock()
+			defer mu.Unlock()
+			zones[zoneConfig.Name] = zone
+
+			return nil
+		})
+	}
+	// Errors are recorded per zone, the group never fails
+	_ = group.Wait()
+
+	return zones
+}
+
+// readZoneRecords reads the records of a single zone.
+func (d *zonesRecordsDataSource) readZoneRecords(ctx context.Context, zoneConfig ZoneConfig) zonesRecordsZoneModel {
+	zone := zonesRecordsZoneModel{
+		ID:      types.StringNull(),
+		Error:   types.StringNull(),
+		Records: []zoneRecordsRecordModel{},
+	}
+
+	if zoneConfig.ID == "" {
+		found, err := d.client.getZoneConfigByName(ctx, strings.TrimSuffix(zoneConfig.Name, "."))
+		if err != nil {
+			zone.Error = types.StringValue(err.Error())
+			return zone
+		}
+		zoneConfig.ID = found.ID
+		zoneConfig.Name = found.Name
+	}
+	zone.ID = types.StringValue(zoneConfig.ID)
+
+	records, err := d.client.listAllRecords(ctx, FilterOrChain{Filter: Filter{
+		Field: "ZoneConfigId",
+		Value: zoneConfig.ID,
+	}})
+	if err != nil {
+		zone.Error = types.StringValue(err.Error())
+		return zone
+	}
+	warnUnknownRecordTypes(ctx, records)
+	tflog.Debug(ctx, "Read records of zone", map[string]any{
+		"hostingde_zone_config_id": zoneConfig.ID,
+		"hostingde_record_count":   len(records),
+	})
+
+	for _, record := range records {
+		content, priority := splitPriority(record)
+		zone.Records = append(zone.Records, zoneRecordsRecordModel{
+			ID:       types.StringValue(record.ID),
+			Name:     types.StringValue(record.Name),
+			Type:     types.StringValue(record.Type),
+			Content:  types.StringValue(content),
+			TTL:      types.Int64Value(int64(record.TTL)),
+			Priority: types.Int64Value(priority),
+			ReadOnly: types.BoolValue(isSystemRecord(record, zoneConfig.Name)),
+
+			AddDate:        recordDate(record.AddDate),
+			LastChangeDate: recordDate(record.LastChangeDate),
+		})
+	}
+
+	return zone
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *zonesRecordsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*Client)
+}
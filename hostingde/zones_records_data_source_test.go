@@ -0,0 +1,54 @@
+package hostingde
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestZonesRecordsDataSourceReadZonesRecords(t *testing.T) {
+	client := newTestClient(t, map[string]testHandler{
+		"/zoneConfigsFind": func(t *testing.T, body []byte) any {
+			var request ZoneConfigsFindRequest
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Fatalf("invalid request body: %v", err)
+			}
+			findResponse := ZoneConfigsFindResponse{}
+			findResponse.Status = "success"
+			if request.Filter.Value == "example.test" {
+				findResponse.Response.Data = []ZoneConfig{{ID: "1", Name: "example.test"}}
+				findResponse.Response.TotalEntries = 1
+			}
+			return findResponse
+		},
+		"/recordsFind": func(t *testing.T, _ []byte) any {
+			findResponse := RecordsFindResponse{}
+			findResponse.Status = "success"
+			findResponse.Response.Data = []DNSRecord{
+				{ID: "10", ZoneID: "1", Name: "example.test", Type: "SOA", Content: "ns1.example.test. hostmaster.example.test. 1 2 3 4 5"},
+				{ID: "11", ZoneID: "1", Name: "www.example.test", Type: "A", Content: "192.0.2.1", TTL: 3600},
+			}
+			findResponse.Response.TotalEntries = 2
+			return findResponse
+		},
+	})
+
+	d := &zonesRecordsDataSource{client: client}
+	zones := d.readZonesRecords(context.Background(), []ZoneConfig{{Name: "example.test"}, {Name: "missing.test"}})
+
+	// A missing zone doesn't fail the other zones
+	if len(zones) != 2 {
+		t.Fatalf("got %d zones, want 2: %v", len(zones), zones)
+	}
+	zone := zones["example.test"]
+	if !zone.Error.IsNull() || zone.ID.ValueString() != "1" {
+		t.Errorf("got zone example.test with ID %s and error %s, want ID 1 without error", zone.ID, zone.Error)
+	}
+	if len(zone.Records) != 2 || !zone.Records[0].ReadOnly.ValueBool() || zone.Records[1].Content.ValueString() != "192.0.2.1" {
+		t.Errorf("got records %v, want the SOA and the www record", zone.Records)
+	}
+	missing := zones["missing.test"]
+	if missing.Error.IsNull() || !missing.ID.IsNull() || len(missing.Records) != 0 {
+		t.Errorf("got zone missing.test with ID %s, error %s and %d records, want only an error", missing.ID, missing.Error, len(missing.Records))
+	}
+}